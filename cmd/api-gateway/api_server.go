@@ -2,18 +2,23 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"mygocache/consistenthash"
 	"mygocache/kitex_gen/geecache"
 	"mygocache/kitex_gen/geecache/groupcache"
 
 	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/client/callopt"
 )
 
 // APIServer HTTP API 网关
@@ -74,6 +79,42 @@ func (s *APIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.handleDelete(w, r)
 	case "stats":
 		s.handleStats(w, r)
+	case "ready":
+		s.handleReady(w, r)
+	case "hotkeys":
+		s.handleHotKeys(w, r)
+	case "gets":
+		s.handleGetWithVersion(w, r)
+	case "cas":
+		s.handleCompareAndSet(w, r)
+	case "cad":
+		s.handleCompareAndDelete(w, r)
+	case "incr":
+		s.handleIncr(w, r, 1)
+	case "decr":
+		s.handleIncr(w, r, -1)
+	case "scan":
+		s.handleScan(w, r)
+	case "invalidate":
+		s.handleInvalidateTag(w, r)
+	case "bump":
+		s.handleBumpGeneration(w, r)
+	case "watch":
+		s.handleWatch(w, r)
+	case "hset":
+		s.handleHSet(w, r)
+	case "hget":
+		s.handleHGet(w, r)
+	case "hdel":
+		s.handleHDel(w, r)
+	case "hgetall":
+		s.handleHGetAll(w, r)
+	case "hincrby":
+		s.handleHIncrBy(w, r)
+	case "publish":
+		s.handlePublish(w, r)
+	case "subscribe":
+		s.handleSubscribe(w, r)
 	default:
 		http.Error(w, "unknown endpoint", http.StatusNotFound)
 	}
@@ -124,6 +165,8 @@ func (s *APIServer) handleGet(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleSet 处理 SET 请求
+// 查询参数 nx=true 表示仅当 key 不存在时写入，xx=true 表示仅当 key 已存在时写入，
+// 可重复的 tag 参数为值附加标签
 func (s *APIServer) handleSet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost && r.Method != http.MethodPut {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -141,6 +184,13 @@ func (s *APIServer) handleSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	nx, _ := strconv.ParseBool(r.URL.Query().Get("nx"))
+	xx, _ := strconv.ParseBool(r.URL.Query().Get("xx"))
+	if nx && xx {
+		http.Error(w, "nx and xx are mutually exclusive", http.StatusBadRequest)
+		return
+	}
+
 	// 读取请求体作为 value
 	value, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -168,10 +218,26 @@ func (s *APIServer) handleSet(w http.ResponseWriter, r *http.Request) {
 		Key:   key,
 		Value: value,
 		Ttl:   0, // 默认不过期
+		Tags:  r.URL.Query()["tag"],
 	}
 
-	resp, err := client.Set(context.Background(), req)
-	if err != nil || !resp.Success {
+	var resp *geecache.SetResponse
+	switch {
+	case nx:
+		resp, err = client.Add(context.Background(), req)
+	case xx:
+		resp, err = client.Replace(context.Background(), req)
+	default:
+		resp, err = client.Set(context.Background(), req)
+	}
+	switch {
+	case err == nil && !resp.Success && nx:
+		http.Error(w, "key already exists", http.StatusConflict)
+		return
+	case err == nil && !resp.Success && xx:
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	case err != nil || !resp.Success:
 		log.Printf("[API] failed to set %s: %v", key, err)
 		http.Error(w, "set failed", http.StatusInternalServerError)
 		return
@@ -255,8 +321,931 @@ func (s *APIServer) handleStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	fmt.Fprintf(w, `{"item_count":%d,"hit_count":%d,"miss_count":%d,"total_count":%d}`,
-		resp.ItemCount, resp.HitCount, resp.MissCount, resp.TotalCount)
+	fmt.Fprintf(w, `{"item_count":%d,"hit_count":%d,"miss_count":%d,"total_count":%d,"integrity_failures":%d,"ready":%t}`,
+		resp.ItemCount, resp.HitCount, resp.MissCount, resp.TotalCount, resp.IntegrityFailures, resp.Ready)
+}
+
+// handleReady 查询所有节点是否已完成预热，任一节点未就绪或不可达时返回 503
+func (s *APIServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	nodes := make(map[string]bool, len(s.nodeAddrs))
+	allReady := len(s.nodeAddrs) > 0
+	for _, node := range s.nodeAddrs {
+		resp, err := s.clients[node].Stats(context.Background(), &geecache.StatsRequest{Group: group})
+		if err != nil {
+			log.Printf("[API] failed to get stats from %s: %v", node, err)
+		}
+		nodes[node] = err == nil && resp.Ready
+		allReady = allReady && nodes[node]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !allReady {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"ready": allReady, "nodes": nodes})
+}
+
+// hotKey 是 /hotkeys 返回的一个热点 key，计数与 QPS 为各节点之和
+type hotKey struct {
+	Key   string           `json:"key"`
+	Count int64            `json:"count"`
+	QPS   float64          `json:"qps"`
+	Nodes map[string]int64 `json:"nodes"`
+}
+
+// handleHotKeys 汇总所有节点统计的热点 key，按估计访问次数降序返回前 n 个。
+// 每个节点返回其跟踪的全部 key 后再合并，不可达或未启用统计的节点列在 unavailable 中
+func (s *APIServer) handleHotKeys(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	n := 10
+	if v := r.URL.Query().Get("n"); v != "" {
+		var err error
+		if n, err = strconv.Atoi(v); err != nil || n <= 0 {
+			http.Error(w, "invalid n", http.StatusBadRequest)
+			return
+		}
+	}
+
+	merged := make(map[string]*hotKey)
+	unavailable := []string{}
+	for _, node := range s.nodeAddrs {
+		resp, err := s.clients[node].HotKeys(context.Background(), &geecache.HotKeysRequest{Group: group})
+		if err != nil || !resp.Enabled {
+			if err != nil {
+				log.Printf("[API] failed to get hot keys from %s: %v", node, err)
+			}
+			unavailable = append(unavailable, node)
+			continue
+		}
+		for _, k := range resp.Keys {
+			h := merged[k.Key]
+			if h == nil {
+				h = &hotKey{Key: k.Key, Nodes: make(map[string]int64)}
+				merged[k.Key] = h
+			}
+			h.Count += k.Count
+			h.QPS += k.Qps
+			h.Nodes[node] = k.Count
+		}
+	}
+
+	keys := make([]*hotKey, 0, len(merged))
+	for _, h := range merged {
+		keys = append(keys, h)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Count != keys[j].Count {
+			return keys[i].Count > keys[j].Count
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Keys        []*hotKey `json:"keys"`
+		Unavailable []string  `json:"unavailable"`
+	}{keys, unavailable})
+}
+
+// pickClient 使用一致性哈希选择 key 所属节点的客户端，失败时写入错误响应并返回 nil
+func (s *APIServer) pickClient(w http.ResponseWriter, key string) groupcache.Client {
+	nodeAddr := s.hashRing.Get(key)
+	if nodeAddr == "" {
+		http.Error(w, "no available cache nodes", http.StatusServiceUnavailable)
+		return nil
+	}
+
+	client := s.clients[nodeAddr]
+	if client == nil {
+		http.Error(w, "selected node not available", http.StatusServiceUnavailable)
+		return nil
+	}
+	return client
+}
+
+// handleGetWithVersion 处理 GETS 请求，通过 X-Cache-Version 响应头返回版本号
+func (s *APIServer) handleGetWithVersion(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.GetWithVersion(context.Background(), &geecache.Request{
+		Group: group,
+		Key:   key,
+	})
+	if err != nil {
+		log.Printf("[API] failed to gets %s: %v", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("X-Cache-Version", strconv.FormatInt(resp.Version, 10))
+	w.Write(resp.Value)
+}
+
+// handleCompareAndSet 处理 CAS 请求，版本冲突时返回 409
+func (s *APIServer) handleCompareAndSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	var ttl int64
+	if v := r.URL.Query().Get("ttl"); v != "" {
+		if ttl, err = strconv.ParseInt(v, 10, 64); err != nil {
+			http.Error(w, "invalid ttl", http.StatusBadRequest)
+			return
+		}
+	}
+
+	value, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.CompareAndSet(context.Background(), &geecache.CompareAndSetRequest{
+		Group:   group,
+		Key:     key,
+		Value:   value,
+		Version: version,
+		Ttl:     ttl,
+	})
+	if err != nil {
+		log.Printf("[API] failed to cas %s: %v", key, err)
+		http.Error(w, "cas failed", http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case resp.Success:
+		log.Printf("[API] cas %s => version %d", key, resp.Version)
+		w.Header().Set("X-Cache-Version", strconv.FormatInt(resp.Version, 10))
+		fmt.Fprintf(w, "OK")
+	case resp.Conflict:
+		w.Header().Set("X-Cache-Version", strconv.FormatInt(resp.Version, 10))
+		http.Error(w, "version conflict", http.StatusConflict)
+	default:
+		http.Error(w, "key not found", http.StatusNotFound)
+	}
+}
+
+// handleCompareAndDelete 处理 CAD 请求，版本冲突时返回 409
+func (s *APIServer) handleCompareAndDelete(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.CompareAndDelete(context.Background(), &geecache.CompareAndDeleteRequest{
+		Group:   group,
+		Key:     key,
+		Version: version,
+	})
+	if err != nil {
+		log.Printf("[API] failed to cad %s: %v", key, err)
+		http.Error(w, "cad failed", http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case resp.Success:
+		log.Printf("[API] cad %s", key)
+		fmt.Fprintf(w, "OK")
+	case resp.Conflict:
+		http.Error(w, "version conflict", http.StatusConflict)
+	default:
+		http.Error(w, "key not found", http.StatusNotFound)
+	}
+}
+
+// handleIncr 处理 INCR/DECR 请求，sign 为 -1 时表示递减
+// 可选参数：delta（默认 1）、initial（key 不存在时的初始值，默认 0）、ttl（仅新建时生效）
+func (s *APIServer) handleIncr(w http.ResponseWriter, r *http.Request, sign int64) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]int64{"delta": 1, "initial": 0, "ttl": 0}
+	for name := range params {
+		if v := r.URL.Query().Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid "+name, http.StatusBadRequest)
+				return
+			}
+			params[name] = n
+		}
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.Incr(context.Background(), &geecache.IncrRequest{
+		Group:   group,
+		Key:     key,
+		Delta:   sign * params["delta"],
+		Initial: params["initial"],
+		Ttl:     params["ttl"],
+	})
+	if err != nil {
+		log.Printf("[API] failed to incr %s: %v", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "%d", resp.Value)
+}
+
+// handleHSet 处理 HSET 请求，请求体为字段名到字段值的 JSON 对象，可选参数 ttl 仅在新建哈希时生效
+func (s *APIServer) handleHSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	var ttl int64
+	if v := r.URL.Query().Get("ttl"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid ttl", http.StatusBadRequest)
+			return
+		}
+		ttl = n
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body) == 0 {
+		http.Error(w, "body must be a non-empty JSON object of fields", http.StatusBadRequest)
+		return
+	}
+	fields := make(map[string][]byte, len(body))
+	for field, value := range body {
+		fields[field] = []byte(value)
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.HSet(context.Background(), &geecache.HSetRequest{
+		Group:  group,
+		Key:    key,
+		Fields: fields,
+		Ttl:    ttl,
+	})
+	if err != nil {
+		log.Printf("[API] failed to hset %s: %v", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"added":%d}`, resp.Added)
+}
+
+// handleHGet 处理 HGET 请求，返回字段值，key 或字段不存在时返回 404
+func (s *APIServer) handleHGet(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	field := r.URL.Query().Get("field")
+	if key == "" || field == "" {
+		http.Error(w, "key and field are required", http.StatusBadRequest)
+		return
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.HGet(context.Background(), &geecache.HGetRequest{
+		Group: group,
+		Key:   key,
+		Field: field,
+	})
+	if err != nil {
+		log.Printf("[API] failed to hget %s.%s: %v", key, field, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !resp.Found {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(resp.Value)
+}
+
+// handleHDel 处理 HDEL 请求，可重复的 field 参数指定要删除的字段
+func (s *APIServer) handleHDel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	fields := r.URL.Query()["field"]
+	if key == "" || len(fields) == 0 {
+		http.Error(w, "key and field are required", http.StatusBadRequest)
+		return
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.HDel(context.Background(), &geecache.HDelRequest{
+		Group:  group,
+		Key:    key,
+		Fields: fields,
+	})
+	if err != nil {
+		log.Printf("[API] failed to hdel %s: %v", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"removed":%d}`, resp.Removed)
+}
+
+// handleHGetAll 处理 HGETALL 请求，以 JSON 对象返回所有字段，key 不存在时返回 404
+func (s *APIServer) handleHGetAll(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.HGetAll(context.Background(), &geecache.Request{
+		Group: group,
+		Key:   key,
+	})
+	if err != nil {
+		log.Printf("[API] failed to hgetall %s: %v", key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !resp.Found {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+
+	fields := make(map[string]string, len(resp.Fields))
+	for field, value := range resp.Fields {
+		fields[field] = string(value)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(fields)
+}
+
+// handleHIncrBy 处理 HINCRBY 请求
+// 可选参数：delta（默认 1）、ttl（仅新建哈希时生效）
+func (s *APIServer) handleHIncrBy(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	field := r.URL.Query().Get("field")
+	if key == "" || field == "" {
+		http.Error(w, "key and field are required", http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]int64{"delta": 1, "ttl": 0}
+	for name := range params {
+		if v := r.URL.Query().Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid "+name, http.StatusBadRequest)
+				return
+			}
+			params[name] = n
+		}
+	}
+
+	client := s.pickClient(w, key)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.HIncrBy(context.Background(), &geecache.HIncrByRequest{
+		Group: group,
+		Key:   key,
+		Field: field,
+		Delta: params["delta"],
+		Ttl:   params["ttl"],
+	})
+	if err != nil {
+		log.Printf("[API] failed to hincrby %s.%s: %v", key, field, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "%d", resp.Value)
+}
+
+// handleScan 处理 SCAN 请求，按节点顺序依次遍历所有缓存节点上的键
+// 游标格式为 "<节点序号>.<节点游标>"，返回的游标为空时遍历结束，一页的键可能为空
+func (s *APIServer) handleScan(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	count := 10
+	if v := r.URL.Query().Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid count", http.StatusBadRequest)
+			return
+		}
+		count = n
+	}
+
+	node, nodeCursor := 0, ""
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		parts := strings.SplitN(cursor, ".", 2)
+		n, err := strconv.Atoi(parts[0])
+		if err != nil || n < 0 || n >= len(s.nodeAddrs) || len(parts) != 2 {
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+		node, nodeCursor = n, parts[1]
+	}
+
+	// 与节点上的 Scan 一样，每次请求只向一个节点检查至多 count 个条目，返回的键可能少于 count 个
+	keys := []string{}
+	if node < len(s.nodeAddrs) {
+		client := s.clients[s.nodeAddrs[node]]
+		if client == nil {
+			http.Error(w, "selected node not available", http.StatusServiceUnavailable)
+			return
+		}
+		resp, err := client.Scan(context.Background(), &geecache.ScanRequest{
+			Group:   group,
+			Cursor:  nodeCursor,
+			Pattern: r.URL.Query().Get("match"),
+			Count:   int32(count),
+		})
+		if err != nil {
+			log.Printf("[API] failed to scan %s: %v", s.nodeAddrs[node], err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		keys = append(keys, resp.Keys...)
+		nodeCursor = resp.Cursor
+		if nodeCursor == "" {
+			node++
+		}
+	}
+
+	next := ""
+	if node < len(s.nodeAddrs) {
+		next = strconv.Itoa(node) + "." + nodeCursor
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Keys   []string `json:"keys"`
+		Cursor string   `json:"cursor"`
+	}{keys, next})
+}
+
+// handleInvalidateTag 处理按标签失效请求，由接收请求的节点向整个集群广播
+func (s *APIServer) handleInvalidateTag(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	tag := r.URL.Query().Get("tag")
+	if tag == "" {
+		http.Error(w, "tag is required", http.StatusBadRequest)
+		return
+	}
+
+	// 任意节点都可以发起广播，按标签哈希选择以分散负载
+	client := s.pickClient(w, tag)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.InvalidateTag(context.Background(), &geecache.InvalidateTagRequest{
+		Group:  group,
+		Tag:    tag,
+		Fanout: true,
+	})
+	if err != nil {
+		log.Printf("[API] failed to invalidate tag %s: %v", tag, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[API] invalidated tag %s (%d keys)", tag, resp.Removed)
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"removed":%d}`, resp.Removed)
+}
+
+// handleBumpGeneration 处理代数递增请求，namespace 为空时作废整个组，由接收请求的节点向整个集群广播
+func (s *APIServer) handleBumpGeneration(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+	namespace := r.URL.Query().Get("namespace")
+
+	client := s.pickClient(w, group+"/"+namespace)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.BumpGeneration(context.Background(), &geecache.BumpGenerationRequest{
+		Group:     group,
+		Namespace: namespace,
+		Fanout:    true,
+	})
+	if err != nil {
+		log.Printf("[API] failed to bump generation of %s/%s: %v", group, namespace, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("[API] bumped generation of %s/%s to %d", group, namespace, resp.Generation)
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"generation":%d}`, resp.Generation)
+}
+
+// watchEvent 是 /watch 响应中的单个变更事件，值可能是任意二进制数据，以 base64 编码
+type watchEvent struct {
+	Seq   int64  `json:"seq"`
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value []byte `json:"value"`
+}
+
+// watchResult 是单个节点的长轮询结果
+type watchResult struct {
+	node int
+	resp *geecache.WatchResponse
+	err  error
+}
+
+// handleWatch 处理长轮询订阅请求：key 以 * 结尾时按前缀订阅所有节点，否则只订阅 key 所属节点。
+// cursor 为上一次响应返回的游标（前缀订阅时为逗号分隔的各节点序号），首次请求留空；
+// timeout 为最长等待秒数，默认 30 秒
+func (s *APIServer) handleWatch(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		group = "scores"
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	timeout := 30 * time.Second
+	if v := r.URL.Query().Get("timeout"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid timeout", http.StatusBadRequest)
+			return
+		}
+		timeout = time.Duration(n) * time.Second
+	}
+
+	// 精确订阅只涉及 key 所属节点，前缀订阅涉及所有节点
+	nodes := s.nodeAddrs
+	if !strings.HasSuffix(key, "*") {
+		nodes = []string{s.hashRing.Get(key)}
+	}
+
+	cursors := make([]int64, len(nodes))
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		parts := strings.Split(cursor, ",")
+		if len(parts) != len(nodes) {
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+		for i, part := range parts {
+			n, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || n < 0 {
+				http.Error(w, "invalid cursor", http.StatusBadRequest)
+				return
+			}
+			cursors[i] = n
+		}
+	}
+
+	clients := make([]groupcache.Client, len(nodes))
+	for i, node := range nodes {
+		if clients[i] = s.clients[node]; clients[i] == nil {
+			http.Error(w, "selected node not available", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	events := make([]watchEvent, 0)
+	truncated := false
+	addEvents := func(resp *geecache.WatchResponse) {
+		truncated = truncated || resp.Truncated
+		for _, e := range resp.Events {
+			events = append(events, watchEvent{e.Seq, e.Key, e.Type, e.Value})
+		}
+	}
+
+	// 前缀订阅在任一节点返回事件时结束本次轮询，其余节点保留发起轮询时的游标。
+	// 游标为 0 表示"从当前位置开始"，因此先取得每个节点的当前序号，避免两次轮询之间的事件丢失
+	if len(nodes) > 1 {
+		for i, client := range clients {
+			if cursors[i] != 0 {
+				continue
+			}
+			resp, err := client.Watch(ctx, &geecache.WatchRequest{Group: group, Key: key, TimeoutMs: 1})
+			if err != nil {
+				log.Printf("[API] failed to watch %s on %s: %v", key, nodes[i], err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			cursors[i] = resp.Next
+			addEvents(resp)
+		}
+	}
+
+	results := make(chan watchResult, len(nodes))
+	pending := 0
+	for i, client := range clients {
+		if len(events) > 0 || truncated {
+			break
+		}
+		pending++
+		go func(i int, client groupcache.Client) {
+			resp, err := client.Watch(ctx, &geecache.WatchRequest{
+				Group:     group,
+				Key:       key,
+				Since:     cursors[i],
+				TimeoutMs: int32(timeout / time.Millisecond),
+			}, callopt.WithRPCTimeout(timeout+5*time.Second))
+			results <- watchResult{i, resp, err}
+		}(i, client)
+	}
+
+	// 任一节点返回事件即结束本次轮询；未返回的节点保留原游标，下次轮询时补齐
+	for ; pending > 0 && len(events) == 0 && !truncated; pending-- {
+		res := <-results
+		if res.err != nil {
+			log.Printf("[API] failed to watch %s on %s: %v", key, nodes[res.node], res.err)
+			http.Error(w, res.err.Error(), http.StatusInternalServerError)
+			return
+		}
+		cursors[res.node] = res.resp.Next
+		addEvents(res.resp)
+	}
+
+	next := make([]string, len(cursors))
+	for i, c := range cursors {
+		next[i] = strconv.FormatInt(c, 10)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Events    []watchEvent `json:"events"`
+		Cursor    string       `json:"cursor"`
+		Truncated bool         `json:"truncated"`
+	}{events, strings.Join(next, ","), truncated})
+}
+
+// handlePublish 处理发布请求，请求体为消息内容，由频道所属节点投递给其订阅者
+func (s *APIServer) handlePublish(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	channel := r.URL.Query().Get("channel")
+	if channel == "" {
+		http.Error(w, "channel is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	client := s.pickClient(w, channel)
+	if client == nil {
+		return
+	}
+
+	resp, err := client.Publish(context.Background(), &geecache.PublishRequest{
+		Channel: channel,
+		Payload: payload,
+	})
+	if err != nil {
+		log.Printf("[API] failed to publish to %s: %v", channel, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"receivers":%d}`, resp.Receivers)
+}
+
+// subscribePollTimeout 网关向节点长轮询订阅消息的单次等待时间
+const subscribePollTimeout = 25 * time.Second
+
+// handleSubscribe 以 Server-Sent Events 推送频道消息，网关代替浏览器向频道所属节点长轮询。
+// 消息至多投递一次：节点端队列已满而丢弃的消息以 dropped 事件报告丢弃数量，
+// 节点端会话失效（如节点重启）后以 reset 事件提示期间的消息可能已丢失
+func (s *APIServer) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	channel := r.URL.Query().Get("channel")
+	if channel == "" {
+		http.Error(w, "channel is required", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client := s.pickClient(w, channel)
+	if client == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := r.Context()
+	subscription := ""
+	defer func() {
+		if subscription == "" {
+			return
+		}
+		// 请求的 ctx 已结束，使用新的 ctx 关闭节点端会话
+		if _, err := client.Unsubscribe(context.Background(), &geecache.UnsubscribeRequest{Subscription: subscription}); err != nil {
+			log.Printf("[API] failed to unsubscribe %s: %v", channel, err)
+		}
+	}()
+
+	for {
+		resp, err := client.Subscribe(ctx, &geecache.SubscribeRequest{
+			Channel:      channel,
+			Subscription: subscription,
+			TimeoutMs:    int32(subscribePollTimeout / time.Millisecond),
+		}, callopt.WithRPCTimeout(subscribePollTimeout+5*time.Second))
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("[API] failed to subscribe %s: %v", channel, err)
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", strings.ReplaceAll(err.Error(), "\n", " "))
+			flusher.Flush()
+			return
+		}
+
+		if subscription != "" && resp.Subscription != subscription {
+			fmt.Fprint(w, "event: reset\ndata: subscription expired, messages may be lost\n\n")
+		}
+		subscription = resp.Subscription
+		if resp.Dropped > 0 {
+			fmt.Fprintf(w, "event: dropped\ndata: %d\n\n", resp.Dropped)
+		}
+		for _, msg := range resp.Messages {
+			// SSE 的 data 字段不能包含换行，多行消息拆分为多个 data 行
+			for _, line := range strings.Split(string(msg), "\n") {
+				fmt.Fprintf(w, "data: %s\n", line)
+			}
+			fmt.Fprint(w, "\n")
+		}
+		if len(resp.Messages) == 0 && resp.Dropped == 0 {
+			// 心跳注释，及时发现已断开的连接
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		flusher.Flush()
+	}
 }
 
 // Start 启动 HTTP 服务器
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"mygocache"
 	"mygocache/asynclog"
@@ -15,30 +20,114 @@ var db = map[string]string{
 	"Sam":  "567",
 }
 
-func createGroup() *mygocache.Group {
-	return mygocache.NewGroupWithTTL("scores", 2<<10, mygocache.GetterFunc(
+func createGroup(opts ...mygocache.Option) *mygocache.Group {
+	opts = append([]mygocache.Option{mygocache.WithCacheBytes(2 << 10)}, opts...)
+	gee, err := mygocache.NewGroup("scores", mygocache.GetterFunc(
 		func(key string) ([]byte, error) {
 			log.Println("[SlowDB] search key", key)
 			if v, ok := db[key]; ok {
 				return []byte(v), nil
 			}
 			return nil, fmt.Errorf("%s not exist", key)
-		}), 0)
+		}), opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return gee
+}
+
+// fsyncPolicies 是 -aof-fsync 参数可选的同步策略
+var fsyncPolicies = map[string]mygocache.FsyncPolicy{
+	"always":   mygocache.FsyncAlways,
+	"everysec": mygocache.FsyncEverySecond,
+	"no":       mygocache.FsyncNever,
+}
+
+// closeOnSignal 收到 SIGINT/SIGTERM 时关闭 Group（写入最后一次快照、同步追加日志）后退出
+func closeOnSignal(gee *mygocache.Group) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		gee.Close()
+		asynclog.Close()
+		os.Exit(0)
+	}()
+}
+
+// warm 在后台预热缓存并记录进度，预热结束前 Stats 报告节点未就绪
+func warm(gee *mygocache.Group, w mygocache.Warmup) {
+	w.OnProgress = func(p mygocache.WarmProgress) {
+		if !p.Done {
+			log.Printf("warming: loaded=%d skipped=%d failed=%d", p.Loaded, p.Skipped, p.Failed)
+		}
+	}
+	// 在返回前标记为预热中，避免后台协程开始运行前就绪探针短暂报告就绪
+	wait := gee.StartWarm(context.Background(), w)
+	go func() {
+		if _, err := wait(); err != nil {
+			log.Println("warm-up finished with error:", err)
+		}
+		log.Println("mygocache is ready")
+	}()
 }
 
-func startCacheServer(addr string, addrs []string, gee *mygocache.Group) {
+func startCacheServer(addr string, addrs []string, gee *mygocache.Group, w mygocache.Warmup) {
 	peers := mygocache.NewKitexPool(addr)
 	peers.Set(addrs...)
 	gee.RegisterPeers(peers)
+	if w.FromPeers || w.Source != nil {
+		warm(gee, w)
+	}
 	log.Println("mygocache Kitex is running at", addr)
-	log.Fatal(mygocache.StartKitexServer(addr))
+	log.Fatal(mygocache.StartKitexServer(addr, peers))
 }
 
 func main() {
-	var port int
+	var (
+		port             int
+		snapshotPath     string
+		snapshotInterval time.Duration
+		aofPath          string
+		aofFsync         string
+		diskDir          string
+		diskBytes        int64
+		warmKeys         string
+		warmPeers        bool
+		hotKeys          int
+		hotKeySample     int
+	)
 	flag.IntVar(&port, "port", 8001, "Geecache server port")
+	flag.StringVar(&snapshotPath, "snapshot", "", "snapshot file loaded on boot and written periodically, empty to disable")
+	flag.DurationVar(&snapshotInterval, "snapshot-interval", time.Minute, "interval between snapshots, 0 to write only on shutdown")
+	flag.StringVar(&aofPath, "aof", "", "append-only log replayed on boot, empty to disable")
+	flag.StringVar(&aofFsync, "aof-fsync", "everysec", "append-only log fsync policy: always, everysec or no")
+	flag.StringVar(&diskDir, "disk", "", "directory of the disk tier holding values evicted from memory, empty to disable")
+	flag.Int64Var(&diskBytes, "disk-bytes", 1<<30, "disk tier capacity in bytes")
+	flag.StringVar(&warmKeys, "warm-keys", "", "file of keys (one per line) loaded through the getter on boot, empty to disable")
+	flag.BoolVar(&warmPeers, "warm-peers", false, "pull values owned by this node from peers on boot")
+	flag.IntVar(&hotKeys, "hot-keys", 0, "number of keys tracked for hot key detection, 0 to disable")
+	flag.IntVar(&hotKeySample, "hot-key-sample", 10, "record one in every n gets for hot key detection")
 	flag.Parse()
 
+	var opts []mygocache.Option
+	if snapshotPath != "" {
+		opts = append(opts, mygocache.WithSnapshotFile(snapshotPath, snapshotInterval))
+	}
+	if aofPath != "" {
+		policy, ok := fsyncPolicies[aofFsync]
+		if !ok {
+			log.Fatalf("unknown -aof-fsync %q", aofFsync)
+		}
+		opts = append(opts, mygocache.WithAppendLog(aofPath, policy))
+	}
+	if diskDir != "" {
+		opts = append(opts, mygocache.WithDiskTier(diskDir, diskBytes))
+	}
+	if hotKeys > 0 {
+		opts = append(opts, mygocache.WithHotKeys(hotKeys, hotKeySample))
+	}
+
 	// 初始化异步日志
 	asynclog.Init(16384)
 	defer asynclog.Close()
@@ -54,6 +143,12 @@ func main() {
 		addrs = append(addrs, v)
 	}
 
-	gee := createGroup()
-	startCacheServer(addrMap[port], addrs, gee)
+	w := mygocache.Warmup{FromPeers: warmPeers}
+	if warmKeys != "" {
+		w.Source = mygocache.FileKeySource(warmKeys)
+	}
+
+	gee := createGroup(opts...)
+	closeOnSignal(gee)
+	startCacheServer(addrMap[port], addrs, gee, w)
 }
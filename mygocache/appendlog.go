@@ -0,0 +1,522 @@
+package mygocache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"mygocache/asynclog"
+)
+
+// FsyncPolicy 决定追加日志同步到磁盘的时机
+type FsyncPolicy int
+
+const (
+	// FsyncEverySecond 每秒同步一次，崩溃时最多丢失约 1 秒的写入
+	FsyncEverySecond FsyncPolicy = iota
+	// FsyncAlways 每条记录写入后立即同步，最安全也最慢
+	FsyncAlways
+	// FsyncNever 不主动同步，由操作系统决定刷盘时机
+	FsyncNever
+)
+
+// DefaultAppendLogRewriteSize 追加日志自动压缩的最小文件大小（字节）
+const DefaultAppendLogRewriteSize int64 = 64 << 20
+
+// ErrAppendLogDisabled 表示 Group 未启用追加日志
+var ErrAppendLogDisabled = errors.New("append log is not enabled")
+
+// 追加日志格式：
+//
+//	"GCAO" | 版本号（1 字节）| Group 名称
+//	记录 ...：负载长度（uvarint）| 负载的 CRC-32C（4 字节，大端序）| 负载
+//
+// 负载的编码与快照记录相同，但 recordValue 与 recordHash 的 TTL 字段存放过期时间戳（0 表示永不过期），
+// 重放时无需知道记录写入的时间。每条记录独立校验，崩溃时写了一半的尾部记录在重放时被截掉
+const (
+	appendLogMagic   = "GCAO"
+	appendLogVersion = 1
+)
+
+// 只出现在追加日志中的记录类型，与快照记录类型统一编号
+const (
+	recordDelete byte = recordHistory + 1 + iota // 删除：key
+	recordClear                                  // 清空
+	recordExpire                                 // 过期：key | 发现过期时的时间戳
+)
+
+// appendLog 是 Group 的追加日志。每次显式写入后记录 key 在缓存中的当前状态（而不是操作本身），
+// 读取状态与追加记录在 mu 内完成，因此同一 key 最后一条记录总是与缓存一致。
+// 加锁顺序为 mu -> 分片锁，持有分片锁期间产生的过期事件不能获取 mu，先暂存在 expired 中
+type appendLog struct {
+	mu             sync.Mutex
+	path           string
+	f              *os.File // nil 表示尚未打开或已关闭，此时的写入不被记录
+	policy         FsyncPolicy
+	size           int64 // 当前文件大小
+	minRewriteSize int64 // 自动压缩的最小文件大小，0 表示不自动压缩
+	rewriteSize    int64 // 文件增长到该大小时自动压缩
+	dirty          bool  // 有尚未同步到磁盘的写入
+	closed         bool  // 已开始关闭，不再启动压缩
+	rewriting      bool
+	rewriteBuf     bytes.Buffer // 压缩期间追加的记录，压缩完成后写入新文件
+	scratch        bytes.Buffer
+
+	expiredMu sync.Mutex
+	expired   []expiredKey
+
+	compactions sync.WaitGroup
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// expiredKey 是一个等待记录的过期事件
+type expiredKey struct {
+	key string
+	at  int64
+}
+
+func newAppendLog(path string, policy FsyncPolicy, minRewriteSize int64) *appendLog {
+	return &appendLog{
+		path:           path,
+		policy:         policy,
+		minRewriteSize: minRewriteSize,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// encodeRecord 将 encode 写入的负载加上长度与校验和追加到 dst
+func encodeRecord(dst *bytes.Buffer, encode func(sw *snapshotWriter)) {
+	var payload bytes.Buffer
+	sw := &snapshotWriter{w: &payload, crc: crc32.New(snapshotTable)}
+	encode(sw)
+	var buf [binary.MaxVarintLen64 + 4]byte
+	n := binary.PutUvarint(buf[:], uint64(payload.Len()))
+	binary.BigEndian.PutUint32(buf[n:], sw.crc.Sum32())
+	dst.Write(buf[:n+4])
+	dst.Write(payload.Bytes())
+}
+
+// writeAppendLogHeader 将文件头写入 w
+func writeAppendLogHeader(w io.Writer, name string) error {
+	var buf bytes.Buffer
+	sw := &snapshotWriter{w: &buf, crc: crc32.New(snapshotTable)}
+	sw.writeRaw([]byte(appendLogMagic))
+	sw.writeByte(appendLogVersion)
+	sw.writeString(name)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// appendLocked 追加一条记录，先记录暂存的过期事件。调用方必须已持有 l.mu
+func (l *appendLog) appendLocked(encode func(sw *snapshotWriter)) {
+	if l.f == nil {
+		return
+	}
+	l.flushExpiredLocked()
+	l.scratch.Reset()
+	encodeRecord(&l.scratch, encode)
+	l.writeLocked(l.scratch.Bytes())
+}
+
+// writeLocked 将编码好的记录写入文件，压缩期间同时写入 rewriteBuf。调用方必须已持有 l.mu
+func (l *appendLog) writeLocked(p []byte) {
+	n, err := l.f.Write(p)
+	l.size += int64(n)
+	if err != nil {
+		asynclog.Printf("[GeeCache] write append log %s failed: %v", l.path, err)
+		return
+	}
+	if l.rewriting {
+		l.rewriteBuf.Write(p)
+	}
+	if l.policy == FsyncAlways {
+		l.syncLocked()
+	} else {
+		l.dirty = true
+	}
+}
+
+func (l *appendLog) syncLocked() {
+	if err := l.f.Sync(); err != nil {
+		asynclog.Printf("[GeeCache] sync append log %s failed: %v", l.path, err)
+	}
+	l.dirty = false
+}
+
+// expire 暂存一个过期事件，可以在持有分片锁期间调用
+func (l *appendLog) expire(key string) {
+	l.expiredMu.Lock()
+	l.expired = append(l.expired, expiredKey{key: key, at: time.Now().Unix()})
+	l.expiredMu.Unlock()
+}
+
+// flushExpiredLocked 记录暂存的过期事件。调用方必须已持有 l.mu
+func (l *appendLog) flushExpiredLocked() {
+	l.expiredMu.Lock()
+	expired := l.expired
+	l.expired = nil
+	l.expiredMu.Unlock()
+	if l.f == nil {
+		return
+	}
+	for _, e := range expired {
+		l.scratch.Reset()
+		encodeRecord(&l.scratch, func(sw *snapshotWriter) {
+			sw.writeByte(recordExpire)
+			sw.writeString(e.key)
+			sw.writeVarint(e.at)
+		})
+		l.writeLocked(l.scratch.Bytes())
+	}
+}
+
+// startRewriteLocked 标记开始压缩，已在压缩或正在关闭时返回 false。调用方必须已持有 l.mu
+func (l *appendLog) startRewriteLocked() bool {
+	if l.rewriting || l.closed || l.f == nil {
+		return false
+	}
+	l.rewriting = true
+	l.rewriteBuf.Reset()
+	l.compactions.Add(1)
+	return true
+}
+
+// loop 每秒记录暂存的过期事件，并按 FsyncEverySecond 同步到磁盘
+func (l *appendLog) loop() {
+	defer close(l.done)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.mu.Lock()
+			if l.f != nil {
+				l.flushExpiredLocked()
+				if l.dirty && l.policy == FsyncEverySecond {
+					l.syncLocked()
+				}
+			}
+			l.mu.Unlock()
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// logKey 将缓存 key ck 的当前状态追加到日志：存在时记录值、过期时间与标签，不存在时记录删除。
+// 在显式写入或删除之后调用
+func (g *Group) logKey(ck string) {
+	l := g.aof
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := g.mainCache.peek(ck); ok {
+		e.tags = g.tags.tagsOf(ck)
+		l.appendLocked(func(sw *snapshotWriter) { sw.writeEntry(e, uint64(e.expiresAt)) })
+	} else {
+		l.appendLocked(func(sw *snapshotWriter) {
+			sw.writeByte(recordDelete)
+			sw.writeString(ck)
+		})
+	}
+	if l.minRewriteSize > 0 && l.size >= l.rewriteSize && l.startRewriteLocked() {
+		go g.rewriteAppendLog()
+	}
+}
+
+// logged 在持有日志锁期间执行 fn 并追加 encode 编码的记录，使 fn 与其记录在日志中的顺序和其他写入一致。
+// 用于清空、递增代数等不针对单个 key 的操作，未启用日志时直接执行 fn
+func (g *Group) logged(fn func(), encode func(sw *snapshotWriter)) {
+	l := g.aof
+	if l == nil {
+		fn()
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn()
+	l.appendLocked(encode)
+}
+
+// openAppendLog 重放 path 中的日志后打开以追加写入，文件不存在时创建
+func (g *Group) openAppendLog() error {
+	l := g.aof
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	size, records, err := g.replayAppendLog(f)
+	if err == nil {
+		// 截掉不完整的尾部记录，之后的记录紧接在最后一条完整记录之后
+		if err = f.Truncate(size); err == nil {
+			_, err = f.Seek(size, io.SeekStart)
+		}
+	}
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("replay append log %s: %v", l.path, err)
+	}
+	asynclog.Printf("[GeeCache] group %s replayed %d records from %s in %v", g.name, records, l.path, time.Since(start))
+
+	l.mu.Lock()
+	l.f = f
+	l.size = size
+	l.rewriteSize = l.nextRewriteSize()
+	l.mu.Unlock()
+	go l.loop()
+	return nil
+}
+
+// nextRewriteSize 返回下次自动压缩的文件大小：当前大小的两倍，且不小于 minRewriteSize
+func (l *appendLog) nextRewriteSize() int64 {
+	if 2*l.size > l.minRewriteSize {
+		return 2 * l.size
+	}
+	return l.minRewriteSize
+}
+
+// replayAppendLog 从 f 的开头重放日志，返回最后一条完整记录之后的偏移与重放的记录数。
+// 空文件写入文件头；文件头损坏或属于其他 Group 时返回错误，尾部不完整或校验失败的记录被丢弃
+func (g *Group) replayAppendLog(f *os.File) (offset int64, records int, err error) {
+	r := bufio.NewReader(f)
+	if _, err := r.Peek(1); err == io.EOF {
+		var buf bytes.Buffer
+		writeAppendLogHeader(&buf, g.name)
+		if _, err := f.Write(buf.Bytes()); err != nil {
+			return 0, 0, err
+		}
+		return int64(buf.Len()), 0, nil
+	}
+
+	sr := &snapshotReader{r: r, crc: crc32.New(snapshotTable)}
+	magic := sr.readRaw(len(appendLogMagic))
+	version := sr.readByte()
+	name := sr.readString()
+	switch {
+	case sr.err == ErrSnapshotCorrupt || sr.err == nil && string(magic) != appendLogMagic:
+		return 0, 0, errors.New("invalid append log header")
+	case sr.err != nil:
+		return 0, 0, sr.err
+	case version != appendLogVersion:
+		return 0, 0, fmt.Errorf("unsupported append log version %d", version)
+	case name != g.name:
+		return 0, 0, fmt.Errorf("append log belongs to group %s", name)
+	}
+	offset = int64(len(appendLogMagic) + 1 + uvarintLen(uint64(len(name))) + len(name))
+
+	for {
+		n, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			return offset, records, nil
+		}
+		var sum [4]byte
+		if err == nil {
+			_, err = io.ReadFull(r, sum[:])
+		}
+		var payload []byte
+		if err == nil && n <= uint64(snapshotPrealloc) {
+			payload = make([]byte, n)
+			_, err = io.ReadFull(r, payload)
+		} else if err == nil {
+			var buf bytes.Buffer
+			_, err = io.CopyN(&buf, r, int64(n))
+			payload = buf.Bytes()
+		}
+		if err == nil && crc32.Checksum(payload, snapshotTable) != binary.BigEndian.Uint32(sum[:]) {
+			err = ErrSnapshotCorrupt
+		}
+		if err == nil {
+			err = g.replayRecord(payload)
+		}
+		if err != nil {
+			asynclog.Printf("[GeeCache] append log %s is truncated at offset %d: %v", f.Name(), offset, err)
+			return offset, records, nil
+		}
+		offset += int64(uvarintLen(n) + 4 + len(payload))
+		records++
+	}
+}
+
+// replayRecord 将一条记录的负载应用到缓存
+func (g *Group) replayRecord(payload []byte) error {
+	sr := &snapshotReader{r: bytes.NewReader(payload), crc: crc32.New(snapshotTable)}
+	switch kind := sr.readByte(); kind {
+	case recordValue, recordHash:
+		e, expiresAt := sr.readEntry(kind)
+		e.expiresAt = int64(expiresAt)
+		if sr.err == nil && !g.restoreEntry(e, time.Now().Unix()) {
+			g.mainCache.delete(e.key)
+		}
+	case recordDelete:
+		if ck := sr.readString(); sr.err == nil {
+			g.mainCache.delete(ck)
+		}
+	case recordClear:
+		g.mainCache.clear()
+	case recordExpire:
+		ck, at := sr.readString(), sr.readVarint()
+		// 过期后重新写入的值过期时间晚于 at，不受影响
+		if e, ok := g.mainCache.peek(ck); sr.err == nil && ok && e.expiresAt > 0 && e.expiresAt <= at {
+			g.mainCache.delete(ck)
+		}
+	case recordGeneration:
+		ns, gen := sr.readString(), sr.readUvarint()
+		if sr.err == nil && ns == "" {
+			g.gens.restore(gen, nil)
+		} else if sr.err == nil {
+			g.gens.restore(0, map[string]uint64{ns: gen})
+		}
+	default:
+		sr.fail(ErrSnapshotCorrupt)
+	}
+	return sr.err
+}
+
+// uvarintLen 返回 v 按 uvarint 编码的字节数
+func uvarintLen(v uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], v)
+}
+
+// CompactAppendLog 以缓存的当前内容重写追加日志，丢弃已被覆盖、删除或过期的记录。
+// 重写期间的写入同时追加到旧日志与内存缓冲区，重写完成后写入新日志，再原子地替换旧日志。
+// 日志增长到一定大小时会自动在后台压缩，通常不需要手动调用
+func (g *Group) CompactAppendLog() error {
+	l := g.aof
+	if l == nil {
+		return ErrAppendLogDisabled
+	}
+	l.mu.Lock()
+	started := l.startRewriteLocked()
+	l.mu.Unlock()
+	if !started {
+		return errors.New("append log compaction is already in progress")
+	}
+	return g.rewriteAppendLog()
+}
+
+// rewriteAppendLog 执行一次压缩，调用方必须已通过 startRewriteLocked 标记开始
+func (g *Group) rewriteAppendLog() (err error) {
+	l := g.aof
+	defer l.compactions.Done()
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".rewrite*")
+	if err != nil {
+		l.abortRewrite(err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			l.abortRewrite(err)
+		}
+	}()
+
+	bw := bufio.NewWriter(tmp)
+	if err = writeAppendLogHeader(bw, g.name); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	group, namespaces := g.gens.snapshot()
+	if group > 0 {
+		namespaces[""] = group
+	}
+	for ns, gen := range namespaces {
+		encodeRecord(&buf, func(sw *snapshotWriter) {
+			sw.writeByte(recordGeneration)
+			sw.writeString(ns)
+			sw.writeUvarint(gen)
+		})
+	}
+	for i := range g.mainCache.shards {
+		for _, e := range g.mainCache.shardEntries(i) {
+			e.tags = g.tags.tagsOf(e.key)
+			encodeRecord(&buf, func(sw *snapshotWriter) { sw.writeEntry(e, uint64(e.expiresAt)) })
+		}
+		if _, err = bw.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		buf.Reset()
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+
+	// closeAppendLog 等待压缩结束后才关闭 l.f，关闭期间进行中的压缩照常完成
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushExpiredLocked()
+	if _, err = tmp.Write(l.rewriteBuf.Bytes()); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), l.path); err != nil {
+		return err
+	}
+	// 临时文件已替换日志，继续在其上追加
+	l.f.Close()
+	l.f = tmp
+	l.size = size
+	l.rewriteSize = l.nextRewriteSize()
+	l.dirty = false
+	l.rewriting = false
+	l.rewriteBuf.Reset()
+	return nil
+}
+
+// abortRewrite 放弃本次压缩，新记录只写入旧日志
+func (l *appendLog) abortRewrite(err error) {
+	asynclog.Printf("[GeeCache] compact append log %s failed: %v", l.path, err)
+	l.mu.Lock()
+	l.rewriting = false
+	l.rewriteBuf.Reset()
+	l.mu.Unlock()
+}
+
+// closeAppendLog 停止后台协程，等待进行中的压缩结束，记录剩余的过期事件并同步到磁盘后关闭日志
+func (g *Group) closeAppendLog() {
+	l := g.aof
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	l.closed = true
+	opened := l.f != nil
+	l.mu.Unlock()
+	if opened {
+		close(l.stop)
+		<-l.done
+	}
+	l.compactions.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	l.flushExpiredLocked()
+	l.syncLocked()
+	l.f.Close()
+	l.f = nil
+}
@@ -0,0 +1,618 @@
+// Package arena 实现基于环形字节缓冲区的缓存存储引擎。
+// 条目的键、值与元数据序列化后连续存放在少量大的 []byte 中，以 map[uint64]uint32 索引，
+// 二者都不含指针，百万级条目时 GC 也无需逐个扫描，适合大量小条目的场景
+package arena
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"mygocache/lru"
+	"mygocache/sortedset"
+)
+
+// 条目的序列化格式：24 字节头部 | 键 | 值。头部依次为
+// 状态（1 字节）| 调用方标志（1 字节）| 键长（2 字节）| 值长（4 字节）| 过期时间（8 字节）| 版本号（8 字节）
+const headerSize = 24
+
+// entryDead 标记已被覆盖、删除或过期的条目，其空间在淘汰轮转到时回收
+const entryDead uint8 = 1
+
+// MaxKeyLen 键的最大字节数，超过时条目不会被写入
+const MaxKeyLen = 1<<16 - 1
+
+// initialBufSize 缓冲区的初始大小，写满后按 2 倍扩容直到 maxBytes
+const initialBufSize = 64 << 10
+
+// Cache 是基于环形字节缓冲区的缓存，并发安全。
+// 新条目追加在缓冲区末尾，容量不足时从最早写入的条目开始淘汰（FIFO），读取不调整淘汰顺序；
+// 覆盖与删除只将旧条目标记为失效。键以 64 位哈希索引，哈希冲突时后写入的条目淘汰先写入的条目
+type Cache struct {
+	mu       sync.Mutex
+	maxBytes int
+	buf      []byte
+	// 数据区为 [head, tail)；回绕后为 [head, wrapEnd) 与 [0, tail)，wrapEnd 为 -1 表示未回绕
+	head, tail, wrapEnd int
+	// used 已占用的字节数，包括失效条目与回绕时缓冲区末尾的空闲部分
+	used  int
+	index map[uint64]uint32 // 键的哈希 -> 条目偏移
+	// order 按大小排序的键哈希，供 RangeAfter 分页遍历；元素不含指针，不增加 GC 扫描开销
+	order *sortedset.Set[uint64]
+	// expiry 以过期时间排序的最小堆，条目被覆盖或删除后不移除对应的项，弹出时再校验
+	expiry  expiryHeap
+	version uint64
+	// onRemoved 在条目被淘汰、过期、删除或覆盖时调用（持有锁期间），data 只在调用期间有效
+	onRemoved func(key string, data []byte, flags uint8, reason lru.RemoveReason)
+	// OnCapacityEvicted 在条目因容量不足（或哈希冲突）被淘汰时调用，附带过期时间，在 onRemoved 之前调用。
+	// 持有锁期间调用，data 只在调用期间有效
+	OnCapacityEvicted func(key string, data []byte, flags uint8, expiresAt int64)
+	stopChan          chan struct{}
+	closeOnce         sync.Once
+}
+
+// header 是条目头部的解码形式
+type header struct {
+	state     uint8
+	flags     uint8
+	keyLen    int
+	valLen    int
+	expiresAt int64
+	version   uint64
+}
+
+func (h header) size() int {
+	return headerSize + h.keyLen + h.valLen
+}
+
+func (h header) expired(now int64) bool {
+	return h.expiresAt > 0 && h.expiresAt < now
+}
+
+func readHeader(b []byte) header {
+	return header{
+		state:     b[0],
+		flags:     b[1],
+		keyLen:    int(binary.LittleEndian.Uint16(b[2:])),
+		valLen:    int(binary.LittleEndian.Uint32(b[4:])),
+		expiresAt: int64(binary.LittleEndian.Uint64(b[8:])),
+		version:   binary.LittleEndian.Uint64(b[16:]),
+	}
+}
+
+func writeHeader(b []byte, h header) {
+	b[0] = h.state
+	b[1] = h.flags
+	binary.LittleEndian.PutUint16(b[2:], uint16(h.keyLen))
+	binary.LittleEndian.PutUint32(b[4:], uint32(h.valLen))
+	binary.LittleEndian.PutUint64(b[8:], uint64(h.expiresAt))
+	binary.LittleEndian.PutUint64(b[16:], h.version)
+}
+
+// expiryItem 只记录过期时间与键的哈希，不含指针
+type expiryItem struct {
+	expiresAt int64
+	hash      uint64
+}
+
+type expiryHeap []expiryItem
+
+func (h expiryHeap) Len() int            { return len(h) }
+func (h expiryHeap) Less(i, j int) bool  { return h[i].expiresAt < h[j].expiresAt }
+func (h expiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(expiryItem)) }
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// hashKey 计算键的 64 位 FNV-1a 哈希，不分配内存
+func hashKey(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// expireTime 将 TTL（秒）换算为过期时间戳，ttl <= 0 表示永不过期
+func expireTime(ttl int64) int64 {
+	if ttl > 0 {
+		return time.Now().Unix() + ttl
+	}
+	return 0
+}
+
+// replaceReason 返回覆盖写入旧条目时上报的原因：旧条目已过期但尚未被清理时视为过期
+func replaceReason(h header) lru.RemoveReason {
+	if h.expired(time.Now().Unix()) {
+		return lru.RemoveExpired
+	}
+	return lru.RemoveReplaced
+}
+
+// New 创建最多占用 maxBytes 字节缓冲区的缓存（每个条目额外占用 24 字节头部），
+// 单个缓冲区不超过 4GB。onRemoved 可以为 nil
+func New(maxBytes int64, onRemoved func(key string, data []byte, flags uint8, reason lru.RemoveReason)) *Cache {
+	if maxBytes > 1<<32-1 {
+		maxBytes = 1<<32 - 1
+	}
+	size := initialBufSize
+	if int64(size) > maxBytes {
+		size = int(maxBytes)
+	}
+	c := &Cache{
+		maxBytes:  int(maxBytes),
+		buf:       make([]byte, size),
+		wrapEnd:   -1,
+		index:     make(map[uint64]uint32),
+		order:     sortedset.New(func(a, b uint64) bool { return a < b }),
+		onRemoved: onRemoved,
+		stopChan:  make(chan struct{}),
+	}
+	go c.expirationLoop()
+	return c
+}
+
+// Close 停止过期检查协程（幂等，可多次调用）
+func (c *Cache) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stopChan)
+	})
+}
+
+// Set 写入 key 的值与调用方标志，返回为其分配的版本号。ttl 为生存时间（秒），0 表示永不过期。
+// 条目大于整个缓冲区或键过长时不写入，key 原有的值被移除
+func (c *Cache) Set(key string, data []byte, flags uint8, ttl int64) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set(key, data, flags, expireTime(ttl))
+}
+
+// SetIf 同 Set，但仅当 cond 返回 true 时写入，返回版本号与是否写入。
+// cond 在持有锁期间、写入之前调用，exists 表示 key 当前是否在缓存中，cond 中不得访问缓存
+func (c *Cache) SetIf(key string, data []byte, flags uint8, ttl int64, cond func(exists bool) bool) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _, exists := c.lookup(key)
+	if !cond(exists) {
+		return 0, false
+	}
+	return c.set(key, data, flags, expireTime(ttl)), true
+}
+
+func (c *Cache) set(key string, data []byte, flags uint8, expiresAt int64) uint64 {
+	c.version++
+	version := c.version
+
+	hash := hashKey(key)
+	if off, ok := c.index[hash]; ok {
+		h := readHeader(c.buf[off:])
+		if c.keyEquals(int(off), h, key) {
+			c.remove(int(off), h, hash, replaceReason(h))
+		} else {
+			c.remove(int(off), h, hash, lru.RemoveEvicted)
+		}
+	}
+
+	h := header{flags: flags, keyLen: len(key), valLen: len(data), expiresAt: expiresAt, version: version}
+	if len(key) > MaxKeyLen || h.size() > c.maxBytes {
+		if c.OnCapacityEvicted != nil {
+			c.OnCapacityEvicted(key, data, flags, expiresAt)
+		}
+		if c.onRemoved != nil {
+			c.onRemoved(key, data, flags, lru.RemoveEvicted)
+		}
+		return version
+	}
+
+	off := c.reserve(h.size())
+	writeHeader(c.buf[off:], h)
+	copy(c.buf[off+headerSize:], key)
+	copy(c.buf[off+headerSize+len(key):], data)
+	c.index[hash] = uint32(off)
+	c.order.Insert(hash)
+
+	if expiresAt > 0 {
+		heap.Push(&c.expiry, expiryItem{expiresAt, hash})
+		// 堆中失效的项过多时重建
+		if len(c.expiry) > 2*len(c.index)+1024 {
+			c.rebuildExpiry()
+		}
+	}
+	return version
+}
+
+// keyAt 返回偏移 off 处条目的键
+func (c *Cache) keyAt(off int, h header) string {
+	return string(c.buf[off+headerSize : off+headerSize+h.keyLen])
+}
+
+// keyEquals 判断偏移 off 处条目的键是否为 key，比较时不分配内存
+func (c *Cache) keyEquals(off int, h header, key string) bool {
+	return string(c.buf[off+headerSize:off+headerSize+h.keyLen]) == key
+}
+
+func (c *Cache) valueAt(off int, h header) []byte {
+	start := off + headerSize + h.keyLen
+	return c.buf[start : start+h.valLen]
+}
+
+// lookup 返回 key 对应的未过期条目，过期条目会被顺带删除（惰性过期）
+func (c *Cache) lookup(key string) (int, header, bool) {
+	hash := hashKey(key)
+	off, ok := c.index[hash]
+	if !ok {
+		return 0, header{}, false
+	}
+	h := readHeader(c.buf[off:])
+	if !c.keyEquals(int(off), h, key) {
+		return 0, header{}, false
+	}
+	if h.expired(time.Now().Unix()) {
+		c.remove(int(off), h, hash, lru.RemoveExpired)
+		return 0, header{}, false
+	}
+	return int(off), h, true
+}
+
+// remove 将条目标记为失效并从索引中删除
+func (c *Cache) remove(off int, h header, hash uint64, reason lru.RemoveReason) {
+	c.buf[off] |= entryDead
+	delete(c.index, hash)
+	c.order.Delete(hash)
+	if reason == lru.RemoveEvicted && c.OnCapacityEvicted != nil {
+		c.OnCapacityEvicted(c.keyAt(off, h), c.valueAt(off, h), h.flags, h.expiresAt)
+	}
+	if c.onRemoved != nil {
+		c.onRemoved(c.keyAt(off, h), c.valueAt(off, h), h.flags, reason)
+	}
+}
+
+// reserve 为 n 字节的条目分配空间并返回其偏移，空间不足时先扩容，已达 maxBytes 时淘汰最早的条目
+func (c *Cache) reserve(n int) int {
+	for {
+		if off, ok := c.tryAppend(n); ok {
+			return off
+		}
+		if len(c.buf) < c.maxBytes {
+			c.grow(n)
+			continue
+		}
+		c.evictOldest()
+	}
+}
+
+func (c *Cache) tryAppend(n int) (int, bool) {
+	if c.used == 0 {
+		c.head, c.tail, c.wrapEnd = 0, 0, -1
+	}
+	if c.wrapEnd < 0 {
+		if len(c.buf)-c.tail >= n {
+			off := c.tail
+			c.tail += n
+			c.used += n
+			return off, true
+		}
+		if c.head >= n {
+			// 回绕到缓冲区开头，末尾放不下条目的空间计入 used，直到 head 越过 wrapEnd
+			c.used += len(c.buf) - c.tail + n
+			c.wrapEnd = c.tail
+			c.tail = n
+			return 0, true
+		}
+		return 0, false
+	}
+	if c.head-c.tail >= n {
+		off := c.tail
+		c.tail += n
+		c.used += n
+		return off, true
+	}
+	return 0, false
+}
+
+// evictOldest 回收最早写入的条目的空间，条目仍有效时上报淘汰（已过期时上报过期）
+func (c *Cache) evictOldest() {
+	if c.used == 0 {
+		return
+	}
+	off := c.head
+	h := readHeader(c.buf[off:])
+	if h.state&entryDead == 0 {
+		reason := lru.RemoveEvicted
+		if h.expired(time.Now().Unix()) {
+			reason = lru.RemoveExpired
+		}
+		c.remove(off, h, hashKey(c.keyAt(off, h)), reason)
+	}
+
+	c.head += h.size()
+	c.used -= h.size()
+	if c.wrapEnd >= 0 && c.head == c.wrapEnd {
+		c.used -= len(c.buf) - c.wrapEnd
+		c.head, c.wrapEnd = 0, -1
+	}
+}
+
+// grow 扩容缓冲区以容纳额外的 n 字节，同时按写入顺序紧凑地复制有效条目，丢弃失效条目。
+// 只在缓冲区小于 maxBytes 时调用，新缓冲区总能放下所有有效条目
+func (c *Cache) grow(n int) {
+	size := 2 * len(c.buf)
+	for size < c.liveBytes()+n && size < c.maxBytes {
+		size *= 2
+	}
+	if size > c.maxBytes || size <= 0 {
+		size = c.maxBytes
+	}
+
+	buf := make([]byte, size)
+	tail := 0
+	c.scan(func(off int, h header) {
+		if h.state&entryDead != 0 {
+			return
+		}
+		copy(buf[tail:], c.buf[off:off+h.size()])
+		c.index[hashKey(c.keyAt(off, h))] = uint32(tail)
+		tail += h.size()
+	})
+	c.buf = buf
+	c.head, c.tail, c.wrapEnd, c.used = 0, tail, -1, tail
+}
+
+// liveBytes 返回有效条目占用的字节数
+func (c *Cache) liveBytes() int {
+	total := 0
+	c.scan(func(off int, h header) {
+		if h.state&entryDead == 0 {
+			total += h.size()
+		}
+	})
+	return total
+}
+
+// scan 按写入顺序遍历数据区中的所有条目（包括失效条目）
+func (c *Cache) scan(fn func(off int, h header)) {
+	if c.used == 0 {
+		return
+	}
+	walk := func(from, to int) {
+		for off := from; off < to; {
+			h := readHeader(c.buf[off:])
+			fn(off, h)
+			off += h.size()
+		}
+	}
+	if c.wrapEnd < 0 {
+		walk(c.head, c.tail)
+		return
+	}
+	walk(c.head, c.wrapEnd)
+	walk(0, c.tail)
+}
+
+// rebuildExpiry 以有效条目重建过期时间堆
+func (c *Cache) rebuildExpiry() {
+	c.expiry = c.expiry[:0]
+	for hash, off := range c.index {
+		if h := readHeader(c.buf[off:]); h.expiresAt > 0 {
+			c.expiry = append(c.expiry, expiryItem{h.expiresAt, hash})
+		}
+	}
+	heap.Init(&c.expiry)
+}
+
+// Get 返回 key 对应的值的副本、调用方标志与版本号（惰性过期）
+func (c *Cache) Get(key string) (data []byte, flags uint8, version uint64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	off, h, ok := c.lookup(key)
+	if !ok {
+		return nil, 0, 0, false
+	}
+	data = make([]byte, h.valLen)
+	copy(data, c.valueAt(off, h))
+	return data, h.flags, h.version, true
+}
+
+// View 以 fn 访问 key 对应的未过期值而不复制，fn 返回后不得继续持有 data
+func (c *Cache) View(key string, fn func(data []byte, flags uint8)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	off, h, ok := c.lookup(key)
+	if !ok {
+		return false
+	}
+	fn(c.valueAt(off, h), h.flags)
+	return true
+}
+
+// Peek 同 View，额外提供条目的过期时间戳（0 表示永不过期）
+func (c *Cache) Peek(key string, fn func(data []byte, flags uint8, expiresAt int64)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	off, h, ok := c.lookup(key)
+	if !ok {
+		return false
+	}
+	fn(c.valueAt(off, h), h.flags, h.expiresAt)
+	return true
+}
+
+// CompareAndSwap 仅当 key 的当前版本号等于 version 时写入新值
+// 成功时返回新的版本号；失败时返回当前版本号（key 不存在或已过期时为 0）
+func (c *Cache) CompareAndSwap(key string, data []byte, flags uint8, version uint64, ttl int64) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, h, ok := c.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	if h.version != version {
+		return h.version, false
+	}
+	return c.set(key, data, flags, expireTime(ttl)), true
+}
+
+// Update 原子地读取并改写 key 对应的值，返回写入后的版本号，语义同 lru.Cache.Update。
+// fn 收到的 old 为副本，返回的 ttl 为 lru.KeepTTL 时沿用已有条目的过期时间
+func (c *Cache) Update(key string, fn func(old []byte, flags uint8, found bool) ([]byte, uint8, int64, error)) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var old []byte
+	var oldFlags uint8
+	var oldExpiresAt int64
+	off, h, found := c.lookup(key)
+	if found {
+		old = make([]byte, h.valLen)
+		copy(old, c.valueAt(off, h))
+		oldFlags, oldExpiresAt = h.flags, h.expiresAt
+	}
+
+	data, flags, ttl, err := fn(old, oldFlags, found)
+	if err != nil {
+		return 0, err
+	}
+	expiresAt := expireTime(ttl)
+	if ttl == lru.KeepTTL {
+		expiresAt = oldExpiresAt
+	}
+	return c.set(key, data, flags, expiresAt), nil
+}
+
+// CompareAndRemove 仅当 key 的当前版本号等于 version 时删除该条目
+// 失败时返回当前版本号（key 不存在或已过期时为 0）
+func (c *Cache) CompareAndRemove(key string, version uint64) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	off, h, ok := c.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	if h.version != version {
+		return h.version, false
+	}
+	c.remove(off, h, hashKey(key), lru.RemoveDeleted)
+	return version, true
+}
+
+// Contains 判断键是否在缓存中，不检查过期
+func (c *Cache) Contains(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	off, ok := c.index[hashKey(key)]
+	return ok && c.keyEquals(int(off), readHeader(c.buf[off:]), key)
+}
+
+// Remove 删除指定键的条目
+func (c *Cache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hash := hashKey(key)
+	if off, ok := c.index[hash]; ok {
+		if h := readHeader(c.buf[off:]); c.keyEquals(int(off), h, key) {
+			c.remove(int(off), h, hash, lru.RemoveDeleted)
+		}
+	}
+}
+
+// Clear 删除所有条目并回收缓冲区
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scan(func(off int, h header) {
+		if h.state&entryDead == 0 {
+			c.remove(off, h, hashKey(c.keyAt(off, h)), lru.RemoveDeleted)
+		}
+	})
+	c.head, c.tail, c.wrapEnd, c.used = 0, 0, -1, 0
+	c.expiry = c.expiry[:0]
+}
+
+// Len 返回缓存中的条目数
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.index)
+}
+
+// Range 按从旧到新的顺序遍历未过期的条目，fn 返回 false 时停止遍历。
+// data 只在调用期间有效，遍历期间不得访问缓存
+func (c *Cache) Range(fn func(key string, data []byte, flags uint8) bool) {
+	c.RangeWithExpiry(func(key string, data []byte, flags uint8, expiresAt int64) bool {
+		return fn(key, data, flags)
+	})
+}
+
+// RangeWithExpiry 同 Range，额外提供条目的过期时间戳（0 表示永不过期）
+func (c *Cache) RangeWithExpiry(fn func(key string, data []byte, flags uint8, expiresAt int64) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now().Unix()
+	stopped := false
+	c.scan(func(off int, h header) {
+		if stopped || h.state&entryDead != 0 || h.expired(now) {
+			return
+		}
+		stopped = !fn(c.keyAt(off, h), c.valueAt(off, h), h.flags, h.expiresAt)
+	})
+}
+
+// RangeAfter 按键的哈希顺序遍历排在 after 之后（after 为空时从头开始）的未过期条目，fn 返回 false 时停止遍历。
+// 该顺序不随读取与写入改变，以上一次遍历到的键作为 after 即可分页遍历，每页只访问该页的条目。
+// data 只在调用期间有效，遍历期间不得访问缓存
+func (c *Cache) RangeAfter(after string, fn func(key string, data []byte, flags uint8) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now().Unix()
+	visit := func(hash uint64) bool {
+		off := int(c.index[hash])
+		h := readHeader(c.buf[off:])
+		if h.expired(now) {
+			return true
+		}
+		return fn(c.keyAt(off, h), c.valueAt(off, h), h.flags)
+	}
+	if after == "" {
+		c.order.Ascend(visit)
+		return
+	}
+	c.order.AscendAfter(hashKey(after), visit)
+}
+
+// expirationLoop 定期删除已过期的条目
+func (c *Cache) expirationLoop() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkExpiration()
+		case <-c.stopChan:
+			return
+		}
+	}
+}
+
+// checkExpiration 弹出堆中已到期的项，对应条目仍有效且确已过期时删除
+func (c *Cache) checkExpiration() {
+	now := time.Now().Unix()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.expiry) > 0 && c.expiry[0].expiresAt < now {
+		item := heap.Pop(&c.expiry).(expiryItem)
+		off, ok := c.index[item.hash]
+		if !ok {
+			continue
+		}
+		if h := readHeader(c.buf[off:]); h.expired(now) {
+			c.remove(int(off), h, item.hash, lru.RemoveExpired)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package arena
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"mygocache/lru"
+)
+
+func TestSetGet(t *testing.T) {
+	c := New(1<<20, nil)
+	defer c.Close()
+
+	v1 := c.Set("key1", []byte("1234"), 3, 0)
+	data, flags, version, ok := c.Get("key1")
+	if !ok || string(data) != "1234" || flags != 3 || version != v1 {
+		t.Fatalf("cache hit key1=1234 failed")
+	}
+	if _, _, _, ok := c.Get("key2"); ok {
+		t.Fatalf("cache miss key2 failed")
+	}
+
+	// 返回的是副本，修改不影响缓存
+	data[0] = 'x'
+	if data, _, _, _ := c.Get("key1"); string(data) != "1234" {
+		t.Fatalf("expected Get to return a copy, got %q", data)
+	}
+
+	c.Set("key1", []byte("5"), 0, 0)
+	if data, _, _, _ := c.Get("key1"); string(data) != "5" || c.Len() != 1 {
+		t.Fatalf("expected overwritten value 5, got %q with %d entries", data, c.Len())
+	}
+	c.Remove("key1")
+	if c.Contains("key1") || c.Len() != 0 {
+		t.Fatalf("expected key1 to be removed")
+	}
+}
+
+func TestEvictionOrder(t *testing.T) {
+	var evicted []string
+	entry := headerSize + len("k0") + 10
+	c := New(int64(3*entry), func(key string, data []byte, flags uint8, reason lru.RemoveReason) {
+		if reason == lru.RemoveEvicted {
+			evicted = append(evicted, key)
+		}
+	})
+	defer c.Close()
+
+	value := []byte("0123456789")
+	for i := 0; i < 5; i++ {
+		c.Set("k"+strconv.Itoa(i), value, 0, 0)
+	}
+	// 按写入顺序淘汰，读取不影响顺序
+	if len(evicted) != 2 || evicted[0] != "k0" || evicted[1] != "k1" {
+		t.Fatalf("expected k0 and k1 to be evicted, got %v", evicted)
+	}
+	for i := 2; i < 5; i++ {
+		if _, _, _, ok := c.Get("k" + strconv.Itoa(i)); !ok {
+			t.Fatalf("expected k%d to be cached", i)
+		}
+	}
+
+	// 覆盖只标记旧条目失效，回绕后数据仍然完整
+	for i := 0; i < 20; i++ {
+		key := "k" + strconv.Itoa(i%4)
+		c.Set(key, []byte(fmt.Sprintf("value-%04d", i)), 0, 0)
+		if data, _, _, ok := c.Get(key); !ok || string(data) != fmt.Sprintf("value-%04d", i) {
+			t.Fatalf("expected %s to hold value-%04d, got %q", key, i, data)
+		}
+	}
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d", c.Len())
+	}
+
+	// 大于整个缓冲区的条目不写入
+	c.Set("k3", make([]byte, 4*entry), 0, 0)
+	if c.Contains("k3") {
+		t.Fatalf("expected oversized entry to be rejected")
+	}
+}
+
+func TestGrow(t *testing.T) {
+	c := New(4<<20, nil)
+	defer c.Close()
+
+	value := make([]byte, 100)
+	for i := 0; i < 20000; i++ {
+		c.Set(strconv.Itoa(i), value, 0, 0)
+		if i%2 == 0 {
+			c.Remove(strconv.Itoa(i))
+		}
+	}
+	if c.Len() != 10000 || len(c.buf) <= initialBufSize {
+		t.Fatalf("expected buffer to grow to hold 10000 entries, got %d entries in %d bytes", c.Len(), len(c.buf))
+	}
+	for i := 1; i < 20000; i += 2 {
+		if _, _, _, ok := c.Get(strconv.Itoa(i)); !ok {
+			t.Fatalf("expected %d to survive growing", i)
+		}
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	expired := make(chan string, 1)
+	c := New(1<<20, func(key string, data []byte, flags uint8, reason lru.RemoveReason) {
+		if reason == lru.RemoveExpired {
+			expired <- key
+		}
+	})
+	defer c.Close()
+
+	c.Set("short", []byte("v"), 0, 1)
+	c.Set("forever", []byte("v"), 0, 0)
+	select {
+	case key := <-expired:
+		if key != "short" {
+			t.Fatalf("expected short to expire, got %s", key)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("expected short to be removed by the expiration loop")
+	}
+	if _, _, _, ok := c.Get("short"); ok {
+		t.Fatalf("expected short to be expired")
+	}
+	if _, _, _, ok := c.Get("forever"); !ok {
+		t.Fatalf("expected forever to stay")
+	}
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	c := New(1<<20, nil)
+	defer c.Close()
+
+	v1 := c.Set("key", []byte("1"), 0, 0)
+	v2, ok := c.CompareAndSwap("key", []byte("2"), 0, v1, 0)
+	if !ok || v2 <= v1 {
+		t.Fatalf("cas with current version failed, got version %d", v2)
+	}
+	if current, ok := c.CompareAndSwap("key", []byte("3"), 0, v1, 0); ok || current != v2 {
+		t.Fatalf("cas with stale version should fail and return %d, got %d", v2, current)
+	}
+	if current, ok := c.CompareAndRemove("key", v1); ok || current != v2 {
+		t.Fatalf("remove with stale version should fail and return %d, got %d", v2, current)
+	}
+
+	v3, err := c.Update("key", func(old []byte, flags uint8, found bool) ([]byte, uint8, int64, error) {
+		if !found || string(old) != "2" {
+			t.Fatalf("expected old value 2, got %q", old)
+		}
+		return []byte("22"), 1, lru.KeepTTL, nil
+	})
+	if err != nil || v3 <= v2 {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, ok := c.CompareAndRemove("key", v3); !ok || c.Contains("key") {
+		t.Fatalf("remove with current version failed")
+	}
+}
+
+// benchmarkGC 写入 n 个小条目后反复强制 GC，报告每次 GC 的耗时与 STW 暂停时间。
+// 标记阶段需要扫描的指针越多，GC 耗时越长
+func benchmarkGC(b *testing.B, n int, set func(key string, value []byte)) {
+	value := make([]byte, 32)
+	for i := 0; i < n; i++ {
+		set("key-"+strconv.Itoa(i), value)
+	}
+	runtime.GC()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+	elapsed := time.Since(start)
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+
+	b.ReportMetric(float64(elapsed.Microseconds())/float64(b.N), "gc-us/op")
+	b.ReportMetric(float64(after.PauseTotalNs-before.PauseTotalNs)/1e3/float64(b.N), "pause-us/op")
+}
+
+type bytesValue []byte
+
+func (v bytesValue) Len() int { return len(v) }
+
+func BenchmarkGCArena(b *testing.B) {
+	c := New(1<<30, nil)
+	defer c.Close()
+	benchmarkGC(b, 1_000_000, func(key string, value []byte) {
+		c.Set(key, value, 0, 0)
+	})
+	runtime.KeepAlive(c)
+}
+
+func BenchmarkGCLRU(b *testing.B) {
+	c := lru.New(1<<30, nil)
+	defer c.Close()
+	benchmarkGC(b, 1_000_000, func(key string, value []byte) {
+		c.Add(key, bytesValue(append([]byte(nil), value...)), 0)
+	})
+	runtime.KeepAlive(c)
+}
+
+func BenchmarkSetArena(b *testing.B) {
+	c := New(64<<20, nil)
+	defer c.Close()
+	value := make([]byte, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set("key-"+strconv.Itoa(i%100000), value, 0, 0)
+	}
+}
+
+func BenchmarkSetLRU(b *testing.B) {
+	c := lru.New(64<<20, nil)
+	defer c.Close()
+	value := make([]byte, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Add("key-"+strconv.Itoa(i%100000), bytesValue(append([]byte(nil), value...)), 0)
+	}
+}
+
+func BenchmarkGetArena(b *testing.B) {
+	c := New(64<<20, nil)
+	defer c.Close()
+	value := make([]byte, 32)
+	for i := 0; i < 100000; i++ {
+		c.Set("key-"+strconv.Itoa(i), value, 0, 0)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Get("key-" + strconv.Itoa(i%100000))
+	}
+}
+
+func BenchmarkGetLRU(b *testing.B) {
+	c := lru.New(64<<20, nil)
+	defer c.Close()
+	value := make([]byte, 32)
+	for i := 0; i < 100000; i++ {
+		c.Add("key-"+strconv.Itoa(i), bytesValue(append([]byte(nil), value...)), 0)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Get("key-" + strconv.Itoa(i%100000))
+	}
+}
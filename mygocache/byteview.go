@@ -1,8 +1,16 @@
 package mygocache
 
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
 // A ByteView holds an immutable view of bytes.
 type ByteView struct {
 	b []byte
+	// enc 非 0 时 b 为压缩等变换后的存储形式，只出现在缓存内部，由 Group 在返回前还原
+	enc valueEncoding
 }
 
 // Len returns the view's length
@@ -15,13 +23,65 @@ func (v ByteView) ByteSlice() []byte {
 	return cloneBytes(v.b)
 }
 
+// UnsafeBytes returns the underlying bytes without copying.
+// The returned slice may be shared with the cache and other readers, so it must
+// never be modified; use ByteSlice when the caller needs to own the data.
+func (v ByteView) UnsafeBytes() []byte {
+	return v.b
+}
+
 // String returns the data as a string, making a copy if necessary.
 func (v ByteView) String() string {
 	return string(v.b)
 }
 
+// Slice returns a view of the data in [from, to) without copying.
+// It panics if the bounds are out of range, like slicing a []byte.
+func (v ByteView) Slice(from, to int) ByteView {
+	return ByteView{b: v.b[from:to]}
+}
+
+// Equal reports whether v and v2 hold the same bytes.
+func (v ByteView) Equal(v2 ByteView) bool {
+	return bytes.Equal(v.b, v2.b)
+}
+
+// Reader returns an io.ReadSeeker over the data without copying.
+func (v ByteView) Reader() io.ReadSeeker {
+	return bytes.NewReader(v.b)
+}
+
+// ReadAt implements io.ReaderAt on the bytes in v.
+func (v ByteView) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("mygocache: negative offset")
+	}
+	if off >= int64(len(v.b)) {
+		return 0, io.EOF
+	}
+	n := copy(p, v.b[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// WriteTo implements io.WriterTo on the bytes in v, writing them without an intermediate copy.
+func (v ByteView) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(v.b)
+	if err == nil && n != len(v.b) {
+		err = io.ErrShortWrite
+	}
+	return int64(n), err
+}
+
 func cloneBytes(b []byte) []byte {
 	c := make([]byte, len(b))
 	copy(c, b)
 	return c
 }
+
+var (
+	_ io.ReaderAt = ByteView{}
+	_ io.WriterTo = ByteView{}
+)
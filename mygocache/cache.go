@@ -1,7 +1,9 @@
 package mygocache
 
 import (
+	"errors"
 	"hash/fnv"
+	"mygocache/arena"
 	"mygocache/lru"
 	"sync"
 	"sync/atomic"
@@ -15,8 +17,14 @@ const (
 	StrategyLRU CacheStrategy = iota
 	// StrategyLRUK 使用 LRU-K 缓存策略
 	StrategyLRUK
+	// StrategyArena 将条目序列化存放在环形字节缓冲区中，以不含指针的索引查找，
+	// 大量小条目时显著降低 GC 扫描开销。按写入顺序（FIFO）淘汰，不支持哈希值
+	StrategyArena
 )
 
+// errArenaHash 表示 StrategyArena 下执行哈希操作
+var errArenaHash = errors.New("hash values are not supported by StrategyArena")
+
 // 默认分片数，必须是 2 的幂
 const defaultShardCount = 32
 
@@ -25,6 +33,7 @@ type cacheShard struct {
 	mu       sync.Mutex // 保护标准 LRU（非并发安全）的并发访问
 	lru      *lru.Cache
 	lruK     *lru.LRUCache
+	arena    *arena.Cache // 内部加锁，不使用 mu
 	cacheBytes int64
 	strategy CacheStrategy
 	k        int
@@ -54,7 +63,12 @@ func (c *cache) getShard(key string) *cacheShard {
 
 // NewCache 创建一个新的分片缓存实例
 func NewCache(cacheBytes int64, strategy CacheStrategy, k int) *cache {
-	shardCount := defaultShardCount
+	return newCache(cacheBytes, strategy, k, defaultShardCount, nil)
+}
+
+// newCache 创建分片缓存，shardCount 必须是 2 的幂，
+// onRemoved 在条目被淘汰、过期、删除或覆盖时调用（持有分片锁期间），value 为 ByteView 或 *lru.Hash
+func newCache(cacheBytes int64, strategy CacheStrategy, k int, shardCount int, onRemoved func(key string, value lru.Value, reason lru.RemoveReason)) *cache {
 	// 每个 shard 分配 cacheBytes/shardCount 的容量
 	perShard := cacheBytes / int64(shardCount)
 	if perShard < 1 {
@@ -72,16 +86,53 @@ func NewCache(cacheBytes int64, strategy CacheStrategy, k int) *cache {
 		s.strategy = strategy
 		s.k = k
 		switch strategy {
+		case StrategyArena:
+			s.arena = arena.New(perShard, arenaOnRemoved(onRemoved))
 		case StrategyLRUK:
 			s.lruK = lru.NewLRUK(perShard, k, nil)
+			s.lruK.OnRemoved = onRemoved
 		default:
 			s.lru = lru.New(perShard, nil)
+			s.lru.OnRemoved = onRemoved
 		}
 	}
 
 	return c
 }
 
+// arenaOnRemoved 将 onRemoved 适配为 arena 的回调。arena 中的数据在回调返回后可能被覆盖，
+// 而钩子在回调返回后才异步还原值，因此需要复制
+func arenaOnRemoved(onRemoved func(key string, value lru.Value, reason lru.RemoveReason)) func(string, []byte, uint8, lru.RemoveReason) {
+	if onRemoved == nil {
+		return nil
+	}
+	return func(key string, data []byte, flags uint8, reason lru.RemoveReason) {
+		onRemoved(key, ByteView{b: cloneBytes(data), enc: valueEncoding(flags)}, reason)
+	}
+}
+
+// setOnCapacityEvicted 设置条目因容量不足被淘汰时的回调（持有分片锁期间，在 onRemoved 之前调用），
+// 附带过期时间。value 只在调用期间有效，哈希值以空的 ByteView 传入
+func (c *cache) setOnCapacityEvicted(fn func(key string, value ByteView, expiresAt int64)) {
+	onLRU := func(key string, value lru.Value, expiresAt int64) {
+		view, _ := value.(ByteView)
+		fn(key, view, expiresAt)
+	}
+	for i := range c.shards {
+		s := &c.shards[i]
+		switch s.strategy {
+		case StrategyArena:
+			s.arena.OnCapacityEvicted = func(key string, data []byte, flags uint8, expiresAt int64) {
+				fn(key, ByteView{b: data, enc: valueEncoding(flags)}, expiresAt)
+			}
+		case StrategyLRUK:
+			s.lruK.OnCapacityEvicted = onLRU
+		default:
+			s.lru.OnCapacityEvicted = onLRU
+		}
+	}
+}
+
 // 默认缓存创建函数（保持向后兼容）
 func defaultCache(cacheBytes int64) *cache {
 	return NewCache(cacheBytes, StrategyLRU, 2)
@@ -91,6 +142,8 @@ func (c *cache) add(key string, value ByteView, ttl int64) {
 	s := c.getShard(key)
 
 	switch s.strategy {
+	case StrategyArena:
+		s.arena.Set(key, value.b, uint8(value.enc), ttl)
 	case StrategyLRUK:
 		s.lruK.Add(key, value, ttl)
 	default:
@@ -105,6 +158,8 @@ func (c *cache) directAdd(key string, value ByteView, ttl int64) {
 	s := c.getShard(key)
 
 	switch s.strategy {
+	case StrategyArena:
+		s.arena.Set(key, value.b, uint8(value.enc), ttl)
 	case StrategyLRUK:
 		s.lruK.DirectAdd(key, value, ttl)
 	default:
@@ -114,36 +169,220 @@ func (c *cache) directAdd(key string, value ByteView, ttl int64) {
 	}
 }
 
-func (c *cache) get(key string) (value ByteView, ok bool) {
+// addIf 同 add（direct 为 true 时同 directAdd），但仅当 cond 返回 true 时写入，返回是否写入。
+// cond 在持有分片锁期间、写入之前调用，exists 表示 key 当前是否在缓存中，cond 中不得访问缓存；
+// LRU-K 下未达到 K 次访问的 key 只记录访问历史，不调用 cond
+func (c *cache) addIf(key string, value ByteView, ttl int64, direct bool, cond func(exists bool) bool) bool {
 	s := c.getShard(key)
 
 	switch s.strategy {
+	case StrategyArena:
+		_, ok := s.arena.SetIf(key, value.b, uint8(value.enc), ttl, cond)
+		return ok
+	case StrategyLRUK:
+		return s.lruK.AddIf(key, value, ttl, direct, cond)
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !cond(s.lru.Contains(key)) {
+			return false
+		}
+		s.lru.Add(key, value, ttl)
+		return true
+	}
+}
+
+// get 返回 key 对应的值，key 的值为哈希时返回 ErrWrongType
+func (c *cache) get(key string) (value ByteView, ok bool, err error) {
+	s := c.getShard(key)
+
+	var v lru.Value
+	switch s.strategy {
+	case StrategyArena:
+		data, flags, _, found := s.arena.Get(key)
+		return ByteView{b: data, enc: valueEncoding(flags)}, found, nil
 	case StrategyLRUK:
 		if s.lruK == nil {
 			return
 		}
-		if v, ok := s.lruK.Get(key); ok {
-			return v.(ByteView), ok
+		v, ok = s.lruK.Get(key)
+	default:
+		if s.lru == nil {
+			return
 		}
+		s.mu.Lock()
+		v, ok = s.lru.Get(key)
+		s.mu.Unlock()
+	}
+	if ok {
+		value, err = asView(v)
+		ok = err == nil
+	}
+	return
+}
+
+// asView 将缓存中的值转换为 ByteView，值为哈希时返回 ErrWrongType
+func asView(v lru.Value) (ByteView, error) {
+	view, ok := v.(ByteView)
+	if !ok {
+		return ByteView{}, ErrWrongType
+	}
+	return view, nil
+}
+
+// getWithVersion 返回 key 对应的值及其版本号，key 的值为哈希时返回 ErrWrongType
+func (c *cache) getWithVersion(key string) (value ByteView, version uint64, ok bool, err error) {
+	s := c.getShard(key)
+
+	var v lru.Value
+	switch s.strategy {
+	case StrategyArena:
+		data, flags, version, found := s.arena.Get(key)
+		return ByteView{b: data, enc: valueEncoding(flags)}, version, found, nil
+	case StrategyLRUK:
+		if s.lruK == nil {
+			return
+		}
+		v, version, ok = s.lruK.GetWithVersion(key)
 	default:
 		if s.lru == nil {
 			return
 		}
 		s.mu.Lock()
-		v, ok := s.lru.Get(key)
+		v, version, ok = s.lru.GetWithVersion(key)
 		s.mu.Unlock()
-		if ok {
-			return v.(ByteView), ok
+	}
+	if ok {
+		value, err = asView(v)
+		ok = err == nil
+	}
+	return
+}
+
+// compareAndSwap 仅当版本号匹配时写入，返回新版本号或当前版本号
+func (c *cache) compareAndSwap(key string, value ByteView, version uint64, ttl int64) (uint64, bool) {
+	s := c.getShard(key)
+
+	switch s.strategy {
+	case StrategyArena:
+		return s.arena.CompareAndSwap(key, value.b, uint8(value.enc), version, ttl)
+	case StrategyLRUK:
+		return s.lruK.CompareAndSwap(key, value, version, ttl)
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lru.CompareAndSwap(key, value, version, ttl)
+	}
+}
+
+// update 原子地读取并改写 key 对应的值，语义同 lru.Cache.Update，key 的值为哈希时返回 ErrWrongType
+func (c *cache) update(key string, fn func(old ByteView, found bool) (ByteView, int64, error)) (uint64, error) {
+	s := c.getShard(key)
+
+	wrapped := func(old lru.Value, found bool) (lru.Value, int64, error) {
+		var view ByteView
+		if found {
+			var err error
+			if view, err = asView(old); err != nil {
+				return nil, 0, err
+			}
 		}
+		return fn(view, found)
 	}
 
-	return
+	switch s.strategy {
+	case StrategyArena:
+		return s.arena.Update(key, func(old []byte, flags uint8, found bool) ([]byte, uint8, int64, error) {
+			view, ttl, err := fn(ByteView{b: old, enc: valueEncoding(flags)}, found)
+			return view.b, uint8(view.enc), ttl, err
+		})
+	case StrategyLRUK:
+		return s.lruK.Update(key, wrapped)
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lru.Update(key, wrapped)
+	}
+}
+
+// updateHash 原子地修改 key 对应的哈希值，语义同 lru.Cache.UpdateHash
+func (c *cache) updateHash(key string, fn func(h *lru.Hash, old lru.Value) (int64, error)) (uint64, error) {
+	s := c.getShard(key)
+
+	switch s.strategy {
+	case StrategyArena:
+		return 0, errArenaHash
+	case StrategyLRUK:
+		return s.lruK.UpdateHash(key, fn)
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lru.UpdateHash(key, fn)
+	}
+}
+
+// view 在持有分片锁期间以 fn 访问 key 对应的值，未命中时返回 false
+func (c *cache) view(key string, fn func(value lru.Value)) bool {
+	s := c.getShard(key)
+
+	switch s.strategy {
+	case StrategyArena:
+		return s.arena.View(key, func(data []byte, flags uint8) {
+			fn(ByteView{b: data, enc: valueEncoding(flags)})
+		})
+	case StrategyLRUK:
+		return s.lruK != nil && s.lruK.View(key, fn)
+	default:
+		if s.lru == nil {
+			return false
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lru.View(key, fn)
+	}
+}
+
+// compareAndRemove 仅当版本号匹配时删除，失败时返回当前版本号
+func (c *cache) compareAndRemove(key string, version uint64) (uint64, bool) {
+	s := c.getShard(key)
+
+	switch s.strategy {
+	case StrategyArena:
+		return s.arena.CompareAndRemove(key, version)
+	case StrategyLRUK:
+		return s.lruK.CompareAndRemove(key, version)
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lru.CompareAndRemove(key, version)
+	}
+}
+
+// contains 判断 key 是否在缓存中，不影响 LRU 顺序与访问历史
+func (c *cache) contains(key string) bool {
+	s := c.getShard(key)
+
+	switch s.strategy {
+	case StrategyArena:
+		return s.arena.Contains(key)
+	case StrategyLRUK:
+		return s.lruK != nil && s.lruK.Contains(key)
+	default:
+		if s.lru == nil {
+			return false
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lru.Contains(key)
+	}
 }
 
 func (c *cache) delete(key string) {
 	s := c.getShard(key)
 
 	switch s.strategy {
+	case StrategyArena:
+		s.arena.Remove(key)
 	case StrategyLRUK:
 		if s.lruK != nil {
 			s.lruK.Remove(key)
@@ -161,6 +400,8 @@ func (c *cache) clear() {
 	for i := range c.shards {
 		s := &c.shards[i]
 		switch s.strategy {
+		case StrategyArena:
+			s.arena.Clear()
 		case StrategyLRUK:
 			if s.lruK != nil {
 				s.lruK.Clear()
@@ -175,6 +416,165 @@ func (c *cache) clear() {
 	}
 }
 
+// close 停止所有分片的过期检查协程
+func (c *cache) close() {
+	for i := range c.shards {
+		s := &c.shards[i]
+		if s.lruK != nil {
+			s.lruK.Close()
+		}
+		if s.arena != nil {
+			s.arena.Close()
+		}
+		if s.lru != nil {
+			s.lru.Close()
+		}
+	}
+}
+
+// shardKeys 按键的哈希顺序返回第 i 个分片中排在 after 之后（after 为空时从头开始）的至多 n 个
+// 有效（非负缓存）条目的键，n <= 0 时返回全部。只在复制这些键期间持有分片锁，过滤由调用方在锁外完成
+func (c *cache) shardKeys(i int, after string, n int) []string {
+	s := &c.shards[i]
+
+	var keys []string
+	collect := func(key string, value lru.Value) bool {
+		if value.Len() > 0 {
+			keys = append(keys, key)
+		}
+		return n <= 0 || len(keys) < n
+	}
+	switch s.strategy {
+	case StrategyArena:
+		s.arena.RangeAfter(after, func(key string, data []byte, flags uint8) bool {
+			if len(data) > 0 {
+				keys = append(keys, key)
+			}
+			return n <= 0 || len(keys) < n
+		})
+	case StrategyLRUK:
+		if s.lruK != nil {
+			s.lruK.RangeAfter(after, collect)
+		}
+	default:
+		if s.lru != nil {
+			s.mu.Lock()
+			s.lru.RangeAfter(after, collect)
+			s.mu.Unlock()
+		}
+	}
+	return keys
+}
+
+// shardEntries 返回第 i 个分片中所有未过期的条目（含负缓存），按从旧到新排列，依次写回即可还原淘汰顺序
+func (c *cache) shardEntries(i int) []snapshotEntry {
+	s := &c.shards[i]
+
+	var entries []snapshotEntry
+	collect := func(key string, value lru.Value, expiresAt int64) bool {
+		entries = append(entries, newSnapshotEntry(key, value, expiresAt))
+		return true
+	}
+	switch s.strategy {
+	case StrategyArena:
+		s.arena.RangeWithExpiry(func(key string, data []byte, flags uint8, expiresAt int64) bool {
+			entries = append(entries, newArenaEntry(key, data, flags, expiresAt))
+			return true
+		})
+		return entries
+	case StrategyLRUK:
+		if s.lruK != nil {
+			s.lruK.RangeWithExpiry(collect)
+		}
+	default:
+		if s.lru != nil {
+			s.mu.Lock()
+			s.lru.RangeWithExpiry(collect)
+			s.mu.Unlock()
+		}
+	}
+	// LRU 从新到旧遍历，反转为从旧到新
+	for l, r := 0, len(entries)-1; l < r; l, r = l+1, r-1 {
+		entries[l], entries[r] = entries[r], entries[l]
+	}
+	return entries
+}
+
+// peek 返回 key 对应的未过期条目，不影响 LRU 顺序、访问历史与命中统计
+func (c *cache) peek(key string) (e snapshotEntry, ok bool) {
+	s := c.getShard(key)
+
+	collect := func(value lru.Value, expiresAt int64) {
+		e = newSnapshotEntry(key, value, expiresAt)
+	}
+	switch s.strategy {
+	case StrategyArena:
+		ok = s.arena.Peek(key, func(data []byte, flags uint8, expiresAt int64) {
+			e = newArenaEntry(key, data, flags, expiresAt)
+		})
+	case StrategyLRUK:
+		ok = s.lruK != nil && s.lruK.Peek(key, collect)
+	default:
+		if s.lru != nil {
+			s.mu.Lock()
+			ok = s.lru.Peek(key, collect)
+			s.mu.Unlock()
+		}
+	}
+	return
+}
+
+// newSnapshotEntry 由 LRU 中的条目构造 snapshotEntry。ByteView 不可变，直接引用；
+// 哈希值会被就地修改，调用方必须持有分片锁，字段在此期间复制
+func newSnapshotEntry(key string, value lru.Value, expiresAt int64) snapshotEntry {
+	e := snapshotEntry{key: key, expiresAt: expiresAt}
+	switch v := value.(type) {
+	case ByteView:
+		e.value = v
+	case *lru.Hash:
+		e.fields = make(map[string][]byte, v.Count())
+		v.Range(func(field string, value []byte) bool {
+			e.fields[field] = value
+			return true
+		})
+	}
+	return e
+}
+
+// newArenaEntry 由 arena 中的条目构造 snapshotEntry，数据在回调返回后可能被覆盖，需要复制
+func newArenaEntry(key string, data []byte, flags uint8, expiresAt int64) snapshotEntry {
+	return snapshotEntry{
+		key:       key,
+		value:     ByteView{b: cloneBytes(data), enc: valueEncoding(flags)},
+		expiresAt: expiresAt,
+	}
+}
+
+// rangeHistory 遍历 LRU-K 分片中尚未进入缓存的 key 的访问历史，其他策略没有访问历史
+func (c *cache) rangeHistory(fn func(key string, ts []int64) bool) {
+	for i := range c.shards {
+		s := &c.shards[i]
+		if s.lruK == nil {
+			continue
+		}
+		stopped := false
+		s.lruK.RangeHistory(func(key string, ts []int64) bool {
+			stopped = !fn(key, ts)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// restoreHistory 将访问历史合并到 key 所在的 LRU-K 分片，其他策略下忽略
+func (c *cache) restoreHistory(key string, ts []int64) {
+	if s := c.getShard(key); s.lruK != nil {
+		s.lruK.RestoreHistory(key, ts)
+	}
+}
+
 func (c *cache) stats() Stats {
 	var totalItems int
 
@@ -182,6 +582,8 @@ func (c *cache) stats() Stats {
 	for i := range c.shards {
 		s := &c.shards[i]
 		switch s.strategy {
+		case StrategyArena:
+			totalItems += s.arena.Len()
 		case StrategyLRUK:
 			if s.lruK != nil {
 				totalItems += s.lruK.Len()
@@ -0,0 +1,241 @@
+package mygocache
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 超过分块阈值的值不作为整体存储（单个值必须放得下一个分片的容量），而是切分为固定大小的分块，
+// 分块按各自的 key 经一致性哈希分散到所属节点，值所属的节点只保存记录分块信息与校验和的清单。
+// 读取时按节点分组、通过 GetMulti 并发获取分块，校验后重新组装。
+// 分块缺失（被淘汰或节点下线）或校验失败时清单被删除，按未命中重新加载。
+// 只有 Set、SetMulti 与 Getter 加载的值会分块；Add、Replace、CompareAndSet 等条件写入按原样存储。
+// 清单被删除或覆盖时不主动删除分块，分块与清单使用相同的 TTL，随后经 LRU 淘汰或过期回收
+
+// chunkKeyMarker 分隔原 key 与分块编号。分块 key 以原 key 开头，与原 key 同属一个命名空间，
+// BumpGeneration 作废命名空间时分块一并作废
+const chunkKeyMarker = "\x00chunk\x00"
+
+// chunkManifestVersion 清单编码格式的版本
+const chunkManifestVersion = 1
+
+var chunkTable = crc32.MakeTable(crc32.Castagnoli)
+
+// isChunkKey 判断 key 是否为内部使用的分块 key，分块不出现在 Scan 结果中，也不触发钩子与变更事件
+func isChunkKey(key string) bool {
+	return strings.Contains(key, chunkKeyMarker)
+}
+
+// chunkManifest 记录一个分块存储的值，编码格式为：
+// 1 字节版本 | 8 字节随机 ID | uvarint 总长度 | uvarint 分块大小 | 每个分块 4 字节 CRC-32C
+type chunkManifest struct {
+	// id 每次写入随机生成，覆盖写入后不会读到旧值残留的分块
+	id        [8]byte
+	size      int
+	chunkSize int
+	sums      []uint32
+}
+
+func (m *chunkManifest) chunkKey(key string, i int) string {
+	return key + chunkKeyMarker + hex.EncodeToString(m.id[:]) + "/" + strconv.Itoa(i)
+}
+
+func (m *chunkManifest) encode() []byte {
+	buf := make([]byte, 1+len(m.id)+2*binary.MaxVarintLen64+4*len(m.sums))
+	buf[0] = chunkManifestVersion
+	n := 1 + copy(buf[1:], m.id[:])
+	n += binary.PutUvarint(buf[n:], uint64(m.size))
+	n += binary.PutUvarint(buf[n:], uint64(m.chunkSize))
+	for _, sum := range m.sums {
+		binary.BigEndian.PutUint32(buf[n:], sum)
+		n += 4
+	}
+	return buf[:n]
+}
+
+func decodeChunkManifest(data []byte) (*chunkManifest, error) {
+	m := &chunkManifest{}
+	if len(data) < 1+len(m.id) || data[0] != chunkManifestVersion {
+		return nil, fmt.Errorf("invalid chunk manifest")
+	}
+	copy(m.id[:], data[1:])
+	data = data[1+len(m.id):]
+
+	size, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid chunk manifest size")
+	}
+	data = data[n:]
+	chunkSize, n := binary.Uvarint(data)
+	if n <= 0 || chunkSize == 0 {
+		return nil, fmt.Errorf("invalid chunk manifest chunk size")
+	}
+	data = data[n:]
+
+	count := (size + chunkSize - 1) / chunkSize
+	if uint64(len(data)) != 4*count {
+		return nil, fmt.Errorf("chunk manifest has %d checksum bytes for %d chunks", len(data), count)
+	}
+	m.size, m.chunkSize = int(size), int(chunkSize)
+	m.sums = make([]uint32, count)
+	for i := range m.sums {
+		m.sums[i] = binary.BigEndian.Uint32(data[4*i:])
+	}
+	return m, nil
+}
+
+// shouldChunk 判断 value 是否需要分块存储
+func (g *Group) shouldChunk(value []byte) bool {
+	return g.chunkThreshold > 0 && len(value) > g.chunkThreshold
+}
+
+// chunkOwner 返回分块 key 所属的远程节点，属于本节点或节点不支持分块存取时返回 false
+func (g *Group) chunkOwner(chunkKey string) (PeerGetter, bool) {
+	if g.peers == nil {
+		return nil, false
+	}
+	peer, ok := g.peers.PickPeer(chunkKey)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := peer.(PeerChunkStore); !ok {
+		return nil, false
+	}
+	return peer, true
+}
+
+// storeChunks 将 value 切分后并发写入各分块所属的节点，返回应写入 key 的清单（存储形式）。
+// 任一节点写入失败时返回错误，此时不应写入清单
+func (g *Group) storeChunks(key string, value []byte, ttl int64) (ByteView, error) {
+	m := &chunkManifest{size: len(value), chunkSize: g.chunkSize}
+	if _, err := rand.Read(m.id[:]); err != nil {
+		return ByteView{}, err
+	}
+
+	local := make(map[string][]byte)
+	remote := make(map[PeerGetter]map[string][]byte)
+	for i := 0; i*m.chunkSize < len(value); i++ {
+		end := (i + 1) * m.chunkSize
+		if end > len(value) {
+			end = len(value)
+		}
+		chunk := value[i*m.chunkSize : end]
+		m.sums = append(m.sums, crc32.Checksum(chunk, chunkTable))
+
+		chunkKey := m.chunkKey(key, i)
+		if peer, ok := g.chunkOwner(chunkKey); ok {
+			if remote[peer] == nil {
+				remote[peer] = make(map[string][]byte)
+			}
+			remote[peer][chunkKey] = chunk
+		} else {
+			local[chunkKey] = chunk
+		}
+	}
+
+	peers := make([]PeerGetter, 0, len(remote))
+	for peer := range remote {
+		peers = append(peers, peer)
+	}
+	if _, err := g.broadcast(peers, func(peer PeerGetter) (int64, error) {
+		return 0, peer.(PeerChunkStore).SetChunks(g.name, remote[peer], ttl)
+	}); err != nil {
+		return ByteView{}, fmt.Errorf("store chunks of key %s: %v", key, err)
+	}
+	g.setChunksLocally(local, ttl)
+
+	return g.encodeValue(key, ByteView{b: m.encode(), enc: encManifest}), nil
+}
+
+// setChunksLocally 将分块写入本节点。分块跳过 LRU-K 的 K 次访问门槛，
+// 否则首次写入的分块不会进入缓存；分块不通知订阅者
+func (g *Group) setChunksLocally(chunks map[string][]byte, ttl int64) {
+	for chunkKey, chunk := range chunks {
+		view := ByteView{b: cloneBytes(chunk)}
+		ck := g.cacheKey(chunkKey)
+		g.mainCache.directAdd(ck, g.encodeValue(chunkKey, view), ttl)
+		g.keyWritten(ck)
+	}
+}
+
+// getChunksLocally 返回本节点缓存的分块，不计入命中统计
+func (g *Group) getChunksLocally(chunkKeys []string) map[string][]byte {
+	result := make(map[string][]byte, len(chunkKeys))
+	for _, chunkKey := range chunkKeys {
+		v, ok, _ := g.mainCache.get(g.cacheKey(chunkKey))
+		if !ok || v.Len() == 0 {
+			continue
+		}
+		v, err := g.decodeValue(chunkKey, v)
+		if err != nil {
+			continue
+		}
+		result[chunkKey] = v.b
+	}
+	return result
+}
+
+// assembleChunks 按清单并发获取 key 的分块并重新组装，分块缺失或校验失败时返回错误
+func (g *Group) assembleChunks(key string, manifest ByteView) (ByteView, error) {
+	m, err := decodeChunkManifest(manifest.b)
+	if err != nil {
+		return ByteView{}, err
+	}
+
+	var local []string
+	remote := make(map[PeerGetter][]string)
+	for i := range m.sums {
+		chunkKey := m.chunkKey(key, i)
+		if peer, ok := g.chunkOwner(chunkKey); ok {
+			remote[peer] = append(remote[peer], chunkKey)
+		} else {
+			local = append(local, chunkKey)
+		}
+	}
+
+	chunks := g.getChunksLocally(local)
+	var mu sync.Mutex
+	peers := make([]PeerGetter, 0, len(remote))
+	for peer := range remote {
+		peers = append(peers, peer)
+	}
+	if _, err := g.broadcast(peers, func(peer PeerGetter) (int64, error) {
+		values, err := peer.(PeerChunkStore).GetMulti(g.name, remote[peer])
+		if err != nil {
+			return 0, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for chunkKey, chunk := range values {
+			chunks[chunkKey] = chunk
+		}
+		return 0, nil
+	}); err != nil {
+		return ByteView{}, fmt.Errorf("fetch chunks: %v", err)
+	}
+
+	value := make([]byte, 0, m.size)
+	for i, sum := range m.sums {
+		chunk, ok := chunks[m.chunkKey(key, i)]
+		if !ok || len(chunk) == 0 {
+			return ByteView{}, fmt.Errorf("chunk %d of %d missing", i, len(m.sums))
+		}
+		if crc32.Checksum(chunk, chunkTable) != sum {
+			atomic.AddInt64(&g.integrityFailures, 1)
+			return ByteView{}, fmt.Errorf("chunk %d checksum mismatch", i)
+		}
+		value = append(value, chunk...)
+	}
+	if len(value) != m.size {
+		atomic.AddInt64(&g.integrityFailures, 1)
+		return ByteView{}, fmt.Errorf("assembled %d bytes, expected %d", len(value), m.size)
+	}
+	return ByteView{b: value}, nil
+}
@@ -0,0 +1,130 @@
+package mygocache
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+// Compressor 压缩算法接口，实现必须并发安全。
+// 可以接入 snappy、zstd 等第三方实现，标准库提供了 gzip 与 flate 两种实现
+type Compressor interface {
+	// Name 返回算法名称，节点间以压缩形式传输时用于校验双方算法一致
+	Name() string
+	Compress(src []byte) ([]byte, error)
+	Decompress(src []byte) ([]byte, error)
+}
+
+// GzipCompressor 使用 compress/gzip 压缩，复用压缩器与解压器以减少分配
+type GzipCompressor struct {
+	level   int
+	writers sync.Pool
+	readers sync.Pool
+}
+
+// NewGzipCompressor 创建 gzip 压缩器，level 取值同 gzip.NewWriterLevel
+func NewGzipCompressor(level int) (*GzipCompressor, error) {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		return nil, err
+	}
+	return &GzipCompressor{level: level}, nil
+}
+
+// Name 实现 Compressor 接口
+func (c *GzipCompressor) Name() string {
+	return "gzip"
+}
+
+// Compress 实现 Compressor 接口
+func (c *GzipCompressor) Compress(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, ok := c.writers.Get().(*gzip.Writer)
+	if ok {
+		w.Reset(&buf)
+	} else {
+		// level 已在构造时校验
+		w, _ = gzip.NewWriterLevel(&buf, c.level)
+	}
+	defer c.writers.Put(w)
+
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress 实现 Compressor 接口
+func (c *GzipCompressor) Decompress(src []byte) ([]byte, error) {
+	r, ok := c.readers.Get().(*gzip.Reader)
+	var err error
+	if ok {
+		err = r.Reset(bytes.NewReader(src))
+	} else {
+		r, err = gzip.NewReader(bytes.NewReader(src))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer c.readers.Put(r)
+	return io.ReadAll(r)
+}
+
+// FlateCompressor 使用 compress/flate 压缩，没有 gzip 的头部与校验和，适合较小的值
+type FlateCompressor struct {
+	level   int
+	writers sync.Pool
+	readers sync.Pool
+}
+
+// NewFlateCompressor 创建 flate 压缩器，level 取值同 flate.NewWriter
+func NewFlateCompressor(level int) (*FlateCompressor, error) {
+	if _, err := flate.NewWriter(io.Discard, level); err != nil {
+		return nil, err
+	}
+	return &FlateCompressor{level: level}, nil
+}
+
+// Name 实现 Compressor 接口
+func (c *FlateCompressor) Name() string {
+	return "flate"
+}
+
+// Compress 实现 Compressor 接口
+func (c *FlateCompressor) Compress(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, ok := c.writers.Get().(*flate.Writer)
+	if ok {
+		w.Reset(&buf)
+	} else {
+		// level 已在构造时校验
+		w, _ = flate.NewWriter(&buf, c.level)
+	}
+	defer c.writers.Put(w)
+
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress 实现 Compressor 接口
+func (c *FlateCompressor) Decompress(src []byte) ([]byte, error) {
+	r, ok := c.readers.Get().(io.ReadCloser)
+	if ok {
+		if err := r.(flate.Resetter).Reset(bytes.NewReader(src), nil); err != nil {
+			return nil, err
+		}
+	} else {
+		r = flate.NewReader(bytes.NewReader(src))
+	}
+	defer c.readers.Put(r)
+	return io.ReadAll(r)
+}
@@ -0,0 +1,92 @@
+package mygocache
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"mygocache/asynclog"
+	"mygocache/lru"
+	"strconv"
+)
+
+// ErrNotInteger 表示 Incr/Decr 的目标值不是合法的十进制整数
+var ErrNotInteger = errors.New("value is not an integer")
+
+// ErrCounterOverflow 表示 Incr/Decr 的结果超出 int64 范围
+var ErrCounterOverflow = errors.New("increment or decrement would overflow")
+
+// Incr 原子地将 key 对应的计数器增加 delta，返回增加后的值。
+// key 不存在（或已过期、命中负缓存）时写入 initial 并返回 initial，
+// ttl 仅在新建计数器时生效，之后的增减保留原有的过期时间。
+// 计数器以十进制字符串存储，可通过 Get 直接读取。
+// 注册了 PeerPicker 时请求会被路由到 key 所属的节点执行
+func (g *Group) Incr(key string, delta, initial, ttl int64) (int64, error) {
+	if key == "" {
+		return 0, fmt.Errorf("key is required")
+	}
+
+	if g.peers != nil {
+		if peer, ok := g.peers.PickPeer(key); ok {
+			if incr, ok := peer.(PeerIncrementer); ok {
+				return incr.Incr(g.name, key, delta, initial, ttl)
+			}
+		}
+	}
+	return g.incrLocally(key, delta, initial, ttl)
+}
+
+// Decr 原子地将 key 对应的计数器减少 delta，语义同 Incr
+func (g *Group) Decr(key string, delta, initial, ttl int64) (int64, error) {
+	if delta == math.MinInt64 {
+		return 0, ErrCounterOverflow
+	}
+	return g.Incr(key, -delta, initial, ttl)
+}
+
+// incrLocally 在本节点的分片锁内完成读-改-写
+func (g *Group) incrLocally(key string, delta, initial, ttl int64) (int64, error) {
+	var result int64
+	var view ByteView
+	ck := g.cacheKey(key)
+	// 先移回磁盘层中的计数器并作废租约，避免从 initial 重新计数或被进行中的加载覆盖
+	g.prepareUpdate(key, ck)
+	_, err := g.mainCache.update(ck, func(old ByteView, found bool) (ByteView, int64, error) {
+		if found && old.Len() > 0 {
+			// 无法还原的值按不存在处理，以 initial 覆盖
+			var err error
+			if old, err = g.decodeValue(key, old); err != nil {
+				asynclog.Printf("[GeeCache] drop undecodable value of key=%s: %v", key, err)
+				found = false
+			}
+		}
+		if !found || old.Len() == 0 {
+			result = initial
+			view = ByteView{b: []byte(strconv.FormatInt(initial, 10))}
+			return g.encodeValue(key, view), ttl, nil
+		}
+
+		n, err := strconv.ParseInt(old.String(), 10, 64)
+		if err != nil {
+			return ByteView{}, 0, ErrNotInteger
+		}
+		if result, err = addInt64(n, delta); err != nil {
+			return ByteView{}, 0, err
+		}
+		view = ByteView{b: []byte(strconv.FormatInt(result, 10))}
+		return g.encodeValue(key, view), lru.KeepTTL, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	g.keyWritten(ck)
+	g.notifySet(key, view)
+	return result, nil
+}
+
+// addInt64 返回 n + delta，结果超出 int64 范围时返回 ErrCounterOverflow
+func addInt64(n, delta int64) (int64, error) {
+	if (delta > 0 && n > math.MaxInt64-delta) || (delta < 0 && n < math.MinInt64-delta) {
+		return 0, ErrCounterOverflow
+	}
+	return n + delta, nil
+}
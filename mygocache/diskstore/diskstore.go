@@ -0,0 +1,303 @@
+// Package diskstore 实现基于追加写段文件的磁盘键值存储，用作内存缓存之下的第二层。
+// 写入总是追加到当前段文件末尾，覆盖与删除只更新内存中的索引；
+// 总大小超过上限时整段丢弃最早的段文件（FIFO），其中仍有效的条目随之淘汰。
+// 存储只在进程运行期间有效：Open 时清除目录中遗留的段文件，Close 时删除全部段文件
+package diskstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 记录的序列化格式：21 字节头部 | 键 | 值。头部依次为
+// 键、标志、过期时间与值的 CRC-32C（4 字节）| 调用方标志（1 字节）| 过期时间（8 字节）| 键长（4 字节）| 值长（4 字节）
+const headerSize = 21
+
+const (
+	// segmentsPerStore 段文件大小取 maxBytes 的 1/segmentsPerStore，丢弃一段时回收约这一比例的空间
+	segmentsPerStore = 16
+	// maxSegmentBytes 单个段文件的最大字节数
+	maxSegmentBytes = 64 << 20
+	// segmentPattern 段文件名的 glob 模式
+	segmentPattern = "*.seg"
+)
+
+var (
+	// ErrClosed 表示存储尚未打开或已关闭
+	ErrClosed = errors.New("disk store is closed")
+	// ErrTooLarge 表示记录大于存储的容量上限
+	ErrTooLarge = errors.New("entry is larger than the disk store")
+	// ErrCorrupt 表示读到的记录与校验和不符，条目已被丢弃
+	ErrCorrupt = errors.New("disk store entry is corrupt")
+)
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+// Store 是并发安全的磁盘键值存储。读取以 ReadAt 并发进行，写入、删除与丢弃段文件互斥
+type Store struct {
+	mu           sync.RWMutex
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+	// segments 按写入顺序排列，最后一个是正在追加的段，nil 表示尚未打开或已关闭
+	segments []*segment
+	nextID   uint64
+	index    map[string]location
+	size     int64 // 所有段文件的总字节数，包括已被覆盖或删除的记录
+	// OnDropped 在条目因段文件被丢弃、读取时已过期或损坏、或 Clear 而被移除时调用（持有锁期间），
+	// Delete 与 Put 覆盖不会调用
+	OnDropped func(key string)
+}
+
+// segment 是一个只追加的段文件
+type segment struct {
+	f    *os.File
+	size int64
+	// keys 写入过该段的键，丢弃段时据此清理索引，其中可能包含已被覆盖或删除的键
+	keys []string
+}
+
+// location 是条目在段文件中的位置
+type location struct {
+	seg       *segment
+	off       int64
+	size      int64
+	expiresAt int64
+}
+
+// New 创建在 dir 中最多占用 maxBytes 字节段文件的存储，需要调用 Open 后才能读写
+func New(dir string, maxBytes int64) *Store {
+	segmentBytes := maxBytes / segmentsPerStore
+	if segmentBytes > maxSegmentBytes {
+		segmentBytes = maxSegmentBytes
+	}
+	if segmentBytes < 1 {
+		segmentBytes = 1
+	}
+	return &Store{
+		dir:          dir,
+		maxBytes:     maxBytes,
+		segmentBytes: segmentBytes,
+		index:        make(map[string]location),
+	}
+}
+
+// Open 创建目录并清除其中遗留的段文件，之后存储可以读写
+func (s *Store) Open() error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	stale, err := filepath.Glob(filepath.Join(s.dir, segmentPattern))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seg, err := s.createSegment()
+	if err != nil {
+		return err
+	}
+	s.segments = []*segment{seg}
+	return nil
+}
+
+// Close 关闭并删除所有段文件（幂等，可多次调用）
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for _, seg := range s.segments {
+		if err := seg.remove(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.segments = nil
+	s.index = make(map[string]location)
+	s.size = 0
+	return firstErr
+}
+
+// Put 写入 key 的值、调用方标志与过期时间戳（0 表示永不过期），已过期的条目不写入。
+// 空间不足时先丢弃最早的段文件
+func (s *Store) Put(key string, data []byte, flags uint8, expiresAt int64) error {
+	if expiresAt > 0 && expiresAt < time.Now().Unix() {
+		return nil
+	}
+	n := int64(headerSize + len(key) + len(data))
+	if n > s.maxBytes {
+		return ErrTooLarge
+	}
+	rec := make([]byte, n)
+	rec[4] = flags
+	binary.LittleEndian.PutUint64(rec[5:], uint64(expiresAt))
+	binary.LittleEndian.PutUint32(rec[13:], uint32(len(key)))
+	binary.LittleEndian.PutUint32(rec[17:], uint32(len(data)))
+	copy(rec[headerSize:], key)
+	copy(rec[headerSize+len(key):], data)
+	binary.LittleEndian.PutUint32(rec, crc32.Checksum(rec[4:], table))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.segments == nil {
+		return ErrClosed
+	}
+	active := s.segments[len(s.segments)-1]
+	if active.size > 0 && active.size+n > s.segmentBytes {
+		seg, err := s.createSegment()
+		if err != nil {
+			return err
+		}
+		s.segments = append(s.segments, seg)
+		active = seg
+	}
+	for s.size+n > s.maxBytes && s.segments[0] != active {
+		s.dropOldest()
+	}
+
+	if _, err := active.f.WriteAt(rec, active.size); err != nil {
+		return err
+	}
+	s.index[key] = location{seg: active, off: active.size, size: n, expiresAt: expiresAt}
+	active.keys = append(active.keys, key)
+	active.size += n
+	s.size += n
+	return nil
+}
+
+// Get 返回 key 的值、调用方标志与过期时间戳，data 是新分配的副本。
+// 已过期的条目视为不存在并被丢弃；记录损坏或读取失败时丢弃条目并返回错误
+func (s *Store) Get(key string) (data []byte, flags uint8, expiresAt int64, ok bool, err error) {
+	s.mu.RLock()
+	loc, found := s.index[key]
+	if !found {
+		s.mu.RUnlock()
+		return nil, 0, 0, false, nil
+	}
+	if loc.expiresAt > 0 && loc.expiresAt < time.Now().Unix() {
+		s.mu.RUnlock()
+		s.drop(key, loc)
+		return nil, 0, 0, false, nil
+	}
+	rec := make([]byte, loc.size)
+	// 持有读锁期间段文件不会被丢弃，ReadAt 可以并发
+	_, err = loc.seg.f.ReadAt(rec, loc.off)
+	s.mu.RUnlock()
+
+	if err == nil {
+		keyLen := int(binary.LittleEndian.Uint32(rec[13:]))
+		if crc32.Checksum(rec[4:], table) != binary.LittleEndian.Uint32(rec) ||
+			headerSize+keyLen > len(rec) || string(rec[headerSize:headerSize+keyLen]) != key {
+			err = ErrCorrupt
+		} else {
+			data = rec[headerSize+keyLen:]
+		}
+	}
+	if err != nil {
+		s.drop(key, loc)
+		return nil, 0, 0, false, fmt.Errorf("read %s from %s: %w", key, loc.seg.f.Name(), err)
+	}
+	return data, rec[4], loc.expiresAt, true, nil
+}
+
+// Delete 移除 key，返回 key 是否存在。记录占用的空间在所属段文件被丢弃时回收
+func (s *Store) Delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.index[key]
+	delete(s.index, key)
+	return ok
+}
+
+// Clear 移除所有条目并删除除当前段以外的段文件
+func (s *Store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.segments) > 1 {
+		s.dropOldest()
+	}
+	for key := range s.index {
+		delete(s.index, key)
+		if s.OnDropped != nil {
+			s.OnDropped(key)
+		}
+	}
+	if len(s.segments) == 1 {
+		active := s.segments[0]
+		active.keys = nil
+		// 截断失败时旧记录只是继续占用空间，不影响正确性
+		if err := active.f.Truncate(0); err == nil {
+			s.size -= active.size
+			active.size = 0
+		}
+	}
+}
+
+// Len 返回条目数量，包括已过期但尚未被丢弃的条目
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.index)
+}
+
+// Size 返回段文件占用的总字节数
+func (s *Store) Size() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// drop 丢弃 key 在 loc 处的条目，条目已被覆盖或删除时什么也不做
+func (s *Store) drop(key string, loc location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cur, ok := s.index[key]; ok && cur == loc {
+		delete(s.index, key)
+		if s.OnDropped != nil {
+			s.OnDropped(key)
+		}
+	}
+}
+
+// dropOldest 删除最早的段文件，并移除仍指向该段的条目。调用方必须已持有 s.mu
+func (s *Store) dropOldest() {
+	seg := s.segments[0]
+	s.segments = s.segments[1:]
+	for _, key := range seg.keys {
+		if loc, ok := s.index[key]; ok && loc.seg == seg {
+			delete(s.index, key)
+			if s.OnDropped != nil {
+				s.OnDropped(key)
+			}
+		}
+	}
+	s.size -= seg.size
+	// 删除失败的段文件只是残留在目录中，下次 Open 时清除
+	seg.remove()
+}
+
+// createSegment 创建一个新的段文件。调用方必须已持有 s.mu
+func (s *Store) createSegment() (*segment, error) {
+	s.nextID++
+	f, err := os.OpenFile(filepath.Join(s.dir, fmt.Sprintf("%016x.seg", s.nextID)), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &segment{f: f}, nil
+}
+
+func (seg *segment) remove() error {
+	seg.f.Close()
+	return os.Remove(seg.f.Name())
+}
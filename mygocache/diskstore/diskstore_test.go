@@ -0,0 +1,123 @@
+package diskstore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func openStore(t *testing.T, dir string, maxBytes int64) *Store {
+	t.Helper()
+	s := New(dir, maxBytes)
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestPutGet(t *testing.T) {
+	dir := t.TempDir()
+	// 遗留的段文件在 Open 时被清除
+	os.WriteFile(filepath.Join(dir, "stale.seg"), []byte("stale"), 0644)
+	s := openStore(t, dir, 1<<20)
+
+	if _, _, _, ok, err := s.Get("key1"); ok || err != nil {
+		t.Fatalf("expected miss before Put, got ok=%v err=%v", ok, err)
+	}
+	if err := s.Put("key1", []byte("1234"), 3, 0); err != nil {
+		t.Fatal(err)
+	}
+	data, flags, expiresAt, ok, err := s.Get("key1")
+	if !ok || err != nil || string(data) != "1234" || flags != 3 || expiresAt != 0 {
+		t.Fatalf("expected key1=1234 with flags 3, got %q %d %d %v %v", data, flags, expiresAt, ok, err)
+	}
+
+	s.Put("key1", []byte("5"), 0, 0)
+	if data, _, _, _, _ := s.Get("key1"); string(data) != "5" || s.Len() != 1 {
+		t.Fatalf("expected overwritten value 5, got %q with %d entries", data, s.Len())
+	}
+	if !s.Delete("key1") || s.Delete("key1") || s.Len() != 0 {
+		t.Fatalf("expected Delete to report whether the key existed")
+	}
+
+	// 已过期的条目不写入，读取时过期的条目被丢弃
+	var dropped []string
+	s.OnDropped = func(key string) { dropped = append(dropped, key) }
+	s.Put("expired", []byte("v"), 0, time.Now().Unix()-10)
+	s.Put("soon", []byte("v"), 0, time.Now().Unix()+100)
+	if _, _, expiresAt, ok, _ := s.Get("soon"); !ok || expiresAt == 0 {
+		t.Fatalf("expected soon to keep its expiry")
+	}
+	loc := s.index["soon"]
+	loc.expiresAt = 1
+	s.index["soon"] = loc
+	if _, _, _, ok, _ := s.Get("soon"); ok || s.Len() != 0 || !reflect.DeepEqual(dropped, []string{"soon"}) {
+		t.Fatalf("expected expired entries to be dropped, got %d entries, dropped %v", s.Len(), dropped)
+	}
+
+	if err := s.Put("huge", make([]byte, 2<<20), 0, 0); err != ErrTooLarge {
+		t.Fatalf("expected ErrTooLarge, got %v", err)
+	}
+
+	s.Close()
+	if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+		t.Fatalf("expected Close to remove segment files, got %v", files)
+	}
+	if err := s.Put("key1", []byte("1"), 0, 0); err != ErrClosed {
+		t.Fatalf("expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestDropOldestSegment(t *testing.T) {
+	// 每段 1KB，每条记录约 121 字节
+	s := openStore(t, t.TempDir(), 16<<10)
+	defer s.Close()
+	var dropped []string
+	s.OnDropped = func(key string) { dropped = append(dropped, key) }
+
+	value := make([]byte, 96)
+	for i := 0; i < 200; i++ {
+		if err := s.Put("key"+strconv.Itoa(i), value, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		if s.Size() > 16<<10 {
+			t.Fatalf("expected size to stay within the limit, got %d", s.Size())
+		}
+	}
+	// 最早写入的条目随所在的段一起被丢弃，最近写入的条目仍然存在
+	if _, _, _, ok, _ := s.Get("key0"); ok {
+		t.Fatalf("expected key0 to be dropped with the oldest segment")
+	}
+	if _, _, _, ok, _ := s.Get("key199"); !ok {
+		t.Fatalf("expected key199 to be kept")
+	}
+	if len(dropped) == 0 || dropped[0] != "key0" || len(dropped)+s.Len() != 200 {
+		t.Fatalf("expected dropped keys to be reported in write order, got %d dropped and %d kept", len(dropped), s.Len())
+	}
+
+	s.Clear()
+	if s.Len() != 0 || s.Size() != 0 {
+		t.Fatalf("expected Clear to remove everything, got %d entries and %d bytes", s.Len(), s.Size())
+	}
+	if files, _ := filepath.Glob(filepath.Join(s.dir, segmentPattern)); len(files) != 1 {
+		t.Fatalf("expected Clear to keep only the active segment, got %v", files)
+	}
+}
+
+func TestCorruptRecord(t *testing.T) {
+	s := openStore(t, t.TempDir(), 1<<20)
+	defer s.Close()
+	s.Put("key", []byte("value"), 0, 0)
+	loc := s.index["key"]
+	loc.seg.f.WriteAt([]byte{'X'}, loc.off+loc.size-1)
+
+	if _, _, _, ok, err := s.Get("key"); ok || err == nil {
+		t.Fatalf("expected corrupt record to be reported, got ok=%v err=%v", ok, err)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("expected corrupt record to be dropped")
+	}
+}
@@ -0,0 +1,304 @@
+package mygocache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mygocache/asynclog"
+	"mygocache/diskstore"
+)
+
+// 磁盘层（WithDiskTier）位于 mainCache 之下：因容量不足被淘汰的值写入磁盘，
+// 内存未命中时先查磁盘，命中的值移回内存，未命中才访问远程节点或 Getter。
+// 显式写入与删除会丢弃磁盘中的旧值，条件写入前先将磁盘中的值移回内存，读取总能看到最新的写入。
+// 哈希、负缓存、分块与分块清单只保存在内存中；磁盘层不随快照或追加日志持久化，重启后为空
+
+// maxPendingSpillBytes 等待后台写入磁盘的值的总字节数上限，超过时新淘汰的值被直接丢弃
+const maxPendingSpillBytes = 16 << 20
+
+// spilledValue 是一个等待写入磁盘层的被淘汰值
+type spilledValue struct {
+	data      []byte
+	flags     uint8
+	expiresAt int64
+	seq       uint64
+}
+
+// spillQueue 缓冲被淘汰的值，由后台协程写入磁盘层，避免在分片锁内同步写磁盘。
+// 值在写入完成前一直留在队列中，读取时先查队列再查磁盘；
+// 显式写入或移回内存时取消对应的待写值，正在写入的值在写入完成后再从磁盘删除
+type spillQueue struct {
+	mu      sync.Mutex
+	pending map[string]spilledValue
+	bytes   int64
+	seq     uint64
+	// inflight 正在写入磁盘的 key 与其序号，canceled 表示它在写入期间被取消
+	inflight    string
+	inflightSeq uint64
+	canceled    bool
+
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	started  bool
+	stopOnce sync.Once
+}
+
+func newSpillQueue() *spillQueue {
+	return &spillQueue{
+		pending: make(map[string]spilledValue),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// push 将 ck 的值加入队列，超过缓冲上限时返回 false
+func (q *spillQueue) push(ck string, data []byte, flags uint8, expiresAt int64) bool {
+	q.mu.Lock()
+	old, exists := q.pending[ck]
+	if q.bytes-int64(len(old.data))+int64(len(data)) > maxPendingSpillBytes {
+		q.mu.Unlock()
+		return false
+	}
+	q.seq++
+	q.pending[ck] = spilledValue{data: data, flags: flags, expiresAt: expiresAt, seq: q.seq}
+	q.bytes += int64(len(data))
+	if exists {
+		q.bytes -= int64(len(old.data))
+	}
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// get 返回 ck 尚未写入磁盘的值
+func (q *spillQueue) get(ck string) (spilledValue, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	v, ok := q.pending[ck]
+	return v, ok
+}
+
+// cancel 取消 ck 的待写值，返回是否存在
+func (q *spillQueue) cancel(ck string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	v, ok := q.pending[ck]
+	if ok {
+		delete(q.pending, ck)
+		q.bytes -= int64(len(v.data))
+	}
+	if q.inflight == ck {
+		q.canceled = true
+	}
+	return ok
+}
+
+// clear 取消所有待写值，返回被取消的 key
+func (q *spillQueue) clear() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	keys := make([]string, 0, len(q.pending))
+	for ck := range q.pending {
+		keys = append(keys, ck)
+	}
+	q.pending = make(map[string]spilledValue)
+	q.bytes = 0
+	if q.inflight != "" {
+		q.canceled = true
+	}
+	return keys
+}
+
+// len 返回尚未写入磁盘的值的数量
+func (q *spillQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// next 取出一个待写值并标记为正在写入，值仍留在队列中供读取
+func (q *spillQueue) next() (string, spilledValue, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for ck, v := range q.pending {
+		q.inflight, q.inflightSeq, q.canceled = ck, v.seq, false
+		return ck, v, true
+	}
+	return "", spilledValue{}, false
+}
+
+// start 启动后台写入协程，写入由 put 完成，丢弃的值由 drop 处理
+func (q *spillQueue) start(disk *diskstore.Store, drop func(ck string)) {
+	q.started = true
+	go func() {
+		defer close(q.done)
+		for {
+			select {
+			case <-q.wake:
+			case <-q.stop:
+				return
+			}
+			for {
+				select {
+				case <-q.stop:
+					return
+				default:
+				}
+				ck, v, ok := q.next()
+				if !ok {
+					break
+				}
+				err := disk.Put(ck, v.data, v.flags, v.expiresAt)
+				if err != nil && err != diskstore.ErrTooLarge {
+					asynclog.Printf("[GeeCache] spill key=%s to disk failed: %v", ck, err)
+				}
+				q.finish(ck, v.seq, err, disk, drop)
+			}
+		}
+	}()
+}
+
+// finish 在写入完成后将值移出队列；写入期间被取消时从磁盘删除刚写入的旧值
+func (q *spillQueue) finish(ck string, seq uint64, err error, disk *diskstore.Store, drop func(ck string)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	canceled := q.canceled
+	q.inflight, q.inflightSeq, q.canceled = "", 0, false
+	if canceled {
+		if err == nil {
+			disk.Delete(ck)
+		}
+		return
+	}
+	// 写入期间 ck 再次被淘汰时保留较新的值，等待下一轮写入
+	if v, ok := q.pending[ck]; ok && v.seq == seq {
+		delete(q.pending, ck)
+		q.bytes -= int64(len(v.data))
+		if err != nil {
+			drop(ck)
+		}
+	}
+}
+
+// close 停止后台写入协程并等待其退出，未写入的值被丢弃
+func (q *spillQueue) close() {
+	q.stopOnce.Do(func() {
+		close(q.stop)
+		if q.started {
+			<-q.done
+		}
+	})
+}
+
+// spill 在条目因容量不足被淘汰时由分片调用（持有分片锁期间），将值交给后台协程写入磁盘层。
+// 写入磁盘的 key 保留标签，直到它被显式删除或被磁盘层丢弃
+func (g *Group) spill(ck string, value ByteView, expiresAt int64) {
+	userKey, current := g.gens.userKey(ck)
+	expired := expiresAt > 0 && expiresAt < time.Now().Unix()
+	// 旧代数的值不会再被读取，无需写入
+	if !current || expired || value.Len() == 0 || isChunkKey(userKey) || value.enc&encManifest != 0 {
+		g.tags.remove(ck)
+		return
+	}
+	// 淘汰后数据可能被复用（StrategyArena），写入前需要复制
+	if !g.spills.push(ck, cloneBytes(value.b), uint8(value.enc), expiresAt) {
+		g.tags.remove(ck)
+	}
+}
+
+// readDisk 读取 ck 在磁盘层中的值，先查尚未写入的队列
+func (g *Group) readDisk(key, ck string) (data []byte, flags uint8, expiresAt int64, ok bool) {
+	if v, ok := g.spills.get(ck); ok {
+		if v.expiresAt > 0 && v.expiresAt < time.Now().Unix() {
+			return nil, 0, 0, false
+		}
+		return v.data, v.flags, v.expiresAt, true
+	}
+	data, flags, expiresAt, ok, err := g.disk.Get(ck)
+	if err != nil {
+		asynclog.Printf("[GeeCache] read key=%s from disk failed: %v", key, err)
+	}
+	return data, flags, expiresAt, ok
+}
+
+// getFromDisk 在内存未命中时查找磁盘层，命中时将值连同剩余 TTL 移回内存。
+// 在填充租约内完成，查找期间 key 被 Delete/Set 时不写回，避免旧值覆盖新的写入
+func (g *Group) getFromDisk(key, ck string) (ByteView, bool) {
+	token, leased := g.leases.grant(ck, false)
+	data, flags, expiresAt, ok := g.readDisk(key, ck)
+	if !ok {
+		if leased {
+			// 释放租约，随后的加载重新申请
+			g.leases.release(ck, token)
+		}
+		return ByteView{}, false
+	}
+
+	atomic.AddInt64(&g.diskHits, 1)
+	value := ByteView{b: data, enc: valueEncoding(flags)}
+	if !leased {
+		return value, true
+	}
+	var ttl int64
+	if expiresAt > 0 {
+		if ttl = expiresAt - time.Now().Unix(); ttl < 1 {
+			ttl = 1
+		}
+	}
+	g.leases.redeem(ck, token, func(held func() bool) bool {
+		if !held() {
+			return false
+		}
+		// 先从磁盘移除再写入内存，写入时若立即被淘汰会重新写入磁盘
+		g.spills.cancel(ck)
+		g.disk.Delete(ck)
+		return g.mainCache.addIf(ck, value, ttl, true, func(bool) bool { return held() })
+	})
+	return value, true
+}
+
+// prepareUpdate 在条件写入（Add、Replace、CompareAndSet、CompareAndDelete、Incr）之前调用：
+// 将只在磁盘层中的值移回内存，使写入基于它的当前值，然后作废进行中的填充租约，
+// 避免加载结果覆盖本次写入。移回内存的值会分配新的版本号
+func (g *Group) prepareUpdate(key, ck string) {
+	if g.disk != nil && !g.mainCache.contains(ck) {
+		// 作废其他调用方的租约，由本次移回持有
+		g.leases.invalidate(ck)
+		g.getFromDisk(key, ck)
+	}
+	g.leases.invalidate(ck)
+}
+
+// keyWritten 在显式写入或删除 ck 之后调用：丢弃磁盘层中的旧值，并将 ck 的当前状态追加到日志
+func (g *Group) keyWritten(ck string) {
+	if g.disk != nil {
+		pending := g.spills.cancel(ck)
+		if g.disk.Delete(ck) || pending {
+			if !g.mainCache.contains(ck) {
+				// 只在磁盘中的 key 被删除，清理其保留的标签
+				g.tags.remove(ck)
+			}
+		}
+	}
+	g.logKey(ck)
+}
+
+// diskLen 返回磁盘层中（包括尚未写入的）条目数量
+func (g *Group) diskLen() int {
+	return g.disk.Len() + g.spills.len()
+}
+
+// clearDisk 清空磁盘层与尚未写入的值
+func (g *Group) clearDisk() {
+	for _, ck := range g.spills.clear() {
+		g.tags.remove(ck)
+	}
+	g.disk.Clear()
+}
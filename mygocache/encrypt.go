@@ -0,0 +1,152 @@
+package mygocache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownKey 表示 KeyProvider 中不存在指定 ID 的密钥（如已被 Retire）
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// KeyProvider 为 AES-GCM 加密提供密钥，实现必须并发安全。
+// 密钥以 ID 标识，同一 ID 对应的密钥不能改变；长度必须为 16、24 或 32 字节
+type KeyProvider interface {
+	// CurrentKey 返回加密新值使用的密钥及其 ID
+	CurrentKey() (id uint32, key []byte, err error)
+	// Key 返回指定 ID 的密钥，用于解密以旧密钥加密的值，不存在时返回 ErrUnknownKey
+	Key(id uint32) ([]byte, error)
+}
+
+// KeyRing 是内存中的 KeyProvider，支持密钥轮换：
+// Rotate 之后新值使用新密钥加密，旧密钥保留用于解密已有的值，直到被 Retire
+type KeyRing struct {
+	mu      sync.RWMutex
+	current uint32
+	keys    map[uint32][]byte
+}
+
+// NewKeyRing 创建以 key 为当前密钥的 KeyRing
+func NewKeyRing(id uint32, key []byte) (*KeyRing, error) {
+	r := &KeyRing{keys: make(map[uint32][]byte)}
+	if err := r.Rotate(id, key); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rotate 添加密钥并将其设为当前密钥。id 已存在时密钥必须相同，用于切回旧密钥
+func (r *KeyRing) Rotate(id uint32, key []byte) error {
+	if _, err := aes.NewCipher(key); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.keys[id]; ok && string(old) != string(key) {
+		return fmt.Errorf("key %d already exists with different material", id)
+	}
+	r.keys[id] = cloneBytes(key)
+	r.current = id
+	return nil
+}
+
+// Retire 移除不再需要的旧密钥，之后以该密钥加密的值按未命中处理。不能移除当前密钥
+func (r *KeyRing) Retire(id uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id == r.current {
+		return fmt.Errorf("cannot retire current key %d", id)
+	}
+	delete(r.keys, id)
+	return nil
+}
+
+// CurrentKey 实现 KeyProvider 接口
+func (r *KeyRing) CurrentKey() (uint32, []byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current, r.keys[r.current], nil
+}
+
+// Key 实现 KeyProvider 接口
+func (r *KeyRing) Key(id uint32) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// envelope 使用 AES-GCM 加解密缓存中的值，密文格式为：4 字节密钥 ID | 12 字节 nonce | 密文与认证标签
+type envelope struct {
+	keys  KeyProvider
+	aeads sync.Map // 密钥 ID -> cipher.AEAD
+}
+
+func newEnvelope(keys KeyProvider) *envelope {
+	return &envelope{keys: keys}
+}
+
+// aead 返回密钥对应的 AEAD，按 ID 缓存
+func (e *envelope) aead(id uint32, key []byte) (cipher.AEAD, error) {
+	if a, ok := e.aeads.Load(id); ok {
+		return a.(cipher.AEAD), nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	a, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	e.aeads.Store(id, a)
+	return a, nil
+}
+
+// seal 以当前密钥加密 plaintext，aad 参与认证但不加密
+func (e *envelope) seal(plaintext, aad []byte) ([]byte, error) {
+	id, key, err := e.keys.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+	a, err := e.aead(id, key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 4+a.NonceSize(), 4+a.NonceSize()+len(plaintext)+a.Overhead())
+	binary.BigEndian.PutUint32(out, id)
+	nonce := out[4:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return a.Seal(out, nonce, plaintext, aad), nil
+}
+
+// open 解密 seal 生成的密文，密文被篡改、aad 不匹配或密钥不存在时返回错误
+func (e *envelope) open(data, aad []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	id := binary.BigEndian.Uint32(data)
+	key, err := e.keys.Key(id)
+	if err != nil {
+		return nil, err
+	}
+	a, err := e.aead(id, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4+a.NonceSize()+a.Overhead() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce := data[4 : 4+a.NonceSize()]
+	return a.Open(nil, nonce, data[4+a.NonceSize():], aad)
+}
@@ -1,10 +1,25 @@
 package mygocache
 
 import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"mygocache/kitex_gen/geecache"
 )
 
 var db = map[string]string{
@@ -26,7 +41,7 @@ func TestGetter(t *testing.T) {
 
 func TestGet(t *testing.T) {
 	loadCounts := make(map[string]int, len(db))
-	gee := NewGroup("scores", 2<<10, GetterFunc(
+	gee, err := NewGroup("scores", GetterFunc(
 		func(key string) ([]byte, error) {
 			log.Println("[SlowDB] search key", key)
 			if v, ok := db[key]; ok {
@@ -37,7 +52,11 @@ func TestGet(t *testing.T) {
 				return []byte(v), nil
 			}
 			return nil, fmt.Errorf("%s not exist", key)
-		}))
+		}), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
 
 	for k, v := range db {
 		if view, err := gee.Get(k); err != nil || view.String() != v {
@@ -55,8 +74,11 @@ func TestGet(t *testing.T) {
 
 func TestGetGroup(t *testing.T) {
 	groupName := "scores"
-	NewGroup(groupName, 2<<10, GetterFunc(
-		func(key string) (bytes []byte, err error) { return }))
+	_, err := NewGroup(groupName, GetterFunc(
+		func(key string) (bytes []byte, err error) { return }), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
 	if group := GetGroup(groupName); group == nil || group.name != groupName {
 		t.Fatalf("group %s not exist", groupName)
 	}
@@ -65,3 +87,1991 @@ func TestGetGroup(t *testing.T) {
 		t.Fatalf("expect nil, but %s got", group.name)
 	}
 }
+
+func TestCompareAndSet(t *testing.T) {
+	gee, err := NewGroup("cas", GetterFunc(
+		func(key string) ([]byte, error) {
+			if v, ok := db[key]; ok {
+				return []byte(v), nil
+			}
+			return nil, fmt.Errorf("%s not exist", key)
+		}), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view, version, err := gee.GetWithVersion("Tom")
+	if err != nil || view.String() != "630" || version == 0 {
+		t.Fatalf("gets Tom failed: %v", err)
+	}
+
+	newVersion, err := gee.CompareAndSet("Tom", []byte("700"), version, 0)
+	if err != nil || newVersion <= version {
+		t.Fatalf("cas with current version failed: %v", err)
+	}
+	if _, err := gee.CompareAndSet("Tom", []byte("800"), version, 0); err != ErrVersionMismatch {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+	if view, _ := gee.Get("Tom"); view.String() != "700" {
+		t.Fatalf("expected 700 after cas, got %s", view)
+	}
+
+	if err := gee.CompareAndDelete("Tom", version); err != ErrVersionMismatch {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+	if err := gee.CompareAndDelete("Tom", newVersion); err != nil {
+		t.Fatalf("cad with current version failed: %v", err)
+	}
+	if _, err := gee.CompareAndSet("Tom", []byte("900"), newVersion, 0); err != ErrKeyNotFound {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestIncr(t *testing.T) {
+	gee, err := NewGroup("counters", GetterFunc(
+		func(key string) ([]byte, error) {
+			return nil, fmt.Errorf("%s not exist", key)
+		}), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := gee.Incr("hits", 1, 10, 0); err != nil || v != 10 {
+		t.Fatalf("expected initial value 10, got %d (%v)", v, err)
+	}
+	if v, err := gee.Incr("hits", 5, 10, 0); err != nil || v != 15 {
+		t.Fatalf("expected 15, got %d (%v)", v, err)
+	}
+	if v, err := gee.Decr("hits", 3, 0, 0); err != nil || v != 12 {
+		t.Fatalf("expected 12, got %d (%v)", v, err)
+	}
+	if view, err := gee.Get("hits"); err != nil || view.String() != "12" {
+		t.Fatalf("expected counter readable via Get, got %s (%v)", view, err)
+	}
+
+	// 负缓存中的 key 视为不存在
+	if _, err := gee.Get("missing"); err == nil {
+		t.Fatalf("expected load error for missing key")
+	}
+	if v, err := gee.Incr("missing", 1, 1, 0); err != nil || v != 1 {
+		t.Fatalf("expected negative cached key to be initialized, got %d (%v)", v, err)
+	}
+
+	gee.Set("text", []byte("abc"), 0)
+	if _, err := gee.Incr("text", 1, 0, 0); err != ErrNotInteger {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+
+	gee.Set("max", []byte("9223372036854775807"), 0)
+	if _, err := gee.Incr("max", 1, 0, 0); err != ErrCounterOverflow {
+		t.Fatalf("expected ErrCounterOverflow, got %v", err)
+	}
+}
+
+func TestIncrConcurrent(t *testing.T) {
+	gee, err := NewGroup("counters-concurrent", GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gee.Incr("requests", 1, 1, 0)
+		}()
+	}
+	wg.Wait()
+
+	if view, _ := gee.Get("requests"); view.String() != "50" {
+		t.Fatalf("expected 50 after concurrent increments, got %s", view)
+	}
+}
+
+func TestAddReplace(t *testing.T) {
+	for _, strategy := range []CacheStrategy{StrategyLRU, StrategyLRUK} {
+		gee := NewGroupWithOptions(fmt.Sprintf("nx-xx-%d", strategy), 2<<10, GetterFunc(
+			func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }), 0, strategy, 2)
+
+		if _, err := gee.Replace("lock", []byte("a"), 0); err != ErrKeyNotFound {
+			t.Fatalf("expected ErrKeyNotFound on replace of missing key, got %v", err)
+		}
+		if _, err := gee.Add("lock", []byte("a"), 0); err != nil {
+			t.Fatalf("add of missing key failed: %v", err)
+		}
+		if _, err := gee.Add("lock", []byte("b"), 0); err != ErrKeyExists {
+			t.Fatalf("expected ErrKeyExists, got %v", err)
+		}
+		if _, err := gee.Replace("lock", []byte("c"), 0); err != nil {
+			t.Fatalf("replace of existing key failed: %v", err)
+		}
+		if view, _ := gee.Get("lock"); view.String() != "c" {
+			t.Fatalf("expected c, got %s", view)
+		}
+	}
+}
+
+func TestLease(t *testing.T) {
+	gee, err := NewGroup("leases", GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := gee.LeaseGet("profile")
+	if err != nil || first.Token == 0 {
+		t.Fatalf("first miss should be granted a lease, got %+v (%v)", first, err)
+	}
+	if second, _ := gee.LeaseGet("profile"); !second.Retry || second.Token != 0 {
+		t.Fatalf("concurrent miss should be told to retry, got %+v", second)
+	}
+
+	if err := gee.LeaseSet("profile", []byte("v1"), first.Token+1, 0); err != ErrLeaseInvalid {
+		t.Fatalf("expected ErrLeaseInvalid for a foreign token, got %v", err)
+	}
+	if err := gee.LeaseSet("profile", []byte("v1"), first.Token, 0); err != nil {
+		t.Fatalf("lease holder fill failed: %v", err)
+	}
+	if res, _ := gee.LeaseGet("profile"); !res.Hit || res.Value.String() != "v1" {
+		t.Fatalf("expected hit after fill, got %+v", res)
+	}
+
+	// Delete 作废未兑现的租约，慢加载方的写回被拒绝
+	gee.Delete("profile")
+	stale, _ := gee.LeaseGet("profile")
+	gee.Delete("profile")
+	if err := gee.LeaseSet("profile", []byte("stale"), stale.Token, 0); err != ErrLeaseInvalid {
+		t.Fatalf("expected ErrLeaseInvalid after delete, got %v", err)
+	}
+	if res, _ := gee.LeaseGet("profile"); res.Hit || res.Token == 0 {
+		t.Fatalf("expected a fresh lease after delete, got %+v", res)
+	}
+}
+
+func TestLeaseTakeover(t *testing.T) {
+	var loads int32
+	gee, err := NewGroup("lease-takeover", GetterFunc(
+		func(key string) ([]byte, error) {
+			atomic.AddInt32(&loads, 1)
+			return []byte("loaded"), nil
+		}), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+
+	// 调用方拿到租约后被遗弃，普通读取接管租约并写回缓存，不必等到租约过期
+	abandoned, _ := gee.LeaseGet("profile")
+	if abandoned.Token == 0 {
+		t.Fatalf("expected a lease, got %+v", abandoned)
+	}
+	for i := 0; i < 2; i++ {
+		if view, err := gee.Get("profile"); err != nil || view.String() != "loaded" {
+			t.Fatalf("Get = %q, %v", view, err)
+		}
+	}
+	if n := atomic.LoadInt32(&loads); n != 1 {
+		t.Fatalf("expected the load to be cached despite the abandoned lease, got %d loads", n)
+	}
+	if err := gee.LeaseSet("profile", []byte("late"), abandoned.Token, 0); err != ErrLeaseInvalid {
+		t.Fatalf("expected ErrLeaseInvalid for a taken-over lease, got %v", err)
+	}
+	if view, _ := gee.Get("profile"); view.String() != "loaded" {
+		t.Fatalf("taken-over lease overwrote the value: %s", view)
+	}
+}
+
+func TestDeleteDuringLoad(t *testing.T) {
+	var loads int
+	entered, release := make(chan struct{}), make(chan struct{})
+	gee := NewGroupWithOptions("delete-during-load", 2<<10, GetterFunc(
+		func(key string) ([]byte, error) {
+			loads++
+			if loads == 1 {
+				close(entered)
+				<-release
+				return []byte("stale"), nil
+			}
+			return []byte("fresh"), nil
+		}), 0, StrategyLRU, 2)
+
+	done := make(chan ByteView)
+	go func() {
+		view, _ := gee.Get("key")
+		done <- view
+	}()
+
+	<-entered
+	gee.Delete("key")
+	close(release)
+	if view := <-done; view.String() != "stale" {
+		t.Fatalf("in-flight load should still return its value, got %s", view)
+	}
+
+	if view, _ := gee.Get("key"); view.String() != "fresh" {
+		t.Fatalf("stale value re-populated after delete, got %s", view)
+	}
+}
+
+// blockingPeer 的 Get 在 release 关闭前阻塞，用于模拟慢 RPC
+type blockingPeer struct {
+	entered, release chan struct{}
+}
+
+func (p blockingPeer) Get(group string, key string) ([]byte, error) {
+	close(p.entered)
+	<-p.release
+	return []byte("stale"), nil
+}
+
+type blockingPicker struct {
+	peer blockingPeer
+}
+
+func (p blockingPicker) PickPeer(key string) (PeerGetter, bool) {
+	return p.peer, true
+}
+
+func TestWriteDuringLoad(t *testing.T) {
+	writes := map[string]func(g *Group) error{
+		"Add": func(g *Group) error {
+			_, err := g.Add("key", []byte("7"), 0)
+			return err
+		},
+		"Incr": func(g *Group) error {
+			_, err := g.Incr("key", 1, 7, 0)
+			return err
+		},
+		"SetMulti": func(g *Group) error {
+			return g.SetMulti(map[string][]byte{"key": []byte("7")}, 0)
+		},
+	}
+	for name, write := range writes {
+		entered, release := make(chan struct{}), make(chan struct{})
+		gee := NewGroupWithOptions("write-during-load", 2<<10, GetterFunc(
+			func(key string) ([]byte, error) {
+				close(entered)
+				<-release
+				return []byte("stale"), nil
+			}), 0, StrategyLRU, 2)
+
+		done := make(chan struct{})
+		go func() {
+			gee.Get("key")
+			close(done)
+		}()
+		<-entered
+		if err := write(gee); err != nil {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+		close(release)
+		<-done
+		if view, _ := gee.Get("key"); view.String() != "7" {
+			t.Fatalf("%s: in-flight load overwrote the write, got %s", name, view)
+		}
+		gee.Close()
+	}
+
+	// 从远程节点获取的值同样不能在 Delete 之后写回
+	peer := blockingPeer{make(chan struct{}), make(chan struct{})}
+	gee := NewGroupWithOptions("delete-during-peer-load", 2<<10, GetterFunc(
+		func(key string) ([]byte, error) { return []byte("fresh"), nil }), 0, StrategyLRU, 2)
+	defer gee.Close()
+	gee.RegisterPeers(blockingPicker{peer})
+	done := make(chan struct{})
+	go func() {
+		gee.Get("key")
+		close(done)
+	}()
+	<-peer.entered
+	gee.Delete("key")
+	close(peer.release)
+	<-done
+	if gee.mainCache.contains(gee.cacheKey("key")) {
+		t.Fatalf("peer value re-populated after delete")
+	}
+}
+
+func TestScan(t *testing.T) {
+	gee, err := NewGroup("scan", GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }), WithCacheBytes(2<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := make(map[string]bool)
+	for i := 0; i < 25; i++ {
+		key := fmt.Sprintf("user:%d", i)
+		gee.Set(key, []byte("v"), 0)
+		want[key] = true
+	}
+	gee.Set("order:1", []byte("v"), 0)
+	gee.Get("user:missing") // 负缓存条目不应出现在结果中
+
+	seen := make(map[string]bool)
+	cursor := ""
+	for {
+		keys, next, err := gee.Scan(cursor, "user:*", 4)
+		if err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		if len(keys) > 4 {
+			t.Fatalf("scan returned %d keys, more than count", len(keys))
+		}
+		for _, key := range keys {
+			if seen[key] {
+				t.Fatalf("key %s returned twice", key)
+			}
+			seen[key] = true
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if !reflect.DeepEqual(seen, want) {
+		t.Fatalf("expected %d user keys, got %v", len(want), seen)
+	}
+
+	// count 限制每次检查的条目数：不匹配任何键的 pattern 也只检查 count 个条目就返回游标
+	keys, next, err := gee.Scan("", "missing:*", 4)
+	if err != nil || len(keys) != 0 || next == "" {
+		t.Fatalf("expected an empty page with a cursor, got %v %q %v", keys, next, err)
+	}
+	calls := 1
+	for ; next != ""; calls++ {
+		if keys, next, err = gee.Scan(next, "missing:*", 4); err != nil || len(keys) != 0 {
+			t.Fatalf("expected no matches, got %v %v", keys, err)
+		}
+	}
+	// 27 个条目（含 order:1 与负缓存条目）每次至多检查 4 个
+	if calls < (27+3)/4 {
+		t.Fatalf("expected the scan to take several calls, took %d", calls)
+	}
+
+	if _, _, err := gee.Scan("not a cursor", "", 10); err != ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	// 各策略下按键的哈希顺序分页，页之间的读写不改变顺序
+	for _, strategy := range []CacheStrategy{StrategyLRU, StrategyLRUK, StrategyArena} {
+		g, err := NewGroup(fmt.Sprintf("scan-%d", strategy), GetterFunc(
+			func(key string) ([]byte, error) { return []byte(key), nil }),
+			WithCacheBytes(2<<20), WithShardCount(2), WithStrategy(strategy, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 300; i++ {
+			g.Set(fmt.Sprintf("key:%d", i), []byte("v"), 0)
+		}
+		seen := make(map[string]bool)
+		pages := 0
+		for cursor := ""; ; pages++ {
+			keys, next, err := g.Scan(cursor, "", 7)
+			if err != nil {
+				t.Fatalf("scan failed: %v", err)
+			}
+			for _, key := range keys {
+				if seen[key] {
+					t.Fatalf("strategy %d: key %s returned twice", strategy, key)
+				}
+				seen[key] = true
+				g.Get(key)
+				g.Set(key, []byte("v2"), 0)
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if len(seen) != 300 || pages > 300/7+2 {
+			t.Fatalf("strategy %d: expected 300 keys in about %d pages, got %d keys in %d pages",
+				strategy, 300/7+1, len(seen), pages)
+		}
+		g.Close()
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		pattern, key string
+		match        bool
+	}{
+		{"", "anything", true},
+		{"user:*", "user:1", true},
+		{"user:*", "order:1", false},
+		{"*:feed", "user:1:feed", true},
+		{"user:?", "user:12", false},
+		{"u*r:*1", "user:21", true},
+		{"exact", "exact", true},
+	}
+	for _, c := range cases {
+		if got := matchPattern(c.pattern, c.key); got != c.match {
+			t.Fatalf("matchPattern(%q, %q) = %v, want %v", c.pattern, c.key, got, c.match)
+		}
+	}
+}
+
+// taggedDB 实现 TaggedGetter，为加载结果附加所属用户的标签
+type taggedDB map[string]string
+
+func (d taggedDB) Get(key string) ([]byte, error) {
+	if v, ok := d[key]; ok {
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("%s not exist", key)
+}
+
+func (d taggedDB) GetWithTags(key string) ([]byte, []string, error) {
+	v, err := d.Get(key)
+	return v, []string{"user:" + strings.SplitN(key, ":", 2)[0]}, err
+}
+
+func TestInvalidateTag(t *testing.T) {
+	gee := NewGroupWithOptions("tags", 2<<20, taggedDB{"1:feed": "f1", "2:feed": "f2"}, 0, StrategyLRU, 2)
+
+	gee.Set("1:profile", []byte("p1"), 0, "user:1")
+	gee.SetMulti(map[string][]byte{"1:settings": []byte("s1"), "2:settings": []byte("s2")}, 0, "settings")
+	gee.Set("2:settings", []byte("s2"), 0, "user:2", "settings")
+	gee.Get("1:feed")
+	gee.Get("2:feed")
+
+	if n := gee.InvalidateTag("user:1"); n != 2 {
+		t.Fatalf("expected 2 keys tagged user:1, got %d", n)
+	}
+	for _, key := range []string{"1:profile", "1:feed"} {
+		if gee.mainCache.contains(key) {
+			t.Fatalf("%s should be invalidated", key)
+		}
+	}
+	for _, key := range []string{"1:settings", "2:settings", "2:feed"} {
+		if !gee.mainCache.contains(key) {
+			t.Fatalf("%s should survive invalidation of user:1", key)
+		}
+	}
+
+	// 覆盖写入会替换标签，删除会清理索引
+	gee.Set("1:settings", []byte("s1"), 0)
+	gee.Delete("2:feed")
+	if keys := gee.tags.keysOf("settings"); !reflect.DeepEqual(keys, []string{"2:settings"}) {
+		t.Fatalf("expected only 2:settings tagged settings, got %v", keys)
+	}
+	if keys := gee.tags.keysOf("user:2"); !reflect.DeepEqual(keys, []string{"2:settings"}) {
+		t.Fatalf("expected evicted key removed from tag index, got %v", keys)
+	}
+}
+
+func TestBumpGeneration(t *testing.T) {
+	loads := make(map[string]int)
+	gee := NewGroupWithOptions("generations", 2<<20, GetterFunc(
+		func(key string) ([]byte, error) {
+			loads[key]++
+			return []byte(key), nil
+		}), 0, StrategyLRU, 2)
+
+	for _, key := range []string{"user:1:name", "user:2:name", "post:1"} {
+		gee.Get(key)
+	}
+	gee.Set("counter", []byte("1"), 0)
+
+	// 递增命名空间代数只作废该命名空间下的 key
+	gee.BumpGeneration("user:1")
+	gee.Get("user:1:name")
+	gee.Get("user:2:name")
+	if loads["user:1:name"] != 2 || loads["user:2:name"] != 1 {
+		t.Fatalf("expected only user:1 to reload, got %v", loads)
+	}
+
+	gee.BumpGeneration("user")
+	gee.Get("user:1:name")
+	gee.Get("user:2:name")
+	gee.Get("post:1")
+	if loads["user:1:name"] != 3 || loads["user:2:name"] != 2 || loads["post:1"] != 1 {
+		t.Fatalf("expected all user keys to reload, got %v", loads)
+	}
+
+	// 递增 Group 代数作废所有 key，旧条目不再出现在 Scan 结果中
+	gee.BumpGeneration("")
+	if values, _ := gee.GetMulti([]string{"counter"}); len(values) != 0 {
+		t.Fatalf("counter should be invalidated by group generation")
+	}
+	if keys, _, _ := gee.Scan("", "", 100); len(keys) != 0 {
+		t.Fatalf("expected stale entries hidden from scan, got %v", keys)
+	}
+	gee.Set("counter", []byte("2"), 0)
+	if keys, _, _ := gee.Scan("", "", 100); !reflect.DeepEqual(keys, []string{"counter"}) {
+		t.Fatalf("expected scan to return user keys, got %v", keys)
+	}
+	if v, err := gee.Get("counter"); err != nil || v.String() != "2" {
+		t.Fatalf("expected counter=2 after bump, got %q %v", v.String(), err)
+	}
+}
+
+// waitGoroutines 等待协程数降到 n 以下，超时返回当前协程数
+func waitGoroutines(n int) int {
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return runtime.NumGoroutine()
+}
+
+func TestGroupClose(t *testing.T) {
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		if v, ok := db[key]; ok {
+			return []byte(v), nil
+		}
+		return nil, fmt.Errorf("%s not exist", key)
+	})
+
+	// 预热 asynclog 等全局协程，避免计入泄漏
+	warmup, err := NewGroup("close-warmup", getter, WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	warmup.Get("Tom")
+	UnregisterGroup("close-warmup")
+	time.Sleep(100 * time.Millisecond)
+	before := runtime.NumGoroutine()
+
+	lruK, err := NewGroup("close", getter, WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lruK.Get("Tom")
+	// 同名 Group 已存在时创建失败，旧实例不受影响
+	if _, err := NewGroup("close", getter, WithStrategy(StrategyLRU, 2)); err != ErrGroupExists {
+		t.Fatalf("expected ErrGroupExists for duplicate name, got %v", err)
+	}
+	if GetGroup("close") != lruK {
+		t.Fatalf("duplicate name should not replace the registered group")
+	}
+
+	if !UnregisterGroup("close") {
+		t.Fatalf("expected group close to be unregistered")
+	}
+	if GetGroup("close") != nil || UnregisterGroup("close") {
+		t.Fatalf("group close should be removed from the registry")
+	}
+	if _, err := lruK.Get("Sam"); err != nil {
+		t.Fatalf("closed group should remain usable: %v", err)
+	}
+	// 旧实例注销后可以重新使用该名称
+	plain := NewGroupWithOptions("close", 2<<10, getter, 0, StrategyLRU, 2)
+	plain.Get("Jack")
+	if GetGroup("close") != plain {
+		t.Fatalf("expected the name to be reusable after UnregisterGroup")
+	}
+	// 旧构造函数保持替换语义：关闭同名的旧实例而不是 panic
+	replaced := NewGroupWithTTL("close", 2<<10, getter, 0)
+	if GetGroup("close") != replaced {
+		t.Fatalf("expected the legacy constructor to replace the registered group")
+	}
+	if !plain.watches.isClosed() {
+		t.Fatalf("expected the replaced group to be closed")
+	}
+	replaced.Get("Jack")
+	replaced.Close()
+
+	if n := waitGoroutines(before); n > before {
+		t.Fatalf("goroutines leaked: %d before, %d after close", before, n)
+	}
+}
+
+func TestNewGroupOptions(t *testing.T) {
+	getter := GetterFunc(func(key string) ([]byte, error) { return []byte(key), nil })
+
+	invalid := map[string][]Option{
+		"cache bytes": {WithCacheBytes(0)},
+		"shard count": {WithShardCount(12)},
+		"threshold":   {WithStrategy(StrategyLRUK, 0)},
+		"max workers": {WithPoolSize(10, 5, 100)},
+		"ttl":         {WithDefaultTTL(-1)},
+	}
+	for want, opts := range invalid {
+		if _, err := NewGroup("options-invalid", getter, opts...); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error mentioning %q, got %v", want, err)
+		}
+	}
+	if _, err := NewGroup("options-invalid", nil); err == nil {
+		t.Fatalf("expected error for nil getter")
+	}
+	if GetGroup("options-invalid") != nil {
+		t.Fatalf("invalid options should not register a group")
+	}
+
+	evicted := make(chan string, 4)
+	gee, err := NewGroup("options", getter,
+		WithShardCount(1),
+		WithCacheBytes(30), // 嵌入代数后每个条目占 13 字节，只能容纳两个
+		WithStrategy(StrategyLRU, 0),
+		WithPoolSize(1, 2, 10),
+		WithEvictionCallback(func(key string, value ByteView) {
+			// 回调异步执行，可以访问 Group 而不会死锁
+			GetGroup("options").Stats()
+			evicted <- key
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+
+	gee.BumpGeneration("")
+	gee.Get("key1")
+	gee.Get("key2")
+	gee.Get("key3")
+	select {
+	case key := <-evicted:
+		if key != "key1" {
+			t.Fatalf("expected key1 evicted with its original key, got %s", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("eviction callback not called")
+	}
+	// 覆盖写入不触发回调
+	gee.Set("key3", []byte("new"), 0)
+	select {
+	case key := <-evicted:
+		t.Fatalf("unexpected eviction callback for %s", key)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEvictionHooks(t *testing.T) {
+	type event struct {
+		key    string
+		value  string
+		reason EvictionReason
+	}
+	events := make(chan event, 16)
+	gee, err := NewGroup("hooks", GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }),
+		WithShardCount(1),
+		WithCacheBytes(15),
+		WithStrategy(StrategyLRU, 0),
+		WithEvictionHook(func(key string, value ByteView, reason EvictionReason) {
+			// 钩子异步执行，可以访问 Group 而不会死锁
+			gee := GetGroup("hooks")
+			gee.Stats()
+			events <- event{key, value.String(), reason}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+
+	next := func() event {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for eviction hook")
+			return event{}
+		}
+	}
+	expect := func(want event) {
+		if got := next(); got != want {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	}
+
+	gee.Set("a", []byte("1"), 0)
+	gee.Set("a", []byte("2"), 0)
+	expect(event{"a", "1", ReasonOverwritten})
+	gee.Delete("a")
+	expect(event{"a", "2", ReasonDeleted})
+
+	// 负缓存条目不触发钩子
+	gee.Get("missing")
+	gee.Delete("missing")
+
+	gee.Set("b", []byte("1234567"), 0)
+	gee.Set("c", []byte("1234567"), 0)
+	expect(event{"b", "1234567", ReasonEvicted})
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event %+v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if ReasonExpired.String() != "expired" {
+		t.Fatalf("unexpected reason name %s", ReasonExpired)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	gee, err := NewGroup("watch", GetterFunc(
+		func(key string) ([]byte, error) { return []byte(key), nil }),
+		WithShardCount(1),
+		WithCacheBytes(64),
+		WithStrategy(StrategyLRU, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exact := gee.Watch(ctx, "user:1")
+	prefix := gee.Watch(ctx, "user:*")
+
+	gee.Set("user:1", []byte("1"), 0)
+	gee.Set("user:2", []byte("b"), 0)
+	gee.Set("post:1", []byte("c"), 0)
+	gee.Get("user:3") // 从 Getter 加载不产生事件
+	gee.Incr("user:1", 1, 0, 0)
+	gee.Delete("user:1")
+
+	expect := func(ch <-chan ChangeEvent, want []string) {
+		for _, w := range want {
+			select {
+			case e := <-ch:
+				if got := e.Type.String() + " " + e.Key + "=" + e.Value.String(); got != w {
+					t.Fatalf("expected %q, got %q", w, got)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for %q", w)
+			}
+		}
+	}
+	expect(exact, []string{"set user:1=1", "set user:1=2", "delete user:1=2"})
+	expect(prefix, []string{"set user:1=1", "set user:2=b", "set user:1=2", "delete user:1=2"})
+
+	// 缓冲区满时丢弃事件，并在下一个送达的事件中报告丢弃数量
+	slow := gee.Watch(ctx, "k*")
+	for i := 0; i < DefaultWatchBuffer+5; i++ {
+		gee.Set("k", []byte("v"), 0)
+	}
+	for i := 0; i < DefaultWatchBuffer; i++ {
+		<-slow
+	}
+	gee.Set("k", []byte("v"), 0)
+	if e := <-slow; e.Dropped != 5 {
+		t.Fatalf("expected 5 dropped events, got %d", e.Dropped)
+	}
+
+	cancel()
+	for range exact {
+	}
+	if _, ok := <-prefix; ok {
+		t.Fatalf("watch channel should be closed after ctx is done")
+	}
+}
+
+func TestWaitChanges(t *testing.T) {
+	gee, err := NewGroup("wait-changes", GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+
+	// since 为 0 时从当前位置开始等待，超时返回空结果
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	events, next, _ := gee.WaitChanges(ctx, "a", 0)
+	cancel()
+	if len(events) != 0 || next == 0 {
+		t.Fatalf("expected no events and a non-zero cursor, got %v and %d", events, next)
+	}
+
+	// 两次轮询之间发布的事件不会丢失
+	gee.Set("a", []byte("1"), 0)
+	if events, next, _ = gee.WaitChanges(context.Background(), "a", next); len(events) != 1 {
+		t.Fatalf("expected the change between polls, got %v", events)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		gee.Set("b", []byte("1"), 0)
+		gee.Set("a", []byte("2"), 0)
+	}()
+	events, next, truncated := gee.WaitChanges(context.Background(), "a", next)
+	if len(events) != 1 || events[0].Key != "a" || events[0].Value.String() != "2" || truncated {
+		t.Fatalf("expected one change of a, got %v (truncated=%v)", events, truncated)
+	}
+
+	// 超出保留范围的序号被标记为 truncated
+	for i := 0; i <= changeLogSize; i++ {
+		gee.Set("a", []byte("x"), 0)
+	}
+	if _, _, truncated := gee.WaitChanges(context.Background(), "a", next); !truncated {
+		t.Fatalf("expected truncated after the change log wrapped")
+	}
+}
+
+func TestHash(t *testing.T) {
+	gee, err := NewGroup("hashes", GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }), WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := gee.HSet("user:1", map[string][]byte{"name": []byte("Tom"), "age": []byte("30")}, 0)
+	if err != nil || added != 2 {
+		t.Fatalf("expected 2 fields added, got %d (%v)", added, err)
+	}
+	if added, _ := gee.HSet("user:1", map[string][]byte{"name": []byte("Jack"), "city": []byte("BJ")}, 0); added != 1 {
+		t.Fatalf("expected only new fields to be counted, got %d", added)
+	}
+	if view, err := gee.HGet("user:1", "name"); err != nil || view.String() != "Jack" {
+		t.Fatalf("expected Jack, got %s (%v)", view, err)
+	}
+	if _, err := gee.HGet("user:1", "email"); err != ErrKeyNotFound {
+		t.Fatalf("expected ErrKeyNotFound for missing field, got %v", err)
+	}
+
+	if v, err := gee.HIncrBy("user:1", "age", 2, 0); err != nil || v != 32 {
+		t.Fatalf("expected 32, got %d (%v)", v, err)
+	}
+	if v, err := gee.HIncrBy("user:1", "visits", 1, 0); err != nil || v != 1 {
+		t.Fatalf("expected missing field to start from 0, got %d (%v)", v, err)
+	}
+	if _, err := gee.HIncrBy("user:1", "name", 1, 0); err != ErrNotInteger {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+
+	all, err := gee.HGetAll("user:1")
+	expect := map[string][]byte{"name": []byte("Jack"), "age": []byte("32"), "city": []byte("BJ"), "visits": []byte("1")}
+	if err != nil || !reflect.DeepEqual(all, expect) {
+		t.Fatalf("expected %q, got %q (%v)", expect, all, err)
+	}
+
+	// 字符串操作与哈希操作互不兼容
+	if _, err := gee.Get("user:1"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType from Get, got %v", err)
+	}
+	if _, err := gee.Incr("user:1", 1, 0, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType from Incr, got %v", err)
+	}
+	gee.Set("text", []byte("abc"), 0)
+	if _, err := gee.HSet("text", map[string][]byte{"f": []byte("v")}, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType from HSet, got %v", err)
+	}
+
+	if removed, err := gee.HDel("user:1", "name", "age", "email"); err != nil || removed != 2 {
+		t.Fatalf("expected 2 fields removed, got %d (%v)", removed, err)
+	}
+	gee.HDel("user:1", "city", "visits")
+	if _, err := gee.HGetAll("user:1"); err != ErrKeyNotFound {
+		t.Fatalf("expected hash to be deleted with its last field, got %v", err)
+	}
+}
+
+func TestTypedGroup(t *testing.T) {
+	type score struct {
+		Name  string
+		Score int
+	}
+	loads := 0
+	typed, err := NewTypedGroup[score]("typed-scores", func(key string) (score, error) {
+		loads++
+		if v, ok := db[key]; ok {
+			n, _ := strconv.Atoi(v)
+			return score{Name: key, Score: n}, nil
+		}
+		return score{}, fmt.Errorf("%s not exist", key)
+	}, JSONCodec[score]{}, WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := typed.Get("Tom"); err != nil || v != (score{"Tom", 630}) {
+		t.Fatalf("expected Tom's score to be loaded, got %+v (%v)", v, err)
+	}
+	if err := typed.Set("Amy", score{"Amy", 700}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := typed.Get("Amy"); err != nil || v.Score != 700 {
+		t.Fatalf("expected typed Set to be readable, got %+v (%v)", v, err)
+	}
+	if _, err := typed.Get("unknown"); err == nil {
+		t.Fatalf("expected load error for unknown key")
+	}
+
+	// 无法解码的值被删除并重新加载，不会一直留在缓存中
+	typed.Group().Set("Jack", []byte("not json"), 0)
+	loads = 0
+	if v, err := typed.Get("Jack"); err != nil || v.Score != 589 || loads != 1 {
+		t.Fatalf("expected undecodable value to be reloaded once, got %+v (%v), %d loads", v, err, loads)
+	}
+	typed.Group().Set("Amy", []byte("not json"), 0)
+	if _, err := typed.Get("Amy"); err == nil {
+		t.Fatalf("expected error for key that cannot be reloaded")
+	}
+
+	typed.Group().Set("Sam", []byte("{"), 0)
+	values, err := typed.GetMulti([]string{"Tom", "Jack", "Sam", "unknown"})
+	if _, ok := err.(*DecodeError); !ok {
+		t.Fatalf("expected *DecodeError from GetMulti, got %v", err)
+	}
+	if len(values) != 2 || values["Tom"].Score != 630 || values["Jack"].Score != 589 {
+		t.Fatalf("expected decodable hits only, got %+v", values)
+	}
+	if _, ok, _ := typed.Group().mainCache.get("Sam"); ok {
+		t.Fatalf("expected undecodable entry to be removed")
+	}
+
+	gobTyped := WrapGroup[score](typed.Group(), GobCodec[score]{})
+	gobTyped.Set("Bob", score{"Bob", 1}, 0)
+	if v, err := gobTyped.Get("Bob"); err != nil || v.Name != "Bob" {
+		t.Fatalf("expected gob round trip, got %+v (%v)", v, err)
+	}
+	raw := WrapGroup[[]byte](typed.Group(), BytesCodec{})
+	if v, err := raw.Get("Tom"); err != nil || string(v) != `{"Name":"Tom","Score":630}` {
+		t.Fatalf("expected raw bytes, got %s (%v)", v, err)
+	}
+}
+
+// transferPeer 模拟远程节点，以保持压缩形式的方式转发 Get
+type transferPeer struct {
+	group *Group
+}
+
+func (p transferPeer) Get(group string, key string) ([]byte, error) {
+	view, err := p.group.Get(key)
+	return view.ByteSlice(), err
+}
+
+func (p transferPeer) GetEncoded(group string, key string, compression string) ([]byte, bool, error) {
+	view, err := p.group.getForTransfer(key, compression)
+	return view.ByteSlice(), view.enc == encCompressed, err
+}
+
+type transferPicker struct {
+	peer transferPeer
+}
+
+func (p transferPicker) PickPeer(key string) (PeerGetter, bool) {
+	return p.peer, true
+}
+
+func TestCompression(t *testing.T) {
+	gz, err := NewGzipCompressor(-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	big := strings.Repeat(`{"name":"Tom","score":630},`, 40)
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		if key == "big" {
+			return []byte(big), nil
+		}
+		return nil, fmt.Errorf("%s not exist", key)
+	})
+	gee, err := NewGroup("compressed", getter, WithCacheBytes(2<<10), WithCompression(gz, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 超过阈值的值按压缩后的大小存储，读取时解压
+	gee.Set("doc", []byte(big), 0)
+	if v, _, _ := gee.mainCache.get("doc"); v.enc != encCompressed || v.Len() >= len(big) {
+		t.Fatalf("expected doc to be stored compressed, got %d bytes", v.Len())
+	}
+	if view, err := gee.Get("doc"); err != nil || view.String() != big {
+		t.Fatalf("expected decompressed value, got %d bytes (%v)", view.Len(), err)
+	}
+	if view, _, err := gee.GetWithVersion("doc"); err != nil || view.String() != big {
+		t.Fatalf("expected decompressed value from GetWithVersion, got %d bytes (%v)", view.Len(), err)
+	}
+	if view, err := gee.Get("big"); err != nil || view.String() != big {
+		t.Fatalf("expected loaded value, got %d bytes (%v)", view.Len(), err)
+	}
+	if v, _, _ := gee.mainCache.get("big"); v.enc != encCompressed {
+		t.Fatalf("expected loaded value to be stored compressed")
+	}
+	gee.Set("small", []byte("42"), 0)
+	if v, _, _ := gee.mainCache.get("small"); v.enc != 0 {
+		t.Fatalf("expected value below threshold to be stored as is")
+	}
+
+	// 无法解压的值被删除并重新加载
+	gee.mainCache.directAdd("big", ByteView{b: []byte("garbage"), enc: encCompressed}, 0)
+	if view, err := gee.Get("big"); err != nil || view.String() != big {
+		t.Fatalf("expected corrupt value to be reloaded, got %d bytes (%v)", view.Len(), err)
+	}
+
+	// 节点间保持压缩形式传输，本节点直接缓存压缩后的数据
+	local, err := NewGroup("compressed-local", getter, WithCacheBytes(2<<10), WithCompression(gz, 64), WithCompressedTransfer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	local.RegisterPeers(transferPicker{transferPeer{gee}})
+	if view, err := local.Get("doc"); err != nil || view.String() != big {
+		t.Fatalf("expected value transferred from peer, got %d bytes (%v)", view.Len(), err)
+	}
+	if v, _, _ := local.mainCache.get("doc"); v.enc != encCompressed {
+		t.Fatalf("expected transferred value to stay compressed")
+	}
+	if view, err := gee.getForTransfer("doc", "flate"); err != nil || view.enc != 0 || view.String() != big {
+		t.Fatalf("expected plain value for mismatched compression, got %d bytes (%v)", view.Len(), err)
+	}
+
+	fl, err := NewFlateCompressor(flate.BestSpeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		data, err := fl.Compress([]byte(big))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out, err := fl.Decompress(data); err != nil || string(out) != big {
+			t.Fatalf("expected flate round trip, got %d bytes (%v)", len(out), err)
+		}
+	}
+	if _, err := NewGroup("compressed-invalid", getter, WithCompressedTransfer()); err == nil {
+		t.Fatalf("expected compressed transfer without compressor to be rejected")
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	key1 := bytes.Repeat([]byte{1}, 32)
+	key2 := bytes.Repeat([]byte{2}, 32)
+	ring, err := NewKeyRing(1, key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loads := 0
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		loads++
+		return []byte("loaded-" + key), nil
+	})
+	gee, err := NewGroup("encrypted", getter, WithCacheBytes(2<<10), WithEncryption(ring))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 缓存中只保存密文，读取时解密
+	gee.Set("Tom", []byte("630"), 0)
+	v, _, _ := gee.mainCache.get("Tom")
+	if v.enc != encEncrypted || bytes.Contains(v.b, []byte("630")) || binary.BigEndian.Uint32(v.b) != 1 {
+		t.Fatalf("expected Tom to be stored encrypted with key 1")
+	}
+	if view, err := gee.Get("Tom"); err != nil || view.String() != "630" {
+		t.Fatalf("expected decrypted value, got %q (%v)", view.String(), err)
+	}
+	// 密文绑定 key，挪用到其他 key 下无法解密
+	gee.mainCache.directAdd("Jack", v, 0)
+	if view, err := gee.Get("Jack"); err != nil || view.String() != "loaded-Jack" {
+		t.Fatalf("expected moved ciphertext to be reloaded, got %q (%v)", view.String(), err)
+	}
+
+	// 轮换后新值使用新密钥，旧值仍可读取
+	if err := ring.Rotate(2, key2); err != nil {
+		t.Fatal(err)
+	}
+	gee.Set("Sam", []byte("567"), 0)
+	if v, _, _ := gee.mainCache.get("Sam"); binary.BigEndian.Uint32(v.b) != 2 {
+		t.Fatalf("expected Sam to be encrypted with key 2")
+	}
+	if view, err := gee.Get("Tom"); err != nil || view.String() != "630" {
+		t.Fatalf("expected value encrypted with old key to be readable, got %q (%v)", view.String(), err)
+	}
+	if err := ring.Rotate(2, key1); err == nil {
+		t.Fatalf("expected rotating to an existing id with different key to fail")
+	}
+	if err := ring.Retire(2); err == nil {
+		t.Fatalf("expected retiring current key to fail")
+	}
+
+	// 旧密钥移除后的值与被篡改的值按未命中处理
+	if err := ring.Retire(1); err != nil {
+		t.Fatal(err)
+	}
+	loads = 0
+	if view, err := gee.Get("Tom"); err != nil || view.String() != "loaded-Tom" || loads != 1 {
+		t.Fatalf("expected value with retired key to be reloaded, got %q (%v)", view.String(), err)
+	}
+	v, _, _ = gee.mainCache.get("Sam")
+	tampered := cloneBytes(v.b)
+	tampered[len(tampered)-1] ^= 0xff
+	gee.mainCache.directAdd("Sam", ByteView{b: tampered, enc: v.enc}, 0)
+	if view, _, err := gee.GetWithVersion("Sam"); err != nil || view.String() != "loaded-Sam" {
+		t.Fatalf("expected tampered value to be reloaded, got %q (%v)", view.String(), err)
+	}
+	if got := gee.Stats().IntegrityFailures; got != 3 {
+		t.Fatalf("expected 3 integrity failures, got %d", got)
+	}
+
+	// 计数器同样加密存储
+	if n, err := gee.Incr("hits", 1, 5, 0); err != nil || n != 5 {
+		t.Fatalf("expected counter 5, got %d (%v)", n, err)
+	}
+	if n, err := gee.Incr("hits", 1, 0, 0); err != nil || n != 6 {
+		t.Fatalf("expected counter 6, got %d (%v)", n, err)
+	}
+	if v, _, _ := gee.mainCache.get("hits"); v.enc != encEncrypted {
+		t.Fatalf("expected counter to be stored encrypted")
+	}
+
+	// 先压缩后加密
+	gz, err := NewGzipCompressor(-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	both, err := NewGroup("encrypted-compressed", getter, WithCacheBytes(64<<10), WithCompression(gz, 64), WithEncryption(ring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	big := strings.Repeat("score:630,", 50)
+	both.Set("doc", []byte(big), 0)
+	if v, _, _ := both.mainCache.get("doc"); v.enc != encCompressed|encEncrypted || v.Len() >= len(big) {
+		t.Fatalf("expected doc to be stored compressed and encrypted")
+	}
+	if view, err := both.Get("doc"); err != nil || view.String() != big {
+		t.Fatalf("expected round trip, got %d bytes (%v)", view.Len(), err)
+	}
+	if view, err := both.getForTransfer("doc", "gzip"); err != nil || view.enc != encCompressed {
+		t.Fatalf("expected transfer to be decrypted but stay compressed (%v)", err)
+	}
+
+	if _, err := NewGroup("encrypted-invalid", getter, WithEncryption(nil)); err == nil {
+		t.Fatalf("expected encryption without key provider to be rejected")
+	}
+	if _, err := NewKeyRing(1, []byte("short")); err == nil {
+		t.Fatalf("expected invalid key size to be rejected")
+	}
+
+	// 长轮询保留的最近变更同样只保存密文，读取时解密
+	watched, _ := NewGroup("encrypted-watch", getter, WithEncryption(ring))
+	defer watched.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	_, cursor, _ := watched.WaitChanges(ctx, "Tom", 0)
+	cancel()
+	watched.Set("Tom", []byte("secret-630"), 0)
+	for _, e := range watched.watches.log {
+		if bytes.Contains(e.Value.b, []byte("secret-630")) {
+			t.Fatalf("expected the change log not to keep plaintext")
+		}
+	}
+	if events, _, _ := watched.WaitChanges(context.Background(), "Tom", cursor); len(events) != 1 || events[0].Value.String() != "secret-630" {
+		t.Fatalf("expected decrypted change of Tom, got %v", events)
+	}
+}
+
+// chunkPeer 模拟保存分块的远程节点
+type chunkPeer struct {
+	group *Group
+}
+
+func (p chunkPeer) Get(group string, key string) ([]byte, error) {
+	view, err := p.group.Get(key)
+	return view.ByteSlice(), err
+}
+
+func (p chunkPeer) SetChunks(group string, chunks map[string][]byte, ttl int64) error {
+	p.group.setChunksLocally(chunks, ttl)
+	return nil
+}
+
+func (p chunkPeer) GetMulti(group string, keys []string) (map[string][]byte, error) {
+	return p.group.GetMulti(keys)
+}
+
+// chunkPicker 将编号为偶数的分块分配给远程节点，其余 key 属于本节点
+type chunkPicker struct {
+	peer chunkPeer
+}
+
+func (p chunkPicker) PickPeer(key string) (PeerGetter, bool) {
+	if isChunkKey(key) && (key[len(key)-1]-'0')%2 == 0 {
+		return p.peer, true
+	}
+	return nil, false
+}
+
+func TestChunking(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; sb.Len() < 10<<10; i++ {
+		sb.WriteString(strconv.Itoa(i * 7919))
+	}
+	big := sb.String()
+	loads := 0
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		loads++
+		return []byte(key + ":" + big), nil
+	})
+	remote, err := NewGroup("chunked-remote", getter, WithCacheBytes(1<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gee, err := NewGroup("chunked", getter, WithCacheBytes(1<<20), WithChunking(4<<10, 1<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gee.RegisterPeers(chunkPicker{chunkPeer{remote}})
+
+	// key 上只保存清单，分块分散在两个节点上
+	if err := gee.Set("doc", []byte(big), 0); err != nil {
+		t.Fatal(err)
+	}
+	v, _, _ := gee.mainCache.get("doc")
+	if v.enc != encManifest || v.Len() >= 1<<10 {
+		t.Fatalf("expected doc to be stored as manifest, got %d bytes", v.Len())
+	}
+	if keys, _, _ := gee.Scan("", "", 100); !reflect.DeepEqual(keys, []string{"doc"}) {
+		t.Fatalf("expected chunks to be hidden from Scan, got %d keys", len(keys))
+	}
+	if keys, _, _ := remote.Scan("", "", 100); len(keys) != 0 {
+		t.Fatalf("expected remote chunks to be hidden from Scan, got %v", keys)
+	}
+	if remote.mainCache.stats().ItemCount != 5 || gee.mainCache.stats().ItemCount != 6 {
+		t.Fatalf("expected 11 chunks spread across both nodes")
+	}
+	if view, err := gee.Get("doc"); err != nil || view.String() != big {
+		t.Fatalf("expected assembled value, got %d bytes (%v)", view.Len(), err)
+	}
+	if values, _ := gee.GetMulti([]string{"doc"}); string(values["doc"]) != big {
+		t.Fatalf("expected assembled value from GetMulti, got %d bytes", len(values["doc"]))
+	}
+
+	// Getter 加载的大值同样分块存储
+	if view, err := gee.Get("big"); err != nil || view.String() != "big:"+big {
+		t.Fatalf("expected loaded value, got %d bytes (%v)", view.Len(), err)
+	}
+	if v, _, _ := gee.mainCache.get("big"); v.enc != encManifest {
+		t.Fatalf("expected loaded value to be stored as manifest")
+	}
+	loads = 0
+	if view, err := gee.Get("big"); err != nil || view.String() != "big:"+big || loads != 0 {
+		t.Fatalf("expected chunked value to be served from cache, got %d bytes (%v)", view.Len(), err)
+	}
+
+	// 分块被篡改时校验失败，按未命中重新加载
+	var tampered bool
+	for i := range remote.mainCache.shards {
+		for _, ck := range remote.mainCache.shardKeys(i, "", 0) {
+			if !tampered && strings.HasPrefix(ck, "doc"+chunkKeyMarker) {
+				remote.mainCache.directAdd(ck, ByteView{b: []byte("garbage")}, 0)
+				tampered = true
+			}
+		}
+	}
+	loads = 0
+	if view, err := gee.Get("doc"); err != nil || view.String() != "doc:"+big || loads != 1 {
+		t.Fatalf("expected tampered value to be reloaded, got %d bytes (%v)", view.Len(), err)
+	}
+	if got := gee.Stats().IntegrityFailures; got != 1 {
+		t.Fatalf("expected 1 integrity failure, got %d", got)
+	}
+
+	// 分块缺失（远程节点被清空）时同样重新加载
+	remote.Clear()
+	loads = 0
+	if view, err := gee.Get("big"); err != nil || view.String() != "big:"+big || loads != 1 {
+		t.Fatalf("expected value with missing chunks to be reloaded, got %d bytes (%v)", view.Len(), err)
+	}
+
+	// 小于阈值的值按原样存储
+	gee.Set("small", []byte("630"), 0)
+	if v, _, _ := gee.mainCache.get("small"); v.enc != 0 {
+		t.Fatalf("expected small value to be stored as is")
+	}
+	if _, err := NewGroup("chunked-invalid", getter, WithChunking(1<<10, 4<<10)); err == nil {
+		t.Fatalf("expected chunk size above threshold to be rejected")
+	}
+}
+
+func TestByteView(t *testing.T) {
+	v := ByteView{b: []byte("hello, world")}
+
+	if got := v.Slice(7, 12); got.String() != "world" || !got.Equal(ByteView{b: []byte("world")}) {
+		t.Fatalf("expected slice world, got %q", got.String())
+	}
+	if v.Equal(v.Slice(0, 5)) {
+		t.Fatalf("expected views of different length to differ")
+	}
+	if &v.UnsafeBytes()[0] != &v.b[0] || &v.ByteSlice()[0] == &v.b[0] {
+		t.Fatalf("expected UnsafeBytes to share and ByteSlice to copy the data")
+	}
+
+	var buf bytes.Buffer
+	if n, err := v.WriteTo(&buf); err != nil || n != 12 || buf.String() != "hello, world" {
+		t.Fatalf("expected WriteTo to write 12 bytes, got %d (%v)", n, err)
+	}
+	if data, err := io.ReadAll(v.Reader()); err != nil || string(data) != "hello, world" {
+		t.Fatalf("expected Reader to read the value, got %q (%v)", data, err)
+	}
+
+	p := make([]byte, 5)
+	if n, err := v.ReadAt(p, 7); err != nil || n != 5 || string(p) != "world" {
+		t.Fatalf("expected ReadAt to read world, got %q (%v)", p[:n], err)
+	}
+	if n, err := v.ReadAt(p, 10); err != io.EOF || n != 2 {
+		t.Fatalf("expected short ReadAt to return EOF, got %d (%v)", n, err)
+	}
+	if _, err := v.ReadAt(p, -1); err == nil {
+		t.Fatalf("expected negative offset to be rejected")
+	}
+}
+
+func TestArenaStrategy(t *testing.T) {
+	removed := make(chan string, 10)
+	gee, err := NewGroup("arena", GetterFunc(func(key string) ([]byte, error) {
+		if v, ok := db[key]; ok {
+			return []byte(v), nil
+		}
+		return nil, fmt.Errorf("%s not exist", key)
+	}), WithCacheBytes(1<<20), WithStrategy(StrategyArena, 0), WithEvictionHook(func(key string, value ByteView, reason EvictionReason) {
+		removed <- key + "=" + value.String() + ":" + reason.String()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 首次加载即写入缓存，不经过 LRU-K 的门槛
+	if view, err := gee.Get("Tom"); err != nil || view.String() != "630" {
+		t.Fatalf("expected Tom=630, got %q (%v)", view.String(), err)
+	}
+	if !gee.mainCache.contains("Tom") {
+		t.Fatalf("expected loaded value to be cached")
+	}
+	if _, err := gee.Get("unknown"); err == nil {
+		t.Fatalf("expected unknown to fail")
+	}
+	if _, err := gee.Get("unknown"); err != ErrKeyNotFound {
+		t.Fatalf("expected negative cache hit, got %v", err)
+	}
+
+	_, version, err := gee.GetWithVersion("Tom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gee.CompareAndSet("Tom", []byte("631"), version, 0); err != nil {
+		t.Fatalf("expected cas to succeed, got %v", err)
+	}
+	if got := <-removed; got != "Tom=630:overwritten" {
+		t.Fatalf("expected overwritten hook with old value, got %s", got)
+	}
+	if n, err := gee.Incr("hits", 1, 10, 0); err != nil || n != 10 {
+		t.Fatalf("expected counter 10, got %d (%v)", n, err)
+	}
+	if n, err := gee.Incr("hits", 5, 0, 0); err != nil || n != 15 {
+		t.Fatalf("expected counter 15, got %d (%v)", n, err)
+	}
+	if got := <-removed; got != "hits=10:overwritten" {
+		t.Fatalf("expected overwritten hook for the counter, got %s", got)
+	}
+	if keys, _, _ := gee.Scan("", "", 10); !reflect.DeepEqual(keys, []string{"Tom", "hits"}) {
+		t.Fatalf("expected Scan to list Tom and hits, got %v", keys)
+	}
+	if _, err := gee.HSet("profile", map[string][]byte{"name": []byte("Tom")}, 0); err == nil {
+		t.Fatalf("expected hash values to be rejected")
+	}
+	if _, err := gee.HGet("Tom", "name"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType, got %v", err)
+	}
+
+	gee.Delete("hits")
+	if got := <-removed; got != "hits=15:deleted" {
+		t.Fatalf("expected deleted hook, got %s", got)
+	}
+	if stats := gee.Stats(); stats.ItemCount != 2 {
+		t.Fatalf("expected Tom and the negative entry to remain, got %d items", stats.ItemCount)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	loads := make(map[string]int)
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		loads[key]++
+		return []byte("loaded-" + key), nil
+	})
+	gee, err := NewGroup("snapshot", getter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gee.Set("forever", []byte("v1"), 0, "t1")
+	gee.Set("short", []byte("v2"), 100)
+	gee.HSet("profile", map[string][]byte{"name": []byte("Tom"), "age": []byte("30")}, 0)
+	gee.BumpGeneration("user")
+	gee.Set("user:1", []byte("u1"), 0)
+	gee.Get("warm") // LRU-K 下首次访问只记录历史
+
+	var buf bytes.Buffer
+	if err := gee.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// 快照记录了 Group 名称，先注销原实例再以同名创建（关闭后原实例仍可读取）
+	gee.Close()
+	// 损坏或不完整的快照被拒绝，缓存保持不变
+	restored, _ := NewGroup("snapshot", getter)
+	corrupted := append([]byte(nil), data...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	for _, bad := range [][]byte{corrupted, data[:len(data)-1], nil} {
+		if err := restored.Restore(bytes.NewReader(bad)); err != ErrSnapshotCorrupt {
+			t.Fatalf("expected ErrSnapshotCorrupt, got %v", err)
+		}
+	}
+	if restored.Stats().ItemCount != 0 {
+		t.Fatalf("expected rejected snapshot to leave the cache empty")
+	}
+	other, _ := NewGroup("snapshot-other", getter)
+	defer other.Close()
+	if err := other.Restore(bytes.NewReader(data)); err == nil {
+		t.Fatalf("expected snapshot of another group to be rejected")
+	}
+
+	if err := restored.Restore(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"forever": "v1", "short": "v2", "user:1": "u1"} {
+		if v, err := restored.Get(key); err != nil || v.String() != want {
+			t.Fatalf("expected %s=%s, got %q (%v)", key, want, v.String(), err)
+		}
+	}
+	if v, err := restored.HGet("profile", "age"); err != nil || v.String() != "30" {
+		t.Fatalf("expected restored hash field age=30, got %q (%v)", v.String(), err)
+	}
+	if len(loads) != 1 {
+		t.Fatalf("expected restored keys to be served without loading, got %v", loads)
+	}
+
+	// 剩余 TTL 与代数被保留
+	for i := range restored.mainCache.shards {
+		for _, e := range restored.mainCache.shardEntries(i) {
+			switch e.key {
+			case "short":
+				if remaining := e.expiresAt - time.Now().Unix(); remaining < 98 || remaining > 100 {
+					t.Fatalf("expected short to keep its ttl, got %ds left", remaining)
+				}
+			case "forever":
+				if e.expiresAt != 0 {
+					t.Fatalf("expected forever to never expire")
+				}
+			}
+		}
+	}
+	if restored.cacheKey("user:1") != gee.cacheKey("user:1") {
+		t.Fatalf("expected namespace generation to be restored")
+	}
+
+	// 标签与访问历史被保留：warm 再访问一次即进入缓存
+	if n := restored.InvalidateTag("t1"); n != 1 || restored.mainCache.contains("forever") {
+		t.Fatalf("expected tag t1 to invalidate forever, got %d", n)
+	}
+	restored.Get("warm")
+	if !restored.mainCache.contains("warm") {
+		t.Fatalf("expected restored access history to admit warm")
+	}
+	restored.Close()
+
+	// WithSnapshotFile 在创建时加载快照，在 Close 时写入
+	path := filepath.Join(t.TempDir(), "snapshot.bin")
+	first, err := NewGroup("snapshot-file", getter, WithSnapshotFile(path, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Set("persisted", []byte("yes"), 0)
+	first.Close()
+	second, err := NewGroup("snapshot-file", getter, WithSnapshotFile(path, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	if v, err := second.Get("persisted"); err != nil || v.String() != "yes" {
+		t.Fatalf("expected value loaded from snapshot file, got %q (%v)", v.String(), err)
+	}
+	if _, err := NewGroup("snapshot-file", getter, WithSnapshotFile("", time.Second)); err == nil {
+		t.Fatalf("expected snapshot interval without a file to be rejected")
+	}
+}
+
+func TestAppendLog(t *testing.T) {
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		return nil, fmt.Errorf("%s not exist", key)
+	})
+	path := filepath.Join(t.TempDir(), "appendonly.aof")
+	open := func() *Group {
+		gee, err := NewGroup("aof", getter, WithAppendLog(path, FsyncAlways))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gee
+	}
+
+	gee := open()
+	gee.Set("cleared", []byte("x"), 0)
+	gee.Clear()
+	gee.Set("Tom", []byte("630"), 0, "score")
+	gee.Set("short", []byte("v"), 100)
+	gee.Set("deleted", []byte("v"), 0)
+	gee.Delete("deleted")
+	gee.Incr("counter", 5, 5, 0)
+	gee.Incr("counter", 2, 0, 0)
+	gee.HSet("profile", map[string][]byte{"name": []byte("Tom"), "age": []byte("30")}, 0)
+	gee.HDel("profile", "age")
+	gee.BumpGeneration("user")
+	gee.Set("user:1", []byte("u1"), 0)
+	gen := gee.cacheKey("user:1")
+	gee.Close()
+
+	verify := func(gee *Group) {
+		t.Helper()
+		for key, want := range map[string]string{"Tom": "630", "short": "v", "counter": "7", "user:1": "u1"} {
+			if v, err := gee.Get(key); err != nil || v.String() != want {
+				t.Fatalf("expected %s=%s, got %q (%v)", key, want, v.String(), err)
+			}
+		}
+		for _, key := range []string{"cleared", "deleted"} {
+			if _, err := gee.Get(key); err == nil {
+				t.Fatalf("expected %s to stay removed after replay", key)
+			}
+		}
+		if fields, err := gee.HGetAll("profile"); err != nil || len(fields) != 1 || string(fields["name"]) != "Tom" {
+			t.Fatalf("expected profile={name:Tom}, got %v (%v)", fields, err)
+		}
+		if gee.cacheKey("user:1") != gen {
+			t.Fatalf("expected namespace generation to be replayed")
+		}
+		for i := range gee.mainCache.shards {
+			for _, e := range gee.mainCache.shardEntries(i) {
+				if e.key == "short" {
+					if remaining := e.expiresAt - time.Now().Unix(); remaining < 98 || remaining > 100 {
+						t.Fatalf("expected short to keep its ttl, got %ds left", remaining)
+					}
+				}
+			}
+		}
+	}
+	gee = open()
+	verify(gee)
+	if n := gee.InvalidateTag("score"); n != 1 {
+		t.Fatalf("expected tag to be replayed, got %d", n)
+	}
+	gee.Set("Tom", []byte("630"), 0, "score")
+	gee.Close()
+
+	// 崩溃时写了一半的尾部记录被截掉，之后的写入紧接在最后一条完整记录之后
+	info, _ := os.Stat(path)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0x20, 0xde, 0xad})
+	f.Close()
+	gee = open()
+	verify(gee)
+	if after, _ := os.Stat(path); after.Size() != info.Size() {
+		t.Fatalf("expected torn tail to be truncated to %d bytes, got %d", info.Size(), after.Size())
+	}
+
+	// 压缩只保留当前内容，压缩后的写入追加到新日志
+	if err := gee.CompactAppendLog(); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := os.Stat(path); after.Size() >= info.Size() {
+		t.Fatalf("expected compaction to shrink the log from %d bytes, got %d", info.Size(), after.Size())
+	}
+	gee.Set("after", []byte("compaction"), 0)
+	gee.Close()
+	gee = open()
+	defer gee.Close()
+	verify(gee)
+	if v, err := gee.Get("after"); err != nil || v.String() != "compaction" {
+		t.Fatalf("expected write after compaction to be replayed, got %q (%v)", v.String(), err)
+	}
+
+	// 日志超过压缩阈值时在后台自动压缩，Close 等待压缩完成
+	writeHot := func(name string, rewriteSize int64) int64 {
+		path := filepath.Join(t.TempDir(), name+".aof")
+		gee, err := NewGroup(name, getter, WithAppendLog(path, FsyncNever), WithAppendLogRewriteSize(rewriteSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 200; i++ {
+			gee.Set("hot", []byte(strconv.Itoa(i)), 0)
+		}
+		gee.Close()
+		gee, _ = NewGroup(name, getter, WithAppendLog(path, FsyncNever))
+		defer gee.Close()
+		if v, err := gee.Get("hot"); err != nil || v.String() != "199" {
+			t.Fatalf("expected hot=199, got %q (%v)", v.String(), err)
+		}
+		info, _ := os.Stat(path)
+		return info.Size()
+	}
+	if full, compacted := writeHot("aof-full", 0), writeHot("aof-auto", 1<<10); compacted >= full {
+		t.Fatalf("expected background compaction to shrink the log from %d bytes, got %d", full, compacted)
+	}
+
+	// 属于其他 Group 的日志与非法参数被拒绝
+	if _, err := NewGroup("aof-other", getter, WithAppendLog(path, FsyncNever)); err == nil {
+		t.Fatalf("expected append log of another group to be rejected")
+	}
+	if _, err := NewGroup("aof-bad", getter, WithAppendLog(path, FsyncPolicy(7))); err == nil {
+		t.Fatalf("expected unknown fsync policy to be rejected")
+	}
+	if _, err := NewGroup("aof-bad", getter, WithAppendLogRewriteSize(-1)); err == nil {
+		t.Fatalf("expected negative rewrite size to be rejected")
+	}
+	plain, _ := NewGroup("aof-disabled", getter)
+	defer plain.Close()
+	if err := plain.CompactAppendLog(); err != ErrAppendLogDisabled {
+		t.Fatalf("expected ErrAppendLogDisabled, got %v", err)
+	}
+}
+
+// waitSpills 等待被淘汰的值全部写入磁盘层
+func waitSpills(t *testing.T, g *Group) {
+	for i := 0; g.spills.len() > 0; i++ {
+		if i == 1000 {
+			t.Fatalf("spilled values were not written to disk")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDiskTier(t *testing.T) {
+	for _, strategy := range []CacheStrategy{StrategyLRU, StrategyLRUK, StrategyArena} {
+		loads := 0
+		getter := GetterFunc(func(key string) ([]byte, error) {
+			loads++
+			return nil, fmt.Errorf("%s not exist", key)
+		})
+		dir := filepath.Join(t.TempDir(), "disk")
+		gee, err := NewGroup("disk", getter, WithCacheBytes(1<<10), WithShardCount(1),
+			WithStrategy(strategy, 2), WithDiskTier(dir, 1<<20))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 内存只能放下少量值，其余被淘汰到磁盘并保留 TTL 与标签
+		value := func(i int) string { return fmt.Sprintf("%03d%s", i, strings.Repeat("v", 97)) }
+		gee.Set("ttl", []byte(value(-1)), 100)
+		gee.Set("tagged", []byte(value(-2)), 0, "t1")
+		for i := 0; i < 50; i++ {
+			gee.Set("key"+strconv.Itoa(i), []byte(value(i)), 0)
+		}
+		waitSpills(t, gee)
+		if stats := gee.Stats(); stats.DiskItemCount < 40 || stats.ItemCount+stats.DiskItemCount != 52 {
+			t.Fatalf("%v: expected evicted values to spill to disk, got %+v", strategy, stats)
+		}
+		if _, _, expiresAt, ok, _ := gee.disk.Get(gee.cacheKey("ttl")); !ok || expiresAt-time.Now().Unix() < 98 {
+			t.Fatalf("%v: expected spilled value to keep its ttl", strategy)
+		}
+
+		// 内存未命中时从磁盘读取并移回内存，不访问 Getter
+		for i := 0; i < 50; i++ {
+			if v, err := gee.Get("key" + strconv.Itoa(i)); err != nil || v.String() != value(i) {
+				t.Fatalf("%v: expected key%d from disk, got %q (%v)", strategy, i, v.String(), err)
+			}
+		}
+		if stats := gee.Stats(); loads != 0 || stats.DiskHitCount < 40 {
+			t.Fatalf("%v: expected disk hits without loading, got %d loads and %+v", strategy, loads, stats)
+		}
+		if !gee.mainCache.contains(gee.cacheKey("key49")) {
+			t.Fatalf("%v: expected disk hit to be promoted to memory", strategy)
+		}
+
+		// 写入、删除与按标签失效会丢弃磁盘中的旧值
+		gee.Set("key0", []byte("new"), 0)
+		for i := 1; i < 50; i++ {
+			gee.Get("key" + strconv.Itoa(i))
+		}
+		if v, err := gee.Get("key0"); err != nil || v.String() != "new" {
+			t.Fatalf("%v: expected overwritten key0=new, got %q (%v)", strategy, v.String(), err)
+		}
+		gee.Delete("key1")
+		if n := gee.InvalidateTag("t1"); n != 1 {
+			t.Fatalf("%v: expected spilled tagged key to be invalidated, got %d", strategy, n)
+		}
+		for _, key := range []string{"key1", "tagged"} {
+			if _, err := gee.Get(key); err == nil {
+				t.Fatalf("%v: expected %s to be removed from disk", strategy, key)
+			}
+		}
+
+		// 条件写入基于磁盘层中的值：计数器继续累加，Add 不会覆盖，Replace 与 CompareAndDelete 能找到 key
+		for i := 0; i < 50; i++ {
+			gee.Set("key"+strconv.Itoa(i), []byte(value(i)), 0)
+		}
+		gee.Set("counter", []byte("41"), 0)
+		for i := 50; i < 100; i++ {
+			gee.Set("key"+strconv.Itoa(i), []byte(value(i)), 0)
+		}
+		if gee.mainCache.contains(gee.cacheKey("counter")) {
+			t.Fatalf("%v: expected counter to be spilled", strategy)
+		}
+		if n, err := gee.Incr("counter", 1, 0, 0); err != nil || n != 42 {
+			t.Fatalf("%v: expected spilled counter to continue at 42, got %d (%v)", strategy, n, err)
+		}
+		if _, err := gee.Add("key2", []byte("x"), 0); err != ErrKeyExists {
+			t.Fatalf("%v: expected Add on a spilled key to fail, got %v", strategy, err)
+		}
+		if _, err := gee.Replace("key3", []byte("replaced"), 0); err != nil {
+			t.Fatalf("%v: expected Replace on a spilled key to succeed, got %v", strategy, err)
+		}
+		if v, err := gee.Get("key3"); err != nil || v.String() != "replaced" {
+			t.Fatalf("%v: expected key3=replaced, got %q (%v)", strategy, v.String(), err)
+		}
+
+		gee.Clear()
+		if stats := gee.Stats(); stats.DiskItemCount != 0 {
+			t.Fatalf("%v: expected Clear to empty the disk tier, got %d", strategy, stats.DiskItemCount)
+		}
+		gee.Close()
+		if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+			t.Fatalf("%v: expected Close to remove segment files, got %v", strategy, files)
+		}
+	}
+
+	getter := GetterFunc(func(key string) ([]byte, error) { return nil, nil })
+	if _, err := NewGroup("disk-bad", getter, WithDiskTier(t.TempDir(), 0)); err == nil {
+		t.Fatalf("expected non-positive disk tier size to be rejected")
+	}
+}
+
+type warmPeer struct {
+	group *Group
+}
+
+func (p warmPeer) Get(group string, key string) ([]byte, error) {
+	view, err := p.group.Get(key)
+	return view.ByteSlice(), err
+}
+
+func (p warmPeer) Dump(group, cursor string, count int, owner string) ([]DumpEntry, string, error) {
+	return p.group.Dump(cursor, count, owner)
+}
+
+// warmPicker 将以 b 开头的 key 分配给节点 b，其余 key 属于节点 a
+type warmPicker struct {
+	self string
+	peer warmPeer
+}
+
+func (p warmPicker) Owner(key string) string {
+	if strings.HasPrefix(key, "b") {
+		return "b"
+	}
+	return "a"
+}
+
+func (p warmPicker) Self() string { return p.self }
+
+func (p warmPicker) PickPeer(key string) (PeerGetter, bool) {
+	if p.Owner(key) == p.self {
+		return nil, false
+	}
+	return p.peer, true
+}
+
+func (p warmPicker) ListPeers() []PeerGetter { return []PeerGetter{p.peer} }
+
+func TestWarm(t *testing.T) {
+	var mu sync.Mutex
+	loads := make(map[string]int)
+	release := make(chan struct{})
+	getter := GetterFunc(func(key string) ([]byte, error) {
+		mu.Lock()
+		loads[key]++
+		mu.Unlock()
+		if key == "slow" {
+			<-release
+		}
+		if strings.HasPrefix(key, "bad") {
+			return nil, fmt.Errorf("%s not exist", key)
+		}
+		return []byte("v:" + key), nil
+	})
+	gee, err := NewGroup("warm", getter, WithStrategy(StrategyLRUK, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+	if !gee.IsReady() {
+		t.Fatalf("expected group to be ready before warming")
+	}
+
+	// 预热期间 Ready 不关闭，结束后关闭
+	gee.Set("cached", []byte("x"), 0)
+	keys := KeyList{"slow", "cached", "bad1", "slow"}
+	for i := 0; i < 250; i++ {
+		keys = append(keys, "key"+strconv.Itoa(i))
+	}
+	var reports []WarmProgress
+	wait := gee.StartWarm(context.Background(), Warmup{
+		Source:      keys,
+		Concurrency: 4,
+		OnProgress:  func(p WarmProgress) { reports = append(reports, p) },
+	})
+	// StartWarm 返回时已标记为预热中
+	if gee.IsReady() {
+		t.Fatalf("expected group not to be ready while warming")
+	}
+	ready := gee.Ready()
+	close(release)
+	progress, err := wait()
+	if err != nil {
+		t.Fatalf("unexpected warm error: %v", err)
+	}
+	<-ready
+	if progress.Loaded != 251 || progress.Skipped != 2 || progress.Failed != 1 || !progress.Done {
+		t.Fatalf("unexpected warm progress %+v", progress)
+	}
+	if len(reports) < 3 || reports[len(reports)-1] != progress || reports[0].Done {
+		t.Fatalf("expected intermediate progress reports ending with the final one, got %+v", reports)
+	}
+	if loads["slow"] != 1 || loads["cached"] != 0 {
+		t.Fatalf("expected duplicate and cached keys not to be reloaded, got %v", loads)
+	}
+	// LRU-K 下预热的值无需 K 次访问即进入缓存
+	if !gee.mainCache.contains(gee.cacheKey("key0")) {
+		t.Fatalf("expected warmed key to bypass lru-k admission")
+	}
+	if v, err := gee.Get("key249"); err != nil || v.String() != "v:key249" || loads["key249"] != 1 {
+		t.Fatalf("expected warmed key249 from cache, got %q (%v), %d loads", v.String(), err, loads["key249"])
+	}
+
+	// 从文件读取 key，忽略空行与注释
+	path := filepath.Join(t.TempDir(), "keys.txt")
+	if err := os.WriteFile(path, []byte("# hot keys\nfile1\n\n  file2  \nkey0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if progress, err := gee.Warm(context.Background(), Warmup{Source: FileKeySource(path)}); err != nil ||
+		progress.Loaded != 2 || progress.Skipped != 1 {
+		t.Fatalf("unexpected file warm progress %+v (%v)", progress, err)
+	}
+	if _, err := gee.Warm(context.Background(), Warmup{Source: FileKeySource(path + ".missing")}); err == nil {
+		t.Fatalf("expected missing key file to fail")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := gee.Warm(ctx, Warmup{Source: KeyList{"canceled"}}); err != context.Canceled || loads["canceled"] != 0 {
+		t.Fatalf("expected canceled warm to stop, got %v", err)
+	}
+	if !gee.IsReady() {
+		t.Fatalf("expected group to be ready after warming")
+	}
+
+	// 节点 b 分页导出属于节点 a 的值，保留剩余 TTL
+	nodeB, _ := NewGroup("warm-b", getter)
+	defer nodeB.Close()
+	nodeA, _ := NewGroup("warm-a", getter)
+	defer nodeA.Close()
+	nodeB.RegisterPeers(warmPicker{self: "b", peer: warmPeer{nodeA}})
+	nodeA.RegisterPeers(warmPicker{self: "a", peer: warmPeer{nodeB}})
+	for i := 0; i < 10; i++ {
+		nodeB.Set("a"+strconv.Itoa(i), []byte("va"+strconv.Itoa(i)), 100)
+		nodeB.Set("b"+strconv.Itoa(i), []byte("vb"+strconv.Itoa(i)), 0)
+	}
+	nodeB.HSet("ahash", map[string][]byte{"f": []byte("1")}, 0)
+	dumped := make(map[string]DumpEntry)
+	pages := 0
+	for cursor := ""; ; pages++ {
+		entries, next, err := nodeB.Dump(cursor, 3, "a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entries {
+			dumped[e.Key] = e
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(dumped) != 10 || pages < 3 {
+		t.Fatalf("expected 10 entries owned by a over several pages, got %d in %d pages", len(dumped), pages)
+	}
+	if e := dumped["a3"]; string(e.Value) != "va3" || e.TTL < 98 || e.TTL > 100 {
+		t.Fatalf("unexpected dumped entry %+v", e)
+	}
+	if all, _, _ := nodeB.Dump("", 100, ""); len(all) != 20 {
+		t.Fatalf("expected 20 entries without an owner filter, got %d", len(all))
+	}
+
+	// 节点 a 从节点 b 拉取属于自己的值，不经过 Getter；已写入的 key 不被覆盖
+	nodeA.Set("a0", []byte("local"), 0)
+	before := len(loads)
+	progress, err = nodeA.Warm(context.Background(), Warmup{FromPeers: true})
+	if err != nil || progress.Loaded != 9 || progress.Skipped != 1 {
+		t.Fatalf("unexpected peer warm progress %+v (%v)", progress, err)
+	}
+	if v, _ := nodeA.Get("a0"); v.String() != "local" {
+		t.Fatalf("expected peer warm not to overwrite a0, got %q", v.String())
+	}
+	if v, _ := nodeA.Get("a5"); v.String() != "va5" || len(loads) != before {
+		t.Fatalf("expected a5 warmed from peer without loading, got %q", v.String())
+	}
+	if nodeA.mainCache.contains(nodeA.cacheKey("b1")) {
+		t.Fatalf("expected keys owned by b not to be warmed on a")
+	}
+}
+
+func TestHotKeys(t *testing.T) {
+	getter := GetterFunc(func(key string) ([]byte, error) { return []byte(key), nil })
+	gee, err := NewGroup("hotkeys", getter, WithHotKeys(16, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gee.Close()
+
+	for i := 0; i < 100; i++ {
+		gee.Get("hot")
+		if i%2 == 0 {
+			gee.Get("warm")
+		}
+		gee.Get("cold" + strconv.Itoa(i))
+	}
+	// 写入不计入访问
+	for i := 0; i < 200; i++ {
+		gee.Set("written", []byte("x"), 0)
+	}
+	keys := gee.HotKeys(2)
+	if len(keys) != 2 || keys[0].Key != "hot" || keys[1].Key != "warm" {
+		t.Fatalf("unexpected hot keys %+v", keys)
+	}
+	if keys[0].Count < 100 || keys[0].QPS <= 0 {
+		t.Fatalf("unexpected estimate for hot %+v", keys[0])
+	}
+	if all := gee.HotKeys(0); len(all) != 16 {
+		t.Fatalf("expected at most 16 tracked keys, got %+v", all)
+	}
+
+	disabled, _ := NewGroup("hotkeys-disabled", getter)
+	defer disabled.Close()
+	disabled.Get("hot")
+	if disabled.HotKeysEnabled() || disabled.HotKeys(10) != nil {
+		t.Fatalf("expected hot key tracking to be disabled by default")
+	}
+	for _, opt := range []Option{WithHotKeys(-1, 1), WithHotKeys(8, 0)} {
+		if _, err := NewGroup("hotkeys-bad", getter, opt); err == nil {
+			t.Fatalf("expected invalid hot key options to be rejected")
+		}
+	}
+}
+
+// channelPicker 将 remote 开头的 key 路由到远程节点，其余属于本节点
+type channelPicker struct{}
+
+func (channelPicker) PickPeer(key string) (PeerGetter, bool) {
+	if strings.HasPrefix(key, "remote") {
+		return blockingPeer{}, true
+	}
+	return nil, false
+}
+
+func TestChannelOwner(t *testing.T) {
+	s := NewKitexServer(channelPicker{})
+	ctx := context.Background()
+
+	if _, err := s.Publish(ctx, &geecache.PublishRequest{Channel: "remote-jobs", Payload: []byte("x")}); err != ErrNotOwner {
+		t.Fatalf("expected ErrNotOwner for a channel owned by a peer, got %v", err)
+	}
+	if _, err := s.Subscribe(ctx, &geecache.SubscribeRequest{Channel: "remote-jobs", TimeoutMs: 1}); err != ErrNotOwner {
+		t.Fatalf("expected ErrNotOwner for a channel owned by a peer, got %v", err)
+	}
+
+	sub, err := s.Subscribe(ctx, &geecache.SubscribeRequest{Channel: "jobs", TimeoutMs: 1})
+	if err != nil || sub.Subscription == "" {
+		t.Fatalf("subscribe to an owned channel failed: %+v %v", sub, err)
+	}
+	if resp, err := s.Publish(ctx, &geecache.PublishRequest{Channel: "jobs", Payload: []byte("x")}); err != nil || resp.Receivers != 1 {
+		t.Fatalf("expected one receiver, got %+v %v", resp, err)
+	}
+}
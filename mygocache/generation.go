@@ -0,0 +1,152 @@
+package mygocache
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"mygocache/asynclog"
+)
+
+// NamespaceSeparator 命名空间分隔符：key 中每个分隔符之前的前缀都是一个命名空间，
+// 例如 "user:42:profile" 同时属于命名空间 "user" 与 "user:42"
+const NamespaceSeparator = ':'
+
+// generations 记录 Group 及各命名空间的代数。代数嵌入实际写入缓存的 key 中，
+// 递增代数后旧 key 不再被访问到，相当于 O(1) 地批量失效，旧条目随后经 LRU 淘汰或过期回收
+type generations struct {
+	mu         sync.RWMutex
+	group      uint64
+	namespaces map[string]uint64 // 仅记录递增过的命名空间
+}
+
+func newGenerations() *generations {
+	return &generations{namespaces: make(map[string]uint64)}
+}
+
+// bump 递增命名空间的代数并返回新值，namespace 为空时递增整个 Group 的代数
+func (gs *generations) bump(namespace string) uint64 {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if namespace == "" {
+		gs.group++
+		return gs.group
+	}
+	gs.namespaces[namespace]++
+	return gs.namespaces[namespace]
+}
+
+// snapshot 返回 Group 的代数与各命名空间代数的副本
+func (gs *generations) snapshot() (group uint64, namespaces map[string]uint64) {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	namespaces = make(map[string]uint64, len(gs.namespaces))
+	for ns, gen := range gs.namespaces {
+		namespaces[ns] = gen
+	}
+	return gs.group, namespaces
+}
+
+// restore 将代数提升到不小于 group 与 namespaces 中的值。代数只增不减，
+// 避免回退后重新使用已作废的代数，使旧条目重新可见
+func (gs *generations) restore(group uint64, namespaces map[string]uint64) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if group > gs.group {
+		gs.group = group
+	}
+	for ns, gen := range namespaces {
+		if gen > gs.namespaces[ns] {
+			gs.namespaces[ns] = gen
+		}
+	}
+}
+
+// cacheKey 返回 key 在当前代数下实际写入缓存的 key。
+// Group 与 key 所属的命名空间均未递增过代数时直接返回 key，
+// 否则编码为 "\x00<代数列表>\x00key"，代数列表只包含非 0 的项
+func (gs *generations) cacheKey(key string) string {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	if gs.group == 0 && len(gs.namespaces) == 0 {
+		return key
+	}
+
+	var b strings.Builder
+	if gs.group > 0 {
+		b.WriteString("g")
+		b.WriteString(strconv.FormatUint(gs.group, 10))
+	}
+	if len(gs.namespaces) > 0 {
+		for i := 0; i < len(key); i++ {
+			if key[i] != NamespaceSeparator {
+				continue
+			}
+			if gen := gs.namespaces[key[:i]]; gen > 0 {
+				// 以前缀长度标识命名空间，key 确定时编码唯一
+				b.WriteString("." + strconv.Itoa(i) + "=" + strconv.FormatUint(gen, 10))
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return key
+	}
+	return "\x00" + b.String() + "\x00" + key
+}
+
+// userKey 从缓存 key 中还原原始 key，缓存 key 属于旧代数时返回 ok=false
+func (gs *generations) userKey(cacheKey string) (key string, ok bool) {
+	key = cacheKey
+	if strings.HasPrefix(cacheKey, "\x00") {
+		if i := strings.IndexByte(cacheKey[1:], 0); i >= 0 {
+			key = cacheKey[i+2:]
+		}
+	}
+	return key, gs.cacheKey(key) == cacheKey
+}
+
+// cacheKey 返回 key 在当前代数下实际写入缓存的 key
+func (g *Group) cacheKey(key string) string {
+	return g.gens.cacheKey(key)
+}
+
+// BumpGeneration 递增本节点上命名空间的代数，使该命名空间下的所有 key 立即失效，返回新的代数。
+// namespace 为空时作废整个 Group。与 Clear 不同，该操作不遍历条目，
+// 旧条目仍占用容量，随后经 LRU 淘汰或过期回收；进行中的加载结果写入旧代数，同样不可见
+func (g *Group) BumpGeneration(namespace string) uint64 {
+	var generation uint64
+	g.logged(func() { generation = g.gens.bump(namespace) }, func(sw *snapshotWriter) {
+		sw.writeByte(recordGeneration)
+		sw.writeString(namespace)
+		sw.writeUvarint(generation)
+	})
+	return generation
+}
+
+// BumpGenerationCluster 在本节点及所有远程节点上递增命名空间的代数，返回本节点的新代数。
+// 非 key 所属节点也可能通过加载缓存了副本，因此需要向全部节点广播。
+// 各节点的代数独立计数，只需保证每个节点都递增过
+func (g *Group) BumpGenerationCluster(namespace string) (uint64, error) {
+	generation := g.BumpGeneration(namespace)
+
+	lister, ok := g.peers.(PeerLister)
+	if !ok {
+		return generation, nil
+	}
+	_, err := g.broadcast(lister.ListPeers(), func(peer PeerGetter) (int64, error) {
+		bumper, ok := peer.(PeerGenerationBumper)
+		if !ok {
+			return 0, nil
+		}
+		_, err := bumper.BumpGeneration(g.name, namespace)
+		if err != nil {
+			asynclog.Printf("[GeeCache] bump generation of namespace %q on peer failed: %v", namespace, err)
+		}
+		return 0, err
+	})
+	return generation, err
+}
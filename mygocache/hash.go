@@ -0,0 +1,255 @@
+package mygocache
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"mygocache/lru"
+)
+
+// ErrWrongType 表示对哈希类型的 key 执行字符串操作，或对字符串类型的 key 执行哈希操作
+var ErrWrongType = errors.New("operation against a key holding the wrong kind of value")
+
+// 哈希值只存在于缓存中，不经 Getter 加载；整个哈希共用一个 TTL，
+// 修改单个字段时缓存只按该字段增减的字节数调整容量，不重写整个值。
+// Set 等字符串写入会覆盖同名的哈希，Get 等字符串读取遇到哈希时返回 ErrWrongType。
+// 哈希的修改产生 Value 为空的 ChangeSet 事件，被淘汰、过期或删除时钩子与订阅者收到的 Value 同样为空。
+// 注册了 PeerPicker 时请求会被路由到 key 所属的节点执行
+
+// HSet 原子地设置哈希 key 中的若干字段，返回新增的字段数。
+// key 不存在（或已过期、命中负缓存）时新建哈希，ttl 仅在新建时生效，之后的修改保留原有的过期时间
+func (g *Group) HSet(key string, fields map[string][]byte, ttl int64) (int, error) {
+	if key == "" {
+		return 0, fmt.Errorf("key is required")
+	}
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("fields are required")
+	}
+	if peer, ok := g.hashPeer(key); ok {
+		return peer.HSet(g.name, key, fields, ttl)
+	}
+	return g.hSetLocally(key, fields, ttl)
+}
+
+// HGet 返回哈希 key 中字段的值，key 或字段不存在时返回 ErrKeyNotFound
+func (g *Group) HGet(key string, field string) (ByteView, error) {
+	if key == "" {
+		return ByteView{}, fmt.Errorf("key is required")
+	}
+	if peer, ok := g.hashPeer(key); ok {
+		value, err := peer.HGet(g.name, key, field)
+		if err != nil {
+			return ByteView{}, err
+		}
+		return ByteView{b: value}, nil
+	}
+	return g.hGetLocally(key, field)
+}
+
+// HDel 原子地删除哈希 key 中的字段，返回实际删除的字段数。最后一个字段被删除时 key 一并删除
+func (g *Group) HDel(key string, fields ...string) (int, error) {
+	if key == "" {
+		return 0, fmt.Errorf("key is required")
+	}
+	if peer, ok := g.hashPeer(key); ok {
+		return peer.HDel(g.name, key, fields)
+	}
+	return g.hDelLocally(key, fields)
+}
+
+// HGetAll 返回哈希 key 的所有字段，key 不存在时返回 ErrKeyNotFound
+func (g *Group) HGetAll(key string) (map[string][]byte, error) {
+	if key == "" {
+		return nil, fmt.Errorf("key is required")
+	}
+	if peer, ok := g.hashPeer(key); ok {
+		return peer.HGetAll(g.name, key)
+	}
+	return g.hGetAllLocally(key)
+}
+
+// HIncrBy 原子地将哈希 key 中字段的整数值增加 delta，返回增加后的值。
+// 字段不存在时视为 0；key 不存在时新建哈希，ttl 仅在新建时生效
+func (g *Group) HIncrBy(key string, field string, delta, ttl int64) (int64, error) {
+	if key == "" {
+		return 0, fmt.Errorf("key is required")
+	}
+	if peer, ok := g.hashPeer(key); ok {
+		return peer.HIncrBy(g.name, key, field, delta, ttl)
+	}
+	return g.hIncrByLocally(key, field, delta, ttl)
+}
+
+// hashPeer 返回 key 所属的远程节点，key 属于本节点或节点不支持哈希操作时返回 false
+func (g *Group) hashPeer(key string) (PeerHasher, bool) {
+	if g.peers == nil {
+		return nil, false
+	}
+	peer, ok := g.peers.PickPeer(key)
+	if !ok {
+		return nil, false
+	}
+	hasher, ok := peer.(PeerHasher)
+	return hasher, ok
+}
+
+// hashTTL 检查 old 能否作为哈希修改，返回写入时使用的 TTL：
+// old 已是哈希时保留原有的过期时间，不存在或为负缓存时使用 ttl
+func hashTTL(old lru.Value, ttl int64) (int64, error) {
+	switch v := old.(type) {
+	case nil:
+		return ttl, nil
+	case *lru.Hash:
+		return lru.KeepTTL, nil
+	default:
+		if v.Len() > 0 {
+			return 0, ErrWrongType
+		}
+		return ttl, nil
+	}
+}
+
+func (g *Group) hSetLocally(key string, fields map[string][]byte, ttl int64) (int, error) {
+	// 在分片锁外复制字段值
+	values := make(map[string][]byte, len(fields))
+	for field, value := range fields {
+		values[field] = cloneBytes(value)
+	}
+
+	ck := g.cacheKey(key)
+	// 显式写入作废进行中的填充租约，避免加载结果覆盖哈希
+	g.leases.invalidate(ck)
+	added := 0
+	_, err := g.mainCache.updateHash(ck, func(h *lru.Hash, old lru.Value) (int64, error) {
+		ttl, err := hashTTL(old, ttl)
+		if err != nil {
+			return 0, err
+		}
+		for field, value := range values {
+			if h.Set(field, value) {
+				added++
+			}
+		}
+		return ttl, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	g.keyWritten(ck)
+	g.notifySet(key, ByteView{})
+	return added, nil
+}
+
+func (g *Group) hGetLocally(key string, field string) (ByteView, error) {
+	var value []byte
+	found := false
+	var err error
+	g.mainCache.view(g.cacheKey(key), func(v lru.Value) {
+		h, ok := v.(*lru.Hash)
+		if !ok {
+			if v.Len() > 0 {
+				err = ErrWrongType
+			}
+			return
+		}
+		// 字段值只会被整体替换而不会被就地修改，可以直接共享
+		value, found = h.Get(field)
+	})
+	if err != nil {
+		return ByteView{}, err
+	}
+	if !found {
+		g.mainCache.recordMiss()
+		return ByteView{}, ErrKeyNotFound
+	}
+	g.mainCache.recordHit()
+	return ByteView{b: value}, nil
+}
+
+func (g *Group) hDelLocally(key string, fields []string) (int, error) {
+	removed := 0
+	emptied := false
+	ck := g.cacheKey(key)
+	g.leases.invalidate(ck)
+	_, err := g.mainCache.updateHash(ck, func(h *lru.Hash, old lru.Value) (int64, error) {
+		if _, err := hashTTL(old, 0); err != nil {
+			return 0, err
+		}
+		for _, field := range fields {
+			if h.Delete(field) {
+				removed++
+			}
+		}
+		emptied = h.Count() == 0
+		return lru.KeepTTL, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if removed > 0 {
+		g.keyWritten(ck)
+	}
+	// 哈希被清空时由分片上报 ChangeDelete
+	if removed > 0 && !emptied {
+		g.notifySet(key, ByteView{})
+	}
+	return removed, nil
+}
+
+func (g *Group) hGetAllLocally(key string) (map[string][]byte, error) {
+	var result map[string][]byte
+	var err error
+	g.mainCache.view(g.cacheKey(key), func(v lru.Value) {
+		h, ok := v.(*lru.Hash)
+		if !ok {
+			if v.Len() > 0 {
+				err = ErrWrongType
+			}
+			return
+		}
+		result = make(map[string][]byte, h.Count())
+		h.Range(func(field string, value []byte) bool {
+			result[field] = cloneBytes(value)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		g.mainCache.recordMiss()
+		return nil, ErrKeyNotFound
+	}
+	g.mainCache.recordHit()
+	return result, nil
+}
+
+func (g *Group) hIncrByLocally(key string, field string, delta, ttl int64) (int64, error) {
+	ck := g.cacheKey(key)
+	g.leases.invalidate(ck)
+	var result int64
+	_, err := g.mainCache.updateHash(ck, func(h *lru.Hash, old lru.Value) (int64, error) {
+		ttl, err := hashTTL(old, ttl)
+		if err != nil {
+			return 0, err
+		}
+		var n int64
+		if v, ok := h.Get(field); ok {
+			if n, err = strconv.ParseInt(string(v), 10, 64); err != nil {
+				return 0, ErrNotInteger
+			}
+		}
+		if result, err = addInt64(n, delta); err != nil {
+			return 0, err
+		}
+		h.Set(field, []byte(strconv.FormatInt(result, 10)))
+		return ttl, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	g.keyWritten(ck)
+	g.notifySet(key, ByteView{})
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package mygocache
+
+import (
+	"sync"
+
+	"mygocache/asynclog"
+	"mygocache/lru"
+)
+
+// EvictionReason 表示条目离开缓存（或被覆盖）的原因
+type EvictionReason int
+
+const (
+	// ReasonEvicted 容量不足被 LRU 淘汰
+	ReasonEvicted EvictionReason = EvictionReason(lru.RemoveEvicted)
+	// ReasonExpired TTL 到期
+	ReasonExpired EvictionReason = EvictionReason(lru.RemoveExpired)
+	// ReasonDeleted 被 Delete、Clear、CompareAndDelete 或 InvalidateTag 显式删除
+	ReasonDeleted EvictionReason = EvictionReason(lru.RemoveDeleted)
+	// ReasonOverwritten 被新值覆盖，钩子收到的是旧值
+	ReasonOverwritten EvictionReason = EvictionReason(lru.RemoveReplaced)
+)
+
+// String 返回原因的可读名称
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonEvicted:
+		return "evicted"
+	case ReasonExpired:
+		return "expired"
+	case ReasonDeleted:
+		return "deleted"
+	case ReasonOverwritten:
+		return "overwritten"
+	}
+	return "unknown"
+}
+
+// EvictionHook 在条目被淘汰、过期、删除或覆盖后调用，key 为调用方写入时使用的 key
+type EvictionHook func(key string, value ByteView, reason EvictionReason)
+
+// onRemoved 在条目被淘汰、过期、删除或覆盖时由分片调用（持有分片锁期间），
+// 清理该 key 的附属状态并派发钩子与变更事件。key 为实际写入缓存的 key，value 为 ByteView 或 *lru.Hash
+func (g *Group) onRemoved(key string, value lru.Value, reason lru.RemoveReason) {
+	// 启用磁盘层时被淘汰 key 的标签由 spill 处理
+	if reason != lru.RemoveReplaced && (reason != lru.RemoveEvicted || g.disk == nil) {
+		g.tags.remove(key)
+	}
+	if reason == lru.RemoveExpired && g.aof != nil {
+		g.aof.expire(key)
+	}
+
+	userKey, current := g.gens.userKey(key)
+	// 分块是内部状态，不通知回调、钩子与订阅者
+	if isChunkKey(userKey) {
+		return
+	}
+	// 哈希值没有单一的字节表示，回调、钩子与订阅者收到空的 ByteView
+	view, isView := value.(ByteView)
+	// 负缓存条目是内部状态，不通知回调、钩子与订阅者
+	if isView && view.Len() == 0 {
+		return
+	}
+	g.dispatchEviction(userKey, view, EvictionReason(reason))
+	if current {
+		g.notifyRemoved(userKey, view, reason)
+	}
+}
+
+// callbackHook 将 WithEvictionCallback 的回调适配为钩子，被新值覆盖时不调用
+func callbackHook(fn func(key string, value ByteView)) EvictionHook {
+	return func(key string, value ByteView, reason EvictionReason) {
+		if reason != ReasonOverwritten {
+			fn(key, value)
+		}
+	}
+}
+
+// decodeRemoved 将被移除的存储形式还原为原值，供回调与订阅者使用，无法还原时返回空值
+func (g *Group) decodeRemoved(key string, value ByteView) ByteView {
+	view, err := g.decodeValue(key, value)
+	if err != nil {
+		asynclog.Printf("[GeeCache] decode removed value of key=%s failed: %v", key, err)
+	}
+	// 分块存储的值不再组装，与哈希一样返回空值
+	if view.enc&encManifest != 0 {
+		return ByteView{}
+	}
+	return view
+}
+
+// dispatchEviction 通过协程池异步执行钩子，避免慢钩子阻塞分片锁。
+// 值在钩子所在的协程中还原，钩子之间以及同一 key 的多次事件之间不保证顺序
+func (g *Group) dispatchEviction(key string, value ByteView, reason EvictionReason) {
+	if len(g.evictionHooks) == 0 {
+		return
+	}
+	var once sync.Once
+	var view ByteView
+	decode := func() ByteView {
+		once.Do(func() { view = g.decodeRemoved(key, value) })
+		return view
+	}
+	for _, hook := range g.evictionHooks {
+		hook := hook
+		task := func() { hook(key, decode(), reason) }
+		if err := g.goroutinePool.Submit(task); err != nil {
+			// 协程池已关闭，仍在锁外执行
+			go task()
+		}
+	}
+}
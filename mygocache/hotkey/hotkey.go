@@ -0,0 +1,215 @@
+// Package hotkey 使用 Space-Saving 算法在固定内存内找出访问最频繁的 key（heavy hitters）。
+// 每个窗口最多跟踪 capacity 个 key：新 key 在计数器已满时替换计数最小的 key 并继承其计数，
+// 因此计数是真实访问次数的上界，误差不超过被替换时的最小计数。
+// 访问按 1/sampleRate 采样记录，估计值再乘以 sampleRate；计数按窗口轮换，只反映最近一到两个窗口内的访问
+package hotkey
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultWindow 默认的统计窗口长度
+const DefaultWindow = 10 * time.Second
+
+// Item 是一个热点 key 的估计值
+type Item struct {
+	Key string
+	// Count 最近的窗口内的估计访问次数（已按采样率放大），是真实值的上界
+	Count uint64
+	// Error Count 可能高估的最大次数
+	Error uint64
+	// QPS 最近的窗口内的估计每秒访问次数
+	QPS float64
+}
+
+// Tracker 是并发安全的热点 key 统计器
+type Tracker struct {
+	// seen 记录过的访问次数，用于采样，放在首位以保证 32 位平台上的原子操作对齐
+	seen       uint64
+	sampleRate uint64
+	capacity   int
+	window     time.Duration
+
+	mu sync.Mutex
+	// cur 当前窗口的计数，curStart 为其开始时间
+	cur      *summary
+	curStart time.Time
+	// prev 上一个完整窗口的计数，窗口间隔超过一个窗口时为空
+	prev map[string]counter
+	// now 返回当前时间，测试时可替换
+	now func() time.Time
+}
+
+// New 创建每个窗口最多跟踪 capacity 个 key、每 sampleRate 次访问记录一次的 Tracker，
+// window 为统计窗口长度，不大于 0 时使用 DefaultWindow
+func New(capacity, sampleRate int, window time.Duration) *Tracker {
+	if capacity < 1 {
+		capacity = 1
+	}
+	if sampleRate < 1 {
+		sampleRate = 1
+	}
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	t := &Tracker{
+		sampleRate: uint64(sampleRate),
+		capacity:   capacity,
+		window:     window,
+		now:        time.Now,
+	}
+	t.cur = newSummary(capacity)
+	t.curStart = t.now()
+	return t
+}
+
+// Record 记录一次对 key 的访问，未被采样的访问只有一次原子加法的开销
+func (t *Tracker) Record(key string) {
+	if t.sampleRate > 1 && atomic.AddUint64(&t.seen, 1)%t.sampleRate != 0 {
+		return
+	}
+	t.mu.Lock()
+	t.rotate(t.now())
+	t.cur.add(key)
+	t.mu.Unlock()
+}
+
+// Top 返回最近的窗口内估计访问次数最多的 n 个 key，按 Count 降序排列；n 不大于 0 时返回所有跟踪的 key
+func (t *Tracker) Top(n int) []Item {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.rotate(now)
+
+	merged := make(map[string]counter, len(t.prev)+len(t.cur.counters))
+	for key, c := range t.prev {
+		merged[key] = c
+	}
+	for _, c := range t.cur.counters {
+		m := merged[c.key]
+		merged[c.key] = counter{count: m.count + c.count, err: m.err + c.err}
+	}
+	elapsed := now.Sub(t.curStart)
+	if t.prev != nil {
+		elapsed += t.window
+	}
+	if elapsed < time.Millisecond {
+		elapsed = time.Millisecond
+	}
+
+	items := make([]Item, 0, len(merged))
+	for key, c := range merged {
+		count := c.count * t.sampleRate
+		items = append(items, Item{
+			Key:   key,
+			Count: count,
+			Error: c.err * t.sampleRate,
+			QPS:   float64(count) / elapsed.Seconds(),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Key < items[j].Key
+	})
+	if n > 0 && len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
+
+// Reset 清空所有计数
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cur = newSummary(t.capacity)
+	t.curStart = t.now()
+	t.prev = nil
+}
+
+// rotate 在当前窗口结束时开始新的窗口，调用方需持有 mu
+func (t *Tracker) rotate(now time.Time) {
+	elapsed := now.Sub(t.curStart)
+	if elapsed < t.window {
+		return
+	}
+	t.prev = nil
+	if elapsed < 2*t.window {
+		t.prev = make(map[string]counter, len(t.cur.counters))
+		for _, c := range t.cur.counters {
+			t.prev[c.key] = *c
+		}
+		t.curStart = t.curStart.Add(t.window)
+	} else {
+		t.curStart = now
+	}
+	t.cur = newSummary(t.capacity)
+}
+
+// counter 是一个 key 的计数与高估的上限
+type counter struct {
+	key   string
+	count uint64
+	err   uint64
+	index int // 在堆中的下标
+}
+
+// summary 是 Space-Saving 的计数器集合，以计数为键的最小堆组织，堆顶是计数最小的 key
+type summary struct {
+	capacity int
+	counters counterHeap
+	index    map[string]*counter
+}
+
+func newSummary(capacity int) *summary {
+	return &summary{capacity: capacity, index: make(map[string]*counter)}
+}
+
+// add 将 key 的计数加一，计数器已满时替换计数最小的 key
+func (s *summary) add(key string) {
+	if c, ok := s.index[key]; ok {
+		c.count++
+		heap.Fix(&s.counters, c.index)
+		return
+	}
+	if len(s.counters) < s.capacity {
+		c := &counter{key: key, count: 1}
+		s.index[key] = c
+		heap.Push(&s.counters, c)
+		return
+	}
+	c := s.counters[0]
+	delete(s.index, c.key)
+	c.key = key
+	c.err = c.count
+	c.count++
+	s.index[key] = c
+	heap.Fix(&s.counters, 0)
+}
+
+// counterHeap 实现 heap.Interface
+type counterHeap []*counter
+
+func (h counterHeap) Len() int           { return len(h) }
+func (h counterHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *counterHeap) Push(x interface{}) {
+	c := x.(*counter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+func (h *counterHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,116 @@
+package hotkey
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeClock 返回可手动推进的时间
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestTracker(capacity, sampleRate int, window time.Duration) (*Tracker, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	t := New(capacity, sampleRate, window)
+	t.now = clock.now
+	t.curStart = clock.t
+	return t, clock
+}
+
+func TestTopK(t *testing.T) {
+	tracker, clock := newTestTracker(8, 1, 10*time.Second)
+	// 三个热点 key 与大量只访问一次的冷 key 交错出现
+	for i := 0; i < 1000; i++ {
+		tracker.Record("hot1")
+		if i%2 == 0 {
+			tracker.Record("hot2")
+		}
+		if i%4 == 0 {
+			tracker.Record("hot3")
+		}
+		tracker.Record("cold" + strconv.Itoa(i))
+	}
+	clock.t = clock.t.Add(5 * time.Second)
+
+	top := tracker.Top(3)
+	if len(top) != 3 || top[0].Key != "hot1" || top[1].Key != "hot2" || top[2].Key != "hot3" {
+		t.Fatalf("unexpected top keys %+v", top)
+	}
+	// 计数是真实值的上界，误差不超过 Error
+	for i, want := range []uint64{1000, 500, 250} {
+		if top[i].Count < want || top[i].Count-top[i].Error > want {
+			t.Fatalf("count of %s = %d (error %d), want %d", top[i].Key, top[i].Count, top[i].Error, want)
+		}
+	}
+	if qps := top[0].QPS; qps < float64(top[0].Count)/5-1 || qps > float64(top[0].Count)/5+1 {
+		t.Fatalf("unexpected qps %v for %d hits in 5s", qps, top[0].Count)
+	}
+	if all := tracker.Top(0); len(all) != 8 {
+		t.Fatalf("expected all 8 tracked keys, got %d", len(all))
+	}
+}
+
+func TestSampling(t *testing.T) {
+	tracker, _ := newTestTracker(4, 10, time.Minute)
+	for i := 0; i < 1000; i++ {
+		tracker.Record("a")
+	}
+	if top := tracker.Top(1); len(top) != 1 || top[0].Count != 1000 {
+		t.Fatalf("expected sampled count scaled to 1000, got %+v", top)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tracker, clock := newTestTracker(4, 1, 10*time.Second)
+	for i := 0; i < 100; i++ {
+		tracker.Record("old")
+	}
+
+	// 上一个窗口的计数与当前窗口合并
+	clock.t = clock.t.Add(12 * time.Second)
+	for i := 0; i < 30; i++ {
+		tracker.Record("new")
+	}
+	top := tracker.Top(0)
+	if len(top) != 2 || top[0].Key != "old" || top[0].Count != 100 || top[1].Count != 30 {
+		t.Fatalf("unexpected top after one window %+v", top)
+	}
+	if top[0].QPS != 100.0/12 {
+		t.Fatalf("expected qps over 12s, got %v", top[0].QPS)
+	}
+
+	// 再过一个窗口，最早的计数被丢弃
+	clock.t = clock.t.Add(10 * time.Second)
+	if top := tracker.Top(0); len(top) != 1 || top[0].Key != "new" {
+		t.Fatalf("expected only the previous window, got %+v", top)
+	}
+	clock.t = clock.t.Add(time.Hour)
+	if top := tracker.Top(0); len(top) != 0 {
+		t.Fatalf("expected idle tracker to be empty, got %+v", top)
+	}
+
+	tracker.Record("x")
+	tracker.Reset()
+	if top := tracker.Top(0); len(top) != 0 {
+		t.Fatalf("expected Reset to clear counts, got %+v", top)
+	}
+}
+
+func BenchmarkRecord(b *testing.B) {
+	tracker := New(64, 16, 0)
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			tracker.Record(keys[i%len(keys)])
+			i++
+		}
+	})
+}
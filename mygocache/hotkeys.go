@@ -0,0 +1,29 @@
+package mygocache
+
+// HotKey 是 HotKeys 返回的一个热点 key 的估计值
+type HotKey struct {
+	Key string
+	// Count 最近 10 到 20 秒内经本节点 Get 访问的估计次数，是真实值的上界
+	Count int64
+	// QPS 同一时间段内的估计每秒访问次数
+	QPS float64
+}
+
+// HotKeys 返回最近经本节点 Get 访问最多的 n 个 key，按访问次数降序排列，n 不大于 0 时返回所有跟踪的 key。
+// 统计包括转发到其他节点的 key；未使用 WithHotKeys 启用时返回 nil
+func (g *Group) HotKeys(n int) []HotKey {
+	if g.hotKeys == nil {
+		return nil
+	}
+	items := g.hotKeys.Top(n)
+	keys := make([]HotKey, len(items))
+	for i, item := range items {
+		keys[i] = HotKey{Key: item.Key, Count: int64(item.Count), QPS: item.QPS}
+	}
+	return keys
+}
+
+// HotKeysEnabled 返回是否启用了热点 key 统计
+func (g *Group) HotKeysEnabled() bool {
+	return g.hotKeys != nil
+}
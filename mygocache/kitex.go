@@ -2,6 +2,7 @@ package mygocache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -14,6 +15,7 @@ import (
 	"mygocache/consistenthash"
 	"mygocache/kitex_gen/geecache"
 	"mygocache/kitex_gen/geecache/groupcache"
+	"mygocache/pubsub"
 
 	"github.com/cloudwego/kitex/client"
 	"github.com/cloudwego/kitex/pkg/connpool"
@@ -80,7 +82,37 @@ func (p *KitexPool) PickPeer(key string) (PeerGetter, bool) {
 	return nil, false
 }
 
-var _ PeerPicker = (*KitexPool)(nil)
+// ListPeers 返回除本节点外所有远程节点
+func (p *KitexPool) ListPeers() []PeerGetter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	peers := make([]PeerGetter, 0, len(p.kitexClients))
+	for _, client := range p.kitexClients {
+		peers = append(peers, &kitexGetter{client: client})
+	}
+	return peers
+}
+
+// Self 返回本节点的地址
+func (p *KitexPool) Self() string {
+	return p.self
+}
+
+// Owner 返回 key 按一致性哈希所属节点的地址，尚未设置节点列表时返回空字符串
+func (p *KitexPool) Owner(key string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.peers == nil {
+		return ""
+	}
+	return p.peers.Get(key)
+}
+
+var (
+	_ PeerPicker  = (*KitexPool)(nil)
+	_ PeerLister  = (*KitexPool)(nil)
+	_ PeerLocator = (*KitexPool)(nil)
+)
 
 type kitexGetter struct {
 	client groupcache.Client
@@ -98,15 +130,315 @@ func (g *kitexGetter) Get(group string, key string) ([]byte, error) {
 	return resp.Value, nil
 }
 
-var _ PeerGetter = (*kitexGetter)(nil)
+// GetEncoded 从远程节点获取值，远程节点使用同名压缩算法时保持压缩形式传输
+func (g *kitexGetter) GetEncoded(group string, key string, compression string) ([]byte, bool, error) {
+	resp, err := g.client.Get(context.Background(), &geecache.Request{
+		Group:             group,
+		Key:               key,
+		AcceptCompression: compression,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return resp.Value, resp.Compressed, nil
+}
+
+// Incr 在远程节点上原子地增减计数器
+func (g *kitexGetter) Incr(group string, key string, delta, initial, ttl int64) (int64, error) {
+	resp, err := g.client.Incr(context.Background(), &geecache.IncrRequest{
+		Group:   group,
+		Key:     key,
+		Delta:   delta,
+		Initial: initial,
+		Ttl:     ttl,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Value, nil
+}
+
+// Add 在远程节点上仅当 key 不存在时写入
+func (g *kitexGetter) Add(group string, key string, value []byte, ttl int64) (uint64, error) {
+	resp, err := g.client.Add(context.Background(), &geecache.SetRequest{
+		Group: group,
+		Key:   key,
+		Value: value,
+		Ttl:   ttl,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !resp.Success {
+		return 0, ErrKeyExists
+	}
+	return uint64(resp.Version), nil
+}
+
+// CompareAndSet 在远程节点上执行 CAS 写入
+func (g *kitexGetter) CompareAndSet(group string, key string, value []byte, version uint64, ttl int64) (uint64, error) {
+	resp, err := g.client.CompareAndSet(context.Background(), &geecache.CompareAndSetRequest{
+		Group:   group,
+		Key:     key,
+		Value:   value,
+		Version: int64(version),
+		Ttl:     ttl,
+	})
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case resp.Success:
+		return uint64(resp.Version), nil
+	case resp.Conflict:
+		return uint64(resp.Version), ErrVersionMismatch
+	default:
+		return 0, ErrKeyNotFound
+	}
+}
+
+// CompareAndDelete 在远程节点上执行 CAS 删除
+func (g *kitexGetter) CompareAndDelete(group string, key string, version uint64) error {
+	resp, err := g.client.CompareAndDelete(context.Background(), &geecache.CompareAndDeleteRequest{
+		Group:   group,
+		Key:     key,
+		Version: int64(version),
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.Success:
+		return nil
+	case resp.Conflict:
+		return ErrVersionMismatch
+	default:
+		return ErrKeyNotFound
+	}
+}
+
+// LeaseGet 在 key 所属的远程节点上读取或申请填充租约
+func (g *kitexGetter) LeaseGet(group string, key string) (LeaseResult, error) {
+	resp, err := g.client.LeaseGet(context.Background(), &geecache.Request{
+		Group: group,
+		Key:   key,
+	})
+	if err != nil {
+		return LeaseResult{}, err
+	}
+	return LeaseResult{
+		Value: ByteView{b: resp.Value},
+		Hit:   resp.Hit,
+		Token: uint64(resp.Token),
+		Retry: resp.Retry,
+	}, nil
+}
+
+// LeaseSet 在 key 所属的远程节点上兑现填充租约
+func (g *kitexGetter) LeaseSet(group string, key string, value []byte, token uint64, ttl int64) error {
+	resp, err := g.client.LeaseSet(context.Background(), &geecache.LeaseSetRequest{
+		Group: group,
+		Key:   key,
+		Value: value,
+		Token: int64(token),
+		Ttl:   ttl,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return ErrLeaseInvalid
+	}
+	return nil
+}
+
+// InvalidateTag 让远程节点删除其本地携带 tag 的条目（不再继续广播）
+func (g *kitexGetter) InvalidateTag(group string, tag string) (int64, error) {
+	resp, err := g.client.InvalidateTag(context.Background(), &geecache.InvalidateTagRequest{
+		Group: group,
+		Tag:   tag,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Removed, nil
+}
+
+// BumpGeneration 让远程节点递增其本地命名空间的代数（不再继续广播）
+func (g *kitexGetter) BumpGeneration(group string, namespace string) (uint64, error) {
+	resp, err := g.client.BumpGeneration(context.Background(), &geecache.BumpGenerationRequest{
+		Group:     group,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(resp.Generation), nil
+}
+
+// HSet 在 key 所属的远程节点上设置哈希字段
+func (g *kitexGetter) HSet(group string, key string, fields map[string][]byte, ttl int64) (int, error) {
+	resp, err := g.client.HSet(context.Background(), &geecache.HSetRequest{
+		Group:  group,
+		Key:    key,
+		Fields: fields,
+		Ttl:    ttl,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Added), nil
+}
+
+// HGet 在 key 所属的远程节点上读取哈希字段
+func (g *kitexGetter) HGet(group string, key string, field string) ([]byte, error) {
+	resp, err := g.client.HGet(context.Background(), &geecache.HGetRequest{
+		Group: group,
+		Key:   key,
+		Field: field,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Found {
+		return nil, ErrKeyNotFound
+	}
+	return resp.Value, nil
+}
+
+// HDel 在 key 所属的远程节点上删除哈希字段
+func (g *kitexGetter) HDel(group string, key string, fields []string) (int, error) {
+	resp, err := g.client.HDel(context.Background(), &geecache.HDelRequest{
+		Group:  group,
+		Key:    key,
+		Fields: fields,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Removed), nil
+}
+
+// HGetAll 在 key 所属的远程节点上读取哈希的所有字段
+func (g *kitexGetter) HGetAll(group string, key string) (map[string][]byte, error) {
+	resp, err := g.client.HGetAll(context.Background(), &geecache.Request{
+		Group: group,
+		Key:   key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Found {
+		return nil, ErrKeyNotFound
+	}
+	return resp.Fields, nil
+}
+
+// HIncrBy 在 key 所属的远程节点上原子地增减哈希字段
+func (g *kitexGetter) HIncrBy(group string, key string, field string, delta, ttl int64) (int64, error) {
+	resp, err := g.client.HIncrBy(context.Background(), &geecache.HIncrByRequest{
+		Group: group,
+		Key:   key,
+		Field: field,
+		Delta: delta,
+		Ttl:   ttl,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Value, nil
+}
+
+// SetChunks 将分块写入远程节点
+func (g *kitexGetter) SetChunks(group string, chunks map[string][]byte, ttl int64) error {
+	_, err := g.client.SetChunks(context.Background(), &geecache.SetMultiRequest{
+		Group:  group,
+		Values: chunks,
+		Ttl:    ttl,
+	})
+	return err
+}
+
+// GetMulti 批量获取远程节点已缓存的值
+func (g *kitexGetter) GetMulti(group string, keys []string) (map[string][]byte, error) {
+	resp, err := g.client.GetMulti(context.Background(), &geecache.GetMultiRequest{
+		Group: group,
+		Keys:  keys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Values, nil
+}
+
+// Dump 分页读取远程节点已缓存的值
+func (g *kitexGetter) Dump(group string, cursor string, count int, owner string) ([]DumpEntry, string, error) {
+	resp, err := g.client.Dump(context.Background(), &geecache.DumpRequest{
+		Group:  group,
+		Cursor: cursor,
+		Count:  int32(count),
+		Owner:  owner,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]DumpEntry, len(resp.Entries))
+	for i, e := range resp.Entries {
+		entries[i] = DumpEntry{Key: e.Key, Value: e.Value, TTL: e.Ttl}
+	}
+	return entries, resp.Cursor, nil
+}
+
+var (
+	_ PeerGetter           = (*kitexGetter)(nil)
+	_ PeerIncrementer      = (*kitexGetter)(nil)
+	_ PeerWriter           = (*kitexGetter)(nil)
+	_ PeerLeaser           = (*kitexGetter)(nil)
+	_ PeerTagInvalidator   = (*kitexGetter)(nil)
+	_ PeerGenerationBumper = (*kitexGetter)(nil)
+	_ PeerHasher           = (*kitexGetter)(nil)
+	_ PeerEncodedGetter    = (*kitexGetter)(nil)
+	_ PeerChunkStore       = (*kitexGetter)(nil)
+	_ PeerDumper           = (*kitexGetter)(nil)
+)
+
+// subscriptionIdleTimeout 远程订阅会话的空闲回收时间，需大于 maxWatchTimeout
+const subscriptionIdleTimeout = 2 * time.Minute
+
+// ErrNotOwner 表示请求的频道按一致性哈希不属于本节点
+var ErrNotOwner = errors.New("not the owner of the channel")
 
 // KitexServer 实现 GroupCache 服务
 type KitexServer struct {
+	// 本节点的发布订阅代理，频道按一致性哈希由调用方路由到所属节点
+	broker *pubsub.Broker
+	poller *pubsub.Poller
+	// 用于确认频道属于本节点，为 nil 时所有频道都由本节点处理
+	peers PeerPicker
 }
 
-// NewKitexServer 创建 Kitex 服务端
-func NewKitexServer() *KitexServer {
-	return &KitexServer{}
+// NewKitexServer 创建 Kitex 服务端，peers 用于拒绝不属于本节点的频道，可以为 nil
+func NewKitexServer(peers PeerPicker) *KitexServer {
+	broker := pubsub.NewBroker()
+	return &KitexServer{
+		broker: broker,
+		poller: pubsub.NewPoller(broker, subscriptionIdleTimeout),
+		peers:  peers,
+	}
+}
+
+// checkChannelOwner 确认 channel 属于本节点。发布者与订阅者必须落在同一节点，
+// 绕过网关直接访问其他节点的请求会被拒绝，而不是投递给收不到消息的订阅者
+func (s *KitexServer) checkChannelOwner(channel string) error {
+	if channel == "" {
+		return fmt.Errorf("channel is required")
+	}
+	if s.peers != nil {
+		if _, ok := s.peers.PickPeer(channel); ok {
+			return ErrNotOwner
+		}
+	}
+	return nil
 }
 
 // Get 实现 GroupCache 的 Get 方法
@@ -116,12 +448,22 @@ func (s *KitexServer) Get(ctx context.Context, req *geecache.Request) (resp *gee
 		return nil, fmt.Errorf("group not found: %s", req.Group)
 	}
 
+	// 调用方接受压缩形式时直接发送缓存中压缩后的数据。
+	// 响应只在序列化时被读取，两种情况下都直接引用缓存中的数据而不复制
+	if req.AcceptCompression != "" {
+		view, err := group.getForTransfer(req.Key, req.AcceptCompression)
+		if err != nil {
+			return nil, err
+		}
+		return &geecache.Response{Value: view.UnsafeBytes(), Compressed: view.enc == encCompressed}, nil
+	}
+
 	view, err := group.Get(req.Key)
 	if err != nil {
 		return nil, err
 	}
 
-	return &geecache.Response{Value: view.ByteSlice()}, nil
+	return &geecache.Response{Value: view.UnsafeBytes()}, nil
 }
 
 // Set 实现 GroupCache 的 Set 方法
@@ -131,7 +473,7 @@ func (s *KitexServer) Set(ctx context.Context, req *geecache.SetRequest) (resp *
 		return nil, fmt.Errorf("group not found: %s", req.Group)
 	}
 
-	err = group.Set(req.Key, req.Value, req.Ttl)
+	err = group.Set(req.Key, req.Value, req.Ttl, req.Tags...)
 	if err != nil {
 		return &geecache.SetResponse{Success: false}, err
 	}
@@ -139,6 +481,42 @@ func (s *KitexServer) Set(ctx context.Context, req *geecache.SetRequest) (resp *
 	return &geecache.SetResponse{Success: true}, nil
 }
 
+// Add 实现 GroupCache 的 Add 方法，key 已存在时返回 Success=false
+func (s *KitexServer) Add(ctx context.Context, req *geecache.SetRequest) (resp *geecache.SetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	version, err := group.Add(req.Key, req.Value, req.Ttl)
+	switch err {
+	case nil:
+		return &geecache.SetResponse{Success: true, Version: int64(version)}, nil
+	case ErrKeyExists:
+		return &geecache.SetResponse{Success: false}, nil
+	default:
+		return &geecache.SetResponse{Success: false}, err
+	}
+}
+
+// Replace 实现 GroupCache 的 Replace 方法，key 不存在时返回 Success=false
+func (s *KitexServer) Replace(ctx context.Context, req *geecache.SetRequest) (resp *geecache.SetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	version, err := group.Replace(req.Key, req.Value, req.Ttl)
+	switch err {
+	case nil:
+		return &geecache.SetResponse{Success: true, Version: int64(version)}, nil
+	case ErrKeyNotFound:
+		return &geecache.SetResponse{Success: false}, nil
+	default:
+		return &geecache.SetResponse{Success: false}, err
+	}
+}
+
 // Delete 实现 GroupCache 的 Delete 方法
 func (s *KitexServer) Delete(ctx context.Context, req *geecache.DeleteRequest) (resp *geecache.DeleteResponse, err error) {
 	group := GetGroup(req.Group)
@@ -178,10 +556,12 @@ func (s *KitexServer) Stats(ctx context.Context, req *geecache.StatsRequest) (re
 
 	stats := group.Stats()
 	return &geecache.StatsResponse{
-		ItemCount:  int64(stats.ItemCount),
-		HitCount:   int64(stats.HitCount),
-		MissCount:  int64(stats.MissCount),
-		TotalCount: int64(stats.TotalCount),
+		ItemCount:         int64(stats.ItemCount),
+		HitCount:          int64(stats.HitCount),
+		MissCount:         int64(stats.MissCount),
+		TotalCount:        int64(stats.TotalCount),
+		IntegrityFailures: stats.IntegrityFailures,
+		Ready:             group.IsReady(),
 	}, nil
 }
 
@@ -207,7 +587,7 @@ func (s *KitexServer) SetMulti(ctx context.Context, req *geecache.SetMultiReques
 		return nil, fmt.Errorf("group not found: %s", req.Group)
 	}
 
-	err = group.SetMulti(req.Values, req.Ttl)
+	err = group.SetMulti(req.Values, req.Ttl, req.Tags...)
 	if err != nil {
 		return &geecache.SetMultiResponse{Success: false}, err
 	}
@@ -215,8 +595,394 @@ func (s *KitexServer) SetMulti(ctx context.Context, req *geecache.SetMultiReques
 	return &geecache.SetMultiResponse{Success: true}, nil
 }
 
-// StartKitexServer 启动 Kitex 服务
-func StartKitexServer(addr string) error {
+// SetChunks 实现 GroupCache 的 SetChunks 方法，写入其他节点分块存储的值在本节点的分块
+func (s *KitexServer) SetChunks(ctx context.Context, req *geecache.SetMultiRequest) (resp *geecache.SetMultiResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	group.setChunksLocally(req.Values, req.Ttl)
+	return &geecache.SetMultiResponse{Success: true}, nil
+}
+
+// GetWithVersion 实现 GroupCache 的 GetWithVersion 方法
+func (s *KitexServer) GetWithVersion(ctx context.Context, req *geecache.Request) (resp *geecache.GetWithVersionResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	view, version, err := group.GetWithVersion(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.GetWithVersionResponse{Value: view.UnsafeBytes(), Version: int64(version)}, nil
+}
+
+// CompareAndSet 实现 GroupCache 的 CompareAndSet 方法
+// 版本冲突通过 Conflict 字段返回，key 不存在时 Success 与 Conflict 均为 false
+func (s *KitexServer) CompareAndSet(ctx context.Context, req *geecache.CompareAndSetRequest) (resp *geecache.CompareAndSetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	version, err := group.CompareAndSet(req.Key, req.Value, uint64(req.Version), req.Ttl)
+	switch err {
+	case nil:
+		return &geecache.CompareAndSetResponse{Success: true, Version: int64(version)}, nil
+	case ErrVersionMismatch:
+		return &geecache.CompareAndSetResponse{Success: false, Version: int64(version), Conflict: true}, nil
+	case ErrKeyNotFound:
+		return &geecache.CompareAndSetResponse{Success: false}, nil
+	default:
+		return &geecache.CompareAndSetResponse{Success: false}, err
+	}
+}
+
+// CompareAndDelete 实现 GroupCache 的 CompareAndDelete 方法
+func (s *KitexServer) CompareAndDelete(ctx context.Context, req *geecache.CompareAndDeleteRequest) (resp *geecache.CompareAndDeleteResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	err = group.CompareAndDelete(req.Key, uint64(req.Version))
+	switch err {
+	case nil:
+		return &geecache.CompareAndDeleteResponse{Success: true}, nil
+	case ErrVersionMismatch:
+		return &geecache.CompareAndDeleteResponse{Success: false, Conflict: true}, nil
+	case ErrKeyNotFound:
+		return &geecache.CompareAndDeleteResponse{Success: false}, nil
+	default:
+		return &geecache.CompareAndDeleteResponse{Success: false}, err
+	}
+}
+
+// Incr 实现 GroupCache 的 Incr 方法
+// 本节点即为 key 的所属节点，直接在本地执行，避免环不一致时请求来回转发
+func (s *KitexServer) Incr(ctx context.Context, req *geecache.IncrRequest) (resp *geecache.IncrResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	value, err := group.incrLocally(req.Key, req.Delta, req.Initial, req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.IncrResponse{Value: value}, nil
+}
+
+// LeaseGet 实现 GroupCache 的 LeaseGet 方法
+func (s *KitexServer) LeaseGet(ctx context.Context, req *geecache.Request) (resp *geecache.LeaseGetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	res, err := group.leaseGetLocally(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.LeaseGetResponse{
+		Value: res.Value.UnsafeBytes(),
+		Hit:   res.Hit,
+		Token: int64(res.Token),
+		Retry: res.Retry,
+	}, nil
+}
+
+// LeaseSet 实现 GroupCache 的 LeaseSet 方法，租约无效时返回 Success=false
+func (s *KitexServer) LeaseSet(ctx context.Context, req *geecache.LeaseSetRequest) (resp *geecache.SetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	err = group.leaseSetLocally(req.Key, req.Value, uint64(req.Token), req.Ttl)
+	switch err {
+	case nil:
+		return &geecache.SetResponse{Success: true}, nil
+	case ErrLeaseInvalid:
+		return &geecache.SetResponse{Success: false}, nil
+	default:
+		return &geecache.SetResponse{Success: false}, err
+	}
+}
+
+// Scan 实现 GroupCache 的 Scan 方法，遍历本节点缓存中的键
+func (s *KitexServer) Scan(ctx context.Context, req *geecache.ScanRequest) (resp *geecache.ScanResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	keys, cursor, err := group.Scan(req.Cursor, req.Pattern, int(req.Count))
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.ScanResponse{Keys: keys, Cursor: cursor}, nil
+}
+
+// Dump 实现 GroupCache 的 Dump 方法，分页返回本节点缓存中的值，供新节点预热
+func (s *KitexServer) Dump(ctx context.Context, req *geecache.DumpRequest) (resp *geecache.DumpResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	entries, cursor, err := group.Dump(req.Cursor, int(req.Count), req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &geecache.DumpResponse{Entries: make([]*geecache.DumpEntry, len(entries)), Cursor: cursor}
+	for i, e := range entries {
+		resp.Entries[i] = &geecache.DumpEntry{Key: e.Key, Value: e.Value, Ttl: e.TTL}
+	}
+	return resp, nil
+}
+
+// HotKeys 实现 GroupCache 的 HotKeys 方法，返回本节点统计的热点 key
+func (s *KitexServer) HotKeys(ctx context.Context, req *geecache.HotKeysRequest) (resp *geecache.HotKeysResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	keys := group.HotKeys(int(req.N))
+	resp = &geecache.HotKeysResponse{Keys: make([]*geecache.HotKey, len(keys)), Enabled: group.HotKeysEnabled()}
+	for i, k := range keys {
+		resp.Keys[i] = &geecache.HotKey{Key: k.Key, Count: k.Count, Qps: k.QPS}
+	}
+	return resp, nil
+}
+
+// InvalidateTag 实现 GroupCache 的 InvalidateTag 方法
+// Fanout 为 true 时由本节点向所有其他节点广播，用于客户端只连接任意一个节点的场景
+func (s *KitexServer) InvalidateTag(ctx context.Context, req *geecache.InvalidateTagRequest) (resp *geecache.InvalidateTagResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	if !req.Fanout {
+		return &geecache.InvalidateTagResponse{Removed: int64(group.InvalidateTag(req.Tag))}, nil
+	}
+
+	removed, err := group.InvalidateTagCluster(req.Tag)
+	if err != nil {
+		return &geecache.InvalidateTagResponse{Removed: int64(removed)}, err
+	}
+	return &geecache.InvalidateTagResponse{Removed: int64(removed)}, nil
+}
+
+// BumpGeneration 实现 GroupCache 的 BumpGeneration 方法
+// Fanout 为 true 时由本节点向所有其他节点广播
+func (s *KitexServer) BumpGeneration(ctx context.Context, req *geecache.BumpGenerationRequest) (resp *geecache.BumpGenerationResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	if !req.Fanout {
+		return &geecache.BumpGenerationResponse{Generation: int64(group.BumpGeneration(req.Namespace))}, nil
+	}
+
+	generation, err := group.BumpGenerationCluster(req.Namespace)
+	return &geecache.BumpGenerationResponse{Generation: int64(generation)}, err
+}
+
+// 长轮询 Watch 的默认与最大等待时间
+const (
+	defaultWatchTimeout = 30 * time.Second
+	maxWatchTimeout     = 60 * time.Second
+)
+
+// Watch 实现 GroupCache 的 Watch 方法，以长轮询方式返回本节点上 key 的变更。
+// Key 以 * 结尾时按前缀匹配；Since 为上一次响应的 Next，首次调用传 0
+func (s *KitexServer) Watch(ctx context.Context, req *geecache.WatchRequest) (resp *geecache.WatchResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	timeout := time.Duration(req.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultWatchTimeout
+	}
+	if timeout > maxWatchTimeout {
+		timeout = maxWatchTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	events, next, truncated := group.WaitChanges(ctx, req.Key, uint64(req.Since))
+	resp = &geecache.WatchResponse{
+		Events:    make([]*geecache.WatchEvent, 0, len(events)),
+		Next:      int64(next),
+		Truncated: truncated,
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &geecache.WatchEvent{
+			Seq:   int64(e.Seq),
+			Key:   e.Key,
+			Type:  e.Type.String(),
+			Value: e.Value.ByteSlice(),
+		})
+	}
+	return resp, nil
+}
+
+// Publish 实现 GroupCache 的 Publish 方法，将消息投递给本节点上该频道的订阅者，
+// 频道不属于本节点时返回 ErrNotOwner
+func (s *KitexServer) Publish(ctx context.Context, req *geecache.PublishRequest) (resp *geecache.PublishResponse, err error) {
+	if err := s.checkChannelOwner(req.Channel); err != nil {
+		return nil, err
+	}
+	return &geecache.PublishResponse{Receivers: int32(s.broker.Publish(req.Channel, req.Payload))}, nil
+}
+
+// Subscribe 实现 GroupCache 的 Subscribe 方法，以长轮询方式拉取频道消息。
+// Subscription 为空时创建新会话；会话已被回收（如节点重启）时同样创建新会话，
+// 调用方可通过响应中 Subscription 的变化得知期间的消息已丢失。频道不属于本节点时返回 ErrNotOwner
+func (s *KitexServer) Subscribe(ctx context.Context, req *geecache.SubscribeRequest) (resp *geecache.SubscribeResponse, err error) {
+	if err := s.checkChannelOwner(req.Channel); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(req.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultWatchTimeout
+	}
+	if timeout > maxWatchTimeout {
+		timeout = maxWatchTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id := req.Subscription
+	msgs, dropped, err := s.poller.Poll(ctx, id, int(req.MaxMessages))
+	if err == pubsub.ErrUnknownSubscription {
+		if id, err = s.poller.Open(req.Channel, pubsub.DefaultBuffer); err != nil {
+			return nil, err
+		}
+		msgs, dropped, err = s.poller.Poll(ctx, id, int(req.MaxMessages))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &geecache.SubscribeResponse{
+		Subscription: id,
+		Messages:     make([][]byte, 0, len(msgs)),
+		Dropped:      int64(dropped),
+	}
+	for _, msg := range msgs {
+		resp.Messages = append(resp.Messages, msg.Payload)
+	}
+	return resp, nil
+}
+
+// Unsubscribe 实现 GroupCache 的 Unsubscribe 方法，关闭远程订阅会话
+func (s *KitexServer) Unsubscribe(ctx context.Context, req *geecache.UnsubscribeRequest) (resp *geecache.UnsubscribeResponse, err error) {
+	return &geecache.UnsubscribeResponse{Success: s.poller.Close(req.Subscription)}, nil
+}
+
+// HSet 实现 GroupCache 的 HSet 方法
+// 与 Incr 一样，本节点即为 key 的所属节点，直接在本地执行
+func (s *KitexServer) HSet(ctx context.Context, req *geecache.HSetRequest) (resp *geecache.HSetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+	if len(req.Fields) == 0 {
+		return nil, fmt.Errorf("fields are required")
+	}
+
+	added, err := group.hSetLocally(req.Key, req.Fields, req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.HSetResponse{Added: int32(added)}, nil
+}
+
+// HGet 实现 GroupCache 的 HGet 方法，key 或字段不存在时 Found 为 false
+func (s *KitexServer) HGet(ctx context.Context, req *geecache.HGetRequest) (resp *geecache.HGetResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	view, err := group.hGetLocally(req.Key, req.Field)
+	switch err {
+	case nil:
+		return &geecache.HGetResponse{Value: view.UnsafeBytes(), Found: true}, nil
+	case ErrKeyNotFound:
+		return &geecache.HGetResponse{Found: false}, nil
+	default:
+		return nil, err
+	}
+}
+
+// HDel 实现 GroupCache 的 HDel 方法
+func (s *KitexServer) HDel(ctx context.Context, req *geecache.HDelRequest) (resp *geecache.HDelResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	removed, err := group.hDelLocally(req.Key, req.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.HDelResponse{Removed: int32(removed)}, nil
+}
+
+// HGetAll 实现 GroupCache 的 HGetAll 方法，key 不存在时 Found 为 false
+func (s *KitexServer) HGetAll(ctx context.Context, req *geecache.Request) (resp *geecache.HGetAllResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	fields, err := group.hGetAllLocally(req.Key)
+	switch err {
+	case nil:
+		return &geecache.HGetAllResponse{Fields: fields, Found: true}, nil
+	case ErrKeyNotFound:
+		return &geecache.HGetAllResponse{Found: false}, nil
+	default:
+		return nil, err
+	}
+}
+
+// HIncrBy 实现 GroupCache 的 HIncrBy 方法
+func (s *KitexServer) HIncrBy(ctx context.Context, req *geecache.HIncrByRequest) (resp *geecache.IncrResponse, err error) {
+	group := GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("group not found: %s", req.Group)
+	}
+
+	value, err := group.hIncrByLocally(req.Key, req.Field, req.Delta, req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geecache.IncrResponse{Value: value}, nil
+}
+
+// StartKitexServer 启动 Kitex 服务，peers 为本节点使用的 PeerPicker，用于确认频道的所属节点
+func StartKitexServer(addr string, peers PeerPicker) error {
 	// 从地址中解析端口
 	portStr := strings.TrimPrefix(addr, "http://")
 	portStr = strings.Split(portStr, ":")[1]
@@ -228,7 +994,7 @@ func StartKitexServer(addr string) error {
 	s := server.NewServer(
 		server.WithServiceAddr(&net.TCPAddr{Port: port}),
 	)
-	service := NewKitexServer()
+	service := NewKitexServer(peers)
 	if err := groupcache.RegisterService(s, service); err != nil {
 		return err
 	}
@@ -3,10 +3,12 @@ namespace go mygocache
 struct Request {
     1: string group
     2: string key
+    3: string accept_compression
 }
 
 struct Response {
     1: binary value
+    2: bool compressed
 }
 
 struct SetRequest {
@@ -14,10 +16,12 @@ struct SetRequest {
     2: string key
     3: binary value
     4: i64 ttl
+    5: list<string> tags
 }
 
 struct SetResponse {
     1: bool success
+    2: i64 version
 }
 
 struct DeleteRequest {
@@ -46,6 +50,8 @@ struct StatsResponse {
     2: i64 hitCount
     3: i64 missCount
     4: i64 totalCount
+    5: i64 integrityFailures
+    6: bool ready
 }
 
 struct GetMultiRequest {
@@ -61,12 +67,231 @@ struct SetMultiRequest {
     1: string group
     2: map<string, binary> values
     3: i64 ttl
+    4: list<string> tags
 }
 
 struct SetMultiResponse {
     1: bool success
 }
 
+struct GetWithVersionResponse {
+    1: binary value
+    2: i64 version
+}
+
+struct CompareAndSetRequest {
+    1: string group
+    2: string key
+    3: binary value
+    4: i64 version
+    5: i64 ttl
+}
+
+struct CompareAndSetResponse {
+    1: bool success
+    2: i64 version
+    3: bool conflict
+}
+
+struct CompareAndDeleteRequest {
+    1: string group
+    2: string key
+    3: i64 version
+}
+
+struct CompareAndDeleteResponse {
+    1: bool success
+    2: bool conflict
+}
+
+struct IncrRequest {
+    1: string group
+    2: string key
+    3: i64 delta
+    4: i64 initial
+    5: i64 ttl
+}
+
+struct IncrResponse {
+    1: i64 value
+}
+
+struct LeaseGetResponse {
+    1: binary value
+    2: bool hit
+    3: i64 token
+    4: bool retry
+}
+
+struct LeaseSetRequest {
+    1: string group
+    2: string key
+    3: binary value
+    4: i64 token
+    5: i64 ttl
+}
+
+struct ScanRequest {
+    1: string group
+    2: string cursor
+    3: string pattern
+    4: i32 count
+}
+
+struct ScanResponse {
+    1: list<string> keys
+    2: string cursor
+}
+
+struct DumpRequest {
+    1: string group
+    2: string cursor
+    3: i32 count
+    4: string owner
+}
+
+struct DumpEntry {
+    1: string key
+    2: binary value
+    3: i64 ttl
+}
+
+struct DumpResponse {
+    1: list<DumpEntry> entries
+    2: string cursor
+}
+
+struct HotKeysRequest {
+    1: string group
+    2: i32 n
+}
+
+struct HotKey {
+    1: string key
+    2: i64 count
+    3: double qps
+}
+
+struct HotKeysResponse {
+    1: list<HotKey> keys
+    2: bool enabled
+}
+
+struct InvalidateTagRequest {
+    1: string group
+    2: string tag
+    3: bool fanout
+}
+
+struct InvalidateTagResponse {
+    1: i64 removed
+}
+
+struct BumpGenerationRequest {
+    1: string group
+    2: string namespace
+    3: bool fanout
+}
+
+struct BumpGenerationResponse {
+    1: i64 generation
+}
+
+struct WatchRequest {
+    1: string group
+    2: string key
+    3: i64 since
+    4: i32 timeout_ms
+}
+
+struct WatchEvent {
+    1: i64 seq
+    2: string key
+    3: string type
+    4: binary value
+}
+
+struct WatchResponse {
+    1: list<WatchEvent> events
+    2: i64 next
+    3: bool truncated
+}
+
+struct PublishRequest {
+    1: string channel
+    2: binary payload
+}
+
+struct PublishResponse {
+    1: i32 receivers
+}
+
+struct SubscribeRequest {
+    1: string channel
+    2: string subscription
+    3: i32 timeout_ms
+    4: i32 max_messages
+}
+
+struct SubscribeResponse {
+    1: string subscription
+    2: list<binary> messages
+    3: i64 dropped
+}
+
+struct UnsubscribeRequest {
+    1: string subscription
+}
+
+struct UnsubscribeResponse {
+    1: bool success
+}
+
+struct HSetRequest {
+    1: string group
+    2: string key
+    3: map<string, binary> fields
+    4: i64 ttl
+}
+
+struct HSetResponse {
+    1: i32 added
+}
+
+struct HGetRequest {
+    1: string group
+    2: string key
+    3: string field
+}
+
+struct HGetResponse {
+    1: binary value
+    2: bool found
+}
+
+struct HDelRequest {
+    1: string group
+    2: string key
+    3: list<string> fields
+}
+
+struct HDelResponse {
+    1: i32 removed
+}
+
+struct HGetAllResponse {
+    1: map<string, binary> fields
+    2: bool found
+}
+
+struct HIncrByRequest {
+    1: string group
+    2: string key
+    3: string field
+    4: i64 delta
+    5: i64 ttl
+}
+
 service GroupCache {
     Response Get(1: Request req)
     SetResponse Set(1: SetRequest req)
@@ -75,4 +300,27 @@ service GroupCache {
     StatsResponse Stats(1: StatsRequest req)
     GetMultiResponse GetMulti(1: GetMultiRequest req)
     SetMultiResponse SetMulti(1: SetMultiRequest req)
+    SetMultiResponse SetChunks(1: SetMultiRequest req)
+    GetWithVersionResponse GetWithVersion(1: Request req)
+    CompareAndSetResponse CompareAndSet(1: CompareAndSetRequest req)
+    CompareAndDeleteResponse CompareAndDelete(1: CompareAndDeleteRequest req)
+    IncrResponse Incr(1: IncrRequest req)
+    SetResponse Add(1: SetRequest req)
+    SetResponse Replace(1: SetRequest req)
+    LeaseGetResponse LeaseGet(1: Request req)
+    SetResponse LeaseSet(1: LeaseSetRequest req)
+    ScanResponse Scan(1: ScanRequest req)
+    DumpResponse Dump(1: DumpRequest req)
+    HotKeysResponse HotKeys(1: HotKeysRequest req)
+    InvalidateTagResponse InvalidateTag(1: InvalidateTagRequest req)
+    BumpGenerationResponse BumpGeneration(1: BumpGenerationRequest req)
+    WatchResponse Watch(1: WatchRequest req)
+    PublishResponse Publish(1: PublishRequest req)
+    SubscribeResponse Subscribe(1: SubscribeRequest req)
+    UnsubscribeResponse Unsubscribe(1: UnsubscribeRequest req)
+    HSetResponse HSet(1: HSetRequest req)
+    HGetResponse HGet(1: HGetRequest req)
+    HDelResponse HDel(1: HDelRequest req)
+    HGetAllResponse HGetAll(1: Request req)
+    IncrResponse HIncrBy(1: HIncrByRequest req)
 }
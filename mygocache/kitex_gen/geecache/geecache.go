@@ -8,8 +8,9 @@ import (
 )
 
 type Request struct {
-	Group string `thrift:"group,1" frugal:"1,default,string" json:"group"`
-	Key   string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Group             string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key               string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	AcceptCompression string `thrift:"accept_compression,3" frugal:"3,default,string" json:"accept_compression"`
 }
 
 func NewRequest() *Request {
@@ -26,12 +27,19 @@ func (p *Request) GetGroup() (v string) {
 func (p *Request) GetKey() (v string) {
 	return p.Key
 }
+
+func (p *Request) GetAcceptCompression() (v string) {
+	return p.AcceptCompression
+}
 func (p *Request) SetGroup(val string) {
 	p.Group = val
 }
 func (p *Request) SetKey(val string) {
 	p.Key = val
 }
+func (p *Request) SetAcceptCompression(val string) {
+	p.AcceptCompression = val
+}
 
 func (p *Request) String() string {
 	if p == nil {
@@ -43,10 +51,12 @@ func (p *Request) String() string {
 var fieldIDToName_Request = map[int16]string{
 	1: "group",
 	2: "key",
+	3: "accept_compression",
 }
 
 type Response struct {
-	Value []byte `thrift:"value,1" frugal:"1,default,binary" json:"value"`
+	Value      []byte `thrift:"value,1" frugal:"1,default,binary" json:"value"`
+	Compressed bool   `thrift:"compressed,2" frugal:"2,default,bool" json:"compressed"`
 }
 
 func NewResponse() *Response {
@@ -59,9 +69,16 @@ func (p *Response) InitDefault() {
 func (p *Response) GetValue() (v []byte) {
 	return p.Value
 }
+
+func (p *Response) GetCompressed() (v bool) {
+	return p.Compressed
+}
 func (p *Response) SetValue(val []byte) {
 	p.Value = val
 }
+func (p *Response) SetCompressed(val bool) {
+	p.Compressed = val
+}
 
 func (p *Response) String() string {
 	if p == nil {
@@ -72,13 +89,15 @@ func (p *Response) String() string {
 
 var fieldIDToName_Response = map[int16]string{
 	1: "value",
+	2: "compressed",
 }
 
 type SetRequest struct {
-	Group string `thrift:"group,1" frugal:"1,default,string" json:"group"`
-	Key   string `thrift:"key,2" frugal:"2,default,string" json:"key"`
-	Value []byte `thrift:"value,3" frugal:"3,default,binary" json:"value"`
-	Ttl   int64  `thrift:"ttl,4" frugal:"4,default,i64" json:"ttl"`
+	Group string   `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key   string   `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Value []byte   `thrift:"value,3" frugal:"3,default,binary" json:"value"`
+	Ttl   int64    `thrift:"ttl,4" frugal:"4,default,i64" json:"ttl"`
+	Tags  []string `thrift:"tags,5" frugal:"5,default,list<string>" json:"tags"`
 }
 
 func NewSetRequest() *SetRequest {
@@ -103,6 +122,10 @@ func (p *SetRequest) GetValue() (v []byte) {
 func (p *SetRequest) GetTtl() (v int64) {
 	return p.Ttl
 }
+
+func (p *SetRequest) GetTags() (v []string) {
+	return p.Tags
+}
 func (p *SetRequest) SetGroup(val string) {
 	p.Group = val
 }
@@ -115,6 +138,9 @@ func (p *SetRequest) SetValue(val []byte) {
 func (p *SetRequest) SetTtl(val int64) {
 	p.Ttl = val
 }
+func (p *SetRequest) SetTags(val []string) {
+	p.Tags = val
+}
 
 func (p *SetRequest) String() string {
 	if p == nil {
@@ -128,10 +154,12 @@ var fieldIDToName_SetRequest = map[int16]string{
 	2: "key",
 	3: "value",
 	4: "ttl",
+	5: "tags",
 }
 
 type SetResponse struct {
-	Success bool `thrift:"success,1" frugal:"1,default,bool" json:"success"`
+	Success bool  `thrift:"success,1" frugal:"1,default,bool" json:"success"`
+	Version int64 `thrift:"version,2" frugal:"2,default,i64" json:"version"`
 }
 
 func NewSetResponse() *SetResponse {
@@ -144,9 +172,16 @@ func (p *SetResponse) InitDefault() {
 func (p *SetResponse) GetSuccess() (v bool) {
 	return p.Success
 }
+
+func (p *SetResponse) GetVersion() (v int64) {
+	return p.Version
+}
 func (p *SetResponse) SetSuccess(val bool) {
 	p.Success = val
 }
+func (p *SetResponse) SetVersion(val int64) {
+	p.Version = val
+}
 
 func (p *SetResponse) String() string {
 	if p == nil {
@@ -157,6 +192,7 @@ func (p *SetResponse) String() string {
 
 var fieldIDToName_SetResponse = map[int16]string{
 	1: "success",
+	2: "version",
 }
 
 type DeleteRequest struct {
@@ -314,10 +350,12 @@ var fieldIDToName_StatsRequest = map[int16]string{
 }
 
 type StatsResponse struct {
-	ItemCount  int64 `thrift:"itemCount,1" frugal:"1,default,i64" json:"itemCount"`
-	HitCount   int64 `thrift:"hitCount,2" frugal:"2,default,i64" json:"hitCount"`
-	MissCount  int64 `thrift:"missCount,3" frugal:"3,default,i64" json:"missCount"`
-	TotalCount int64 `thrift:"totalCount,4" frugal:"4,default,i64" json:"totalCount"`
+	ItemCount         int64 `thrift:"itemCount,1" frugal:"1,default,i64" json:"itemCount"`
+	HitCount          int64 `thrift:"hitCount,2" frugal:"2,default,i64" json:"hitCount"`
+	MissCount         int64 `thrift:"missCount,3" frugal:"3,default,i64" json:"missCount"`
+	TotalCount        int64 `thrift:"totalCount,4" frugal:"4,default,i64" json:"totalCount"`
+	IntegrityFailures int64 `thrift:"integrityFailures,5" frugal:"5,default,i64" json:"integrityFailures"`
+	Ready             bool  `thrift:"ready,6" frugal:"6,default,bool" json:"ready"`
 }
 
 func NewStatsResponse() *StatsResponse {
@@ -342,6 +380,14 @@ func (p *StatsResponse) GetMissCount() (v int64) {
 func (p *StatsResponse) GetTotalCount() (v int64) {
 	return p.TotalCount
 }
+
+func (p *StatsResponse) GetIntegrityFailures() (v int64) {
+	return p.IntegrityFailures
+}
+
+func (p *StatsResponse) GetReady() (v bool) {
+	return p.Ready
+}
 func (p *StatsResponse) SetItemCount(val int64) {
 	p.ItemCount = val
 }
@@ -354,6 +400,12 @@ func (p *StatsResponse) SetMissCount(val int64) {
 func (p *StatsResponse) SetTotalCount(val int64) {
 	p.TotalCount = val
 }
+func (p *StatsResponse) SetIntegrityFailures(val int64) {
+	p.IntegrityFailures = val
+}
+func (p *StatsResponse) SetReady(val bool) {
+	p.Ready = val
+}
 
 func (p *StatsResponse) String() string {
 	if p == nil {
@@ -367,6 +419,8 @@ var fieldIDToName_StatsResponse = map[int16]string{
 	2: "hitCount",
 	3: "missCount",
 	4: "totalCount",
+	5: "integrityFailures",
+	6: "ready",
 }
 
 type GetMultiRequest struct {
@@ -440,6 +494,7 @@ type SetMultiRequest struct {
 	Group  string            `thrift:"group,1" frugal:"1,default,string" json:"group"`
 	Values map[string][]byte `thrift:"values,2" frugal:"2,default,map<string:binary>" json:"values"`
 	Ttl    int64             `thrift:"ttl,3" frugal:"3,default,i64" json:"ttl"`
+	Tags   []string          `thrift:"tags,4" frugal:"4,default,list<string>" json:"tags"`
 }
 
 func NewSetMultiRequest() *SetMultiRequest {
@@ -460,6 +515,10 @@ func (p *SetMultiRequest) GetValues() (v map[string][]byte) {
 func (p *SetMultiRequest) GetTtl() (v int64) {
 	return p.Ttl
 }
+
+func (p *SetMultiRequest) GetTags() (v []string) {
+	return p.Tags
+}
 func (p *SetMultiRequest) SetGroup(val string) {
 	p.Group = val
 }
@@ -469,6 +528,9 @@ func (p *SetMultiRequest) SetValues(val map[string][]byte) {
 func (p *SetMultiRequest) SetTtl(val int64) {
 	p.Ttl = val
 }
+func (p *SetMultiRequest) SetTags(val []string) {
+	p.Tags = val
+}
 
 func (p *SetMultiRequest) String() string {
 	if p == nil {
@@ -481,6 +543,7 @@ var fieldIDToName_SetMultiRequest = map[int16]string{
 	1: "group",
 	2: "values",
 	3: "ttl",
+	4: "tags",
 }
 
 type SetMultiResponse struct {
@@ -512,550 +575,4040 @@ var fieldIDToName_SetMultiResponse = map[int16]string{
 	1: "success",
 }
 
-type GroupCache interface {
-	Get(ctx context.Context, req *Request) (r *Response, err error)
+type GetWithVersionResponse struct {
+	Value   []byte `thrift:"value,1" frugal:"1,default,binary" json:"value"`
+	Version int64  `thrift:"version,2" frugal:"2,default,i64" json:"version"`
+}
 
-	Set(ctx context.Context, req *SetRequest) (r *SetResponse, err error)
+func NewGetWithVersionResponse() *GetWithVersionResponse {
+	return &GetWithVersionResponse{}
+}
 
-	Delete(ctx context.Context, req *DeleteRequest) (r *DeleteResponse, err error)
+func (p *GetWithVersionResponse) InitDefault() {
+}
 
-	Clear(ctx context.Context, req *ClearRequest) (r *ClearResponse, err error)
+func (p *GetWithVersionResponse) GetValue() (v []byte) {
+	return p.Value
+}
 
-	Stats(ctx context.Context, req *StatsRequest) (r *StatsResponse, err error)
+func (p *GetWithVersionResponse) GetVersion() (v int64) {
+	return p.Version
+}
+func (p *GetWithVersionResponse) SetValue(val []byte) {
+	p.Value = val
+}
+func (p *GetWithVersionResponse) SetVersion(val int64) {
+	p.Version = val
+}
 
-	GetMulti(ctx context.Context, req *GetMultiRequest) (r *GetMultiResponse, err error)
+func (p *GetWithVersionResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetWithVersionResponse(%+v)", *p)
+}
 
-	SetMulti(ctx context.Context, req *SetMultiRequest) (r *SetMultiResponse, err error)
+var fieldIDToName_GetWithVersionResponse = map[int16]string{
+	1: "value",
+	2: "version",
 }
 
-type GroupCacheGetArgs struct {
-	Req *Request `thrift:"req,1" frugal:"1,default,Request" json:"req"`
+type CompareAndSetRequest struct {
+	Group   string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key     string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Value   []byte `thrift:"value,3" frugal:"3,default,binary" json:"value"`
+	Version int64  `thrift:"version,4" frugal:"4,default,i64" json:"version"`
+	Ttl     int64  `thrift:"ttl,5" frugal:"5,default,i64" json:"ttl"`
 }
 
-func NewGroupCacheGetArgs() *GroupCacheGetArgs {
-	return &GroupCacheGetArgs{}
+func NewCompareAndSetRequest() *CompareAndSetRequest {
+	return &CompareAndSetRequest{}
 }
 
-func (p *GroupCacheGetArgs) InitDefault() {
+func (p *CompareAndSetRequest) InitDefault() {
 }
 
-var GroupCacheGetArgs_Req_DEFAULT *Request
+func (p *CompareAndSetRequest) GetGroup() (v string) {
+	return p.Group
+}
 
-func (p *GroupCacheGetArgs) GetReq() (v *Request) {
-	if !p.IsSetReq() {
-		return GroupCacheGetArgs_Req_DEFAULT
-	}
-	return p.Req
+func (p *CompareAndSetRequest) GetKey() (v string) {
+	return p.Key
 }
-func (p *GroupCacheGetArgs) SetReq(val *Request) {
-	p.Req = val
+
+func (p *CompareAndSetRequest) GetValue() (v []byte) {
+	return p.Value
 }
 
-func (p *GroupCacheGetArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *CompareAndSetRequest) GetVersion() (v int64) {
+	return p.Version
 }
 
-func (p *GroupCacheGetArgs) String() string {
+func (p *CompareAndSetRequest) GetTtl() (v int64) {
+	return p.Ttl
+}
+func (p *CompareAndSetRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *CompareAndSetRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *CompareAndSetRequest) SetValue(val []byte) {
+	p.Value = val
+}
+func (p *CompareAndSetRequest) SetVersion(val int64) {
+	p.Version = val
+}
+func (p *CompareAndSetRequest) SetTtl(val int64) {
+	p.Ttl = val
+}
+
+func (p *CompareAndSetRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheGetArgs(%+v)", *p)
+	return fmt.Sprintf("CompareAndSetRequest(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheGetArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_CompareAndSetRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "value",
+	4: "version",
+	5: "ttl",
 }
 
-type GroupCacheGetResult struct {
-	Success *Response `thrift:"success,0,optional" frugal:"0,optional,Response" json:"success,omitempty"`
+type CompareAndSetResponse struct {
+	Success  bool  `thrift:"success,1" frugal:"1,default,bool" json:"success"`
+	Version  int64 `thrift:"version,2" frugal:"2,default,i64" json:"version"`
+	Conflict bool  `thrift:"conflict,3" frugal:"3,default,bool" json:"conflict"`
 }
 
-func NewGroupCacheGetResult() *GroupCacheGetResult {
-	return &GroupCacheGetResult{}
+func NewCompareAndSetResponse() *CompareAndSetResponse {
+	return &CompareAndSetResponse{}
 }
 
-func (p *GroupCacheGetResult) InitDefault() {
+func (p *CompareAndSetResponse) InitDefault() {
 }
 
-var GroupCacheGetResult_Success_DEFAULT *Response
-
-func (p *GroupCacheGetResult) GetSuccess() (v *Response) {
-	if !p.IsSetSuccess() {
-		return GroupCacheGetResult_Success_DEFAULT
-	}
+func (p *CompareAndSetResponse) GetSuccess() (v bool) {
 	return p.Success
 }
-func (p *GroupCacheGetResult) SetSuccess(x interface{}) {
-	p.Success = x.(*Response)
+
+func (p *CompareAndSetResponse) GetVersion() (v int64) {
+	return p.Version
 }
 
-func (p *GroupCacheGetResult) IsSetSuccess() bool {
-	return p.Success != nil
+func (p *CompareAndSetResponse) GetConflict() (v bool) {
+	return p.Conflict
+}
+func (p *CompareAndSetResponse) SetSuccess(val bool) {
+	p.Success = val
+}
+func (p *CompareAndSetResponse) SetVersion(val int64) {
+	p.Version = val
+}
+func (p *CompareAndSetResponse) SetConflict(val bool) {
+	p.Conflict = val
 }
 
-func (p *GroupCacheGetResult) String() string {
+func (p *CompareAndSetResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheGetResult(%+v)", *p)
+	return fmt.Sprintf("CompareAndSetResponse(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheGetResult = map[int16]string{
-	0: "success",
+var fieldIDToName_CompareAndSetResponse = map[int16]string{
+	1: "success",
+	2: "version",
+	3: "conflict",
 }
 
-type GroupCacheSetArgs struct {
-	Req *SetRequest `thrift:"req,1" frugal:"1,default,SetRequest" json:"req"`
+type CompareAndDeleteRequest struct {
+	Group   string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key     string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Version int64  `thrift:"version,3" frugal:"3,default,i64" json:"version"`
 }
 
-func NewGroupCacheSetArgs() *GroupCacheSetArgs {
-	return &GroupCacheSetArgs{}
+func NewCompareAndDeleteRequest() *CompareAndDeleteRequest {
+	return &CompareAndDeleteRequest{}
 }
 
-func (p *GroupCacheSetArgs) InitDefault() {
+func (p *CompareAndDeleteRequest) InitDefault() {
 }
 
-var GroupCacheSetArgs_Req_DEFAULT *SetRequest
-
-func (p *GroupCacheSetArgs) GetReq() (v *SetRequest) {
-	if !p.IsSetReq() {
-		return GroupCacheSetArgs_Req_DEFAULT
-	}
-	return p.Req
+func (p *CompareAndDeleteRequest) GetGroup() (v string) {
+	return p.Group
 }
-func (p *GroupCacheSetArgs) SetReq(val *SetRequest) {
-	p.Req = val
+
+func (p *CompareAndDeleteRequest) GetKey() (v string) {
+	return p.Key
 }
 
-func (p *GroupCacheSetArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *CompareAndDeleteRequest) GetVersion() (v int64) {
+	return p.Version
+}
+func (p *CompareAndDeleteRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *CompareAndDeleteRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *CompareAndDeleteRequest) SetVersion(val int64) {
+	p.Version = val
 }
 
-func (p *GroupCacheSetArgs) String() string {
+func (p *CompareAndDeleteRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheSetArgs(%+v)", *p)
+	return fmt.Sprintf("CompareAndDeleteRequest(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheSetArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_CompareAndDeleteRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "version",
 }
 
-type GroupCacheSetResult struct {
-	Success *SetResponse `thrift:"success,0,optional" frugal:"0,optional,SetResponse" json:"success,omitempty"`
+type CompareAndDeleteResponse struct {
+	Success  bool `thrift:"success,1" frugal:"1,default,bool" json:"success"`
+	Conflict bool `thrift:"conflict,2" frugal:"2,default,bool" json:"conflict"`
 }
 
-func NewGroupCacheSetResult() *GroupCacheSetResult {
-	return &GroupCacheSetResult{}
+func NewCompareAndDeleteResponse() *CompareAndDeleteResponse {
+	return &CompareAndDeleteResponse{}
 }
 
-func (p *GroupCacheSetResult) InitDefault() {
+func (p *CompareAndDeleteResponse) InitDefault() {
 }
 
-var GroupCacheSetResult_Success_DEFAULT *SetResponse
-
-func (p *GroupCacheSetResult) GetSuccess() (v *SetResponse) {
-	if !p.IsSetSuccess() {
-		return GroupCacheSetResult_Success_DEFAULT
-	}
+func (p *CompareAndDeleteResponse) GetSuccess() (v bool) {
 	return p.Success
 }
-func (p *GroupCacheSetResult) SetSuccess(x interface{}) {
-	p.Success = x.(*SetResponse)
-}
 
-func (p *GroupCacheSetResult) IsSetSuccess() bool {
-	return p.Success != nil
+func (p *CompareAndDeleteResponse) GetConflict() (v bool) {
+	return p.Conflict
+}
+func (p *CompareAndDeleteResponse) SetSuccess(val bool) {
+	p.Success = val
+}
+func (p *CompareAndDeleteResponse) SetConflict(val bool) {
+	p.Conflict = val
 }
 
-func (p *GroupCacheSetResult) String() string {
+func (p *CompareAndDeleteResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheSetResult(%+v)", *p)
+	return fmt.Sprintf("CompareAndDeleteResponse(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheSetResult = map[int16]string{
-	0: "success",
+var fieldIDToName_CompareAndDeleteResponse = map[int16]string{
+	1: "success",
+	2: "conflict",
 }
 
-type GroupCacheDeleteArgs struct {
-	Req *DeleteRequest `thrift:"req,1" frugal:"1,default,DeleteRequest" json:"req"`
+type IncrRequest struct {
+	Group   string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key     string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Delta   int64  `thrift:"delta,3" frugal:"3,default,i64" json:"delta"`
+	Initial int64  `thrift:"initial,4" frugal:"4,default,i64" json:"initial"`
+	Ttl     int64  `thrift:"ttl,5" frugal:"5,default,i64" json:"ttl"`
 }
 
-func NewGroupCacheDeleteArgs() *GroupCacheDeleteArgs {
-	return &GroupCacheDeleteArgs{}
+func NewIncrRequest() *IncrRequest {
+	return &IncrRequest{}
 }
 
-func (p *GroupCacheDeleteArgs) InitDefault() {
+func (p *IncrRequest) InitDefault() {
 }
 
-var GroupCacheDeleteArgs_Req_DEFAULT *DeleteRequest
+func (p *IncrRequest) GetGroup() (v string) {
+	return p.Group
+}
 
-func (p *GroupCacheDeleteArgs) GetReq() (v *DeleteRequest) {
-	if !p.IsSetReq() {
-		return GroupCacheDeleteArgs_Req_DEFAULT
-	}
-	return p.Req
+func (p *IncrRequest) GetKey() (v string) {
+	return p.Key
 }
-func (p *GroupCacheDeleteArgs) SetReq(val *DeleteRequest) {
-	p.Req = val
+
+func (p *IncrRequest) GetDelta() (v int64) {
+	return p.Delta
 }
 
-func (p *GroupCacheDeleteArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *IncrRequest) GetInitial() (v int64) {
+	return p.Initial
 }
 
-func (p *GroupCacheDeleteArgs) String() string {
+func (p *IncrRequest) GetTtl() (v int64) {
+	return p.Ttl
+}
+func (p *IncrRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *IncrRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *IncrRequest) SetDelta(val int64) {
+	p.Delta = val
+}
+func (p *IncrRequest) SetInitial(val int64) {
+	p.Initial = val
+}
+func (p *IncrRequest) SetTtl(val int64) {
+	p.Ttl = val
+}
+
+func (p *IncrRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheDeleteArgs(%+v)", *p)
+	return fmt.Sprintf("IncrRequest(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheDeleteArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_IncrRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "delta",
+	4: "initial",
+	5: "ttl",
 }
 
-type GroupCacheDeleteResult struct {
-	Success *DeleteResponse `thrift:"success,0,optional" frugal:"0,optional,DeleteResponse" json:"success,omitempty"`
+type IncrResponse struct {
+	Value int64 `thrift:"value,1" frugal:"1,default,i64" json:"value"`
 }
 
-func NewGroupCacheDeleteResult() *GroupCacheDeleteResult {
-	return &GroupCacheDeleteResult{}
+func NewIncrResponse() *IncrResponse {
+	return &IncrResponse{}
 }
 
-func (p *GroupCacheDeleteResult) InitDefault() {
+func (p *IncrResponse) InitDefault() {
 }
 
-var GroupCacheDeleteResult_Success_DEFAULT *DeleteResponse
-
-func (p *GroupCacheDeleteResult) GetSuccess() (v *DeleteResponse) {
-	if !p.IsSetSuccess() {
-		return GroupCacheDeleteResult_Success_DEFAULT
-	}
-	return p.Success
-}
-func (p *GroupCacheDeleteResult) SetSuccess(x interface{}) {
-	p.Success = x.(*DeleteResponse)
+func (p *IncrResponse) GetValue() (v int64) {
+	return p.Value
 }
-
-func (p *GroupCacheDeleteResult) IsSetSuccess() bool {
-	return p.Success != nil
+func (p *IncrResponse) SetValue(val int64) {
+	p.Value = val
 }
 
-func (p *GroupCacheDeleteResult) String() string {
+func (p *IncrResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheDeleteResult(%+v)", *p)
+	return fmt.Sprintf("IncrResponse(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheDeleteResult = map[int16]string{
-	0: "success",
+var fieldIDToName_IncrResponse = map[int16]string{
+	1: "value",
 }
 
-type GroupCacheClearArgs struct {
-	Req *ClearRequest `thrift:"req,1" frugal:"1,default,ClearRequest" json:"req"`
+type LeaseGetResponse struct {
+	Value []byte `thrift:"value,1" frugal:"1,default,binary" json:"value"`
+	Hit   bool   `thrift:"hit,2" frugal:"2,default,bool" json:"hit"`
+	Token int64  `thrift:"token,3" frugal:"3,default,i64" json:"token"`
+	Retry bool   `thrift:"retry,4" frugal:"4,default,bool" json:"retry"`
 }
 
-func NewGroupCacheClearArgs() *GroupCacheClearArgs {
-	return &GroupCacheClearArgs{}
+func NewLeaseGetResponse() *LeaseGetResponse {
+	return &LeaseGetResponse{}
 }
 
-func (p *GroupCacheClearArgs) InitDefault() {
+func (p *LeaseGetResponse) InitDefault() {
 }
 
-var GroupCacheClearArgs_Req_DEFAULT *ClearRequest
+func (p *LeaseGetResponse) GetValue() (v []byte) {
+	return p.Value
+}
 
-func (p *GroupCacheClearArgs) GetReq() (v *ClearRequest) {
-	if !p.IsSetReq() {
-		return GroupCacheClearArgs_Req_DEFAULT
-	}
-	return p.Req
+func (p *LeaseGetResponse) GetHit() (v bool) {
+	return p.Hit
 }
-func (p *GroupCacheClearArgs) SetReq(val *ClearRequest) {
-	p.Req = val
+
+func (p *LeaseGetResponse) GetToken() (v int64) {
+	return p.Token
 }
 
-func (p *GroupCacheClearArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *LeaseGetResponse) GetRetry() (v bool) {
+	return p.Retry
+}
+func (p *LeaseGetResponse) SetValue(val []byte) {
+	p.Value = val
+}
+func (p *LeaseGetResponse) SetHit(val bool) {
+	p.Hit = val
+}
+func (p *LeaseGetResponse) SetToken(val int64) {
+	p.Token = val
+}
+func (p *LeaseGetResponse) SetRetry(val bool) {
+	p.Retry = val
 }
 
-func (p *GroupCacheClearArgs) String() string {
+func (p *LeaseGetResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheClearArgs(%+v)", *p)
+	return fmt.Sprintf("LeaseGetResponse(%+v)", *p)
+}
+
+var fieldIDToName_LeaseGetResponse = map[int16]string{
+	1: "value",
+	2: "hit",
+	3: "token",
+	4: "retry",
+}
+
+type LeaseSetRequest struct {
+	Group string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key   string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Value []byte `thrift:"value,3" frugal:"3,default,binary" json:"value"`
+	Token int64  `thrift:"token,4" frugal:"4,default,i64" json:"token"`
+	Ttl   int64  `thrift:"ttl,5" frugal:"5,default,i64" json:"ttl"`
+}
+
+func NewLeaseSetRequest() *LeaseSetRequest {
+	return &LeaseSetRequest{}
+}
+
+func (p *LeaseSetRequest) InitDefault() {
+}
+
+func (p *LeaseSetRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *LeaseSetRequest) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *LeaseSetRequest) GetValue() (v []byte) {
+	return p.Value
+}
+
+func (p *LeaseSetRequest) GetToken() (v int64) {
+	return p.Token
+}
+
+func (p *LeaseSetRequest) GetTtl() (v int64) {
+	return p.Ttl
+}
+func (p *LeaseSetRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *LeaseSetRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *LeaseSetRequest) SetValue(val []byte) {
+	p.Value = val
+}
+func (p *LeaseSetRequest) SetToken(val int64) {
+	p.Token = val
+}
+func (p *LeaseSetRequest) SetTtl(val int64) {
+	p.Ttl = val
+}
+
+func (p *LeaseSetRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LeaseSetRequest(%+v)", *p)
+}
+
+var fieldIDToName_LeaseSetRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "value",
+	4: "token",
+	5: "ttl",
+}
+
+type ScanRequest struct {
+	Group   string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Cursor  string `thrift:"cursor,2" frugal:"2,default,string" json:"cursor"`
+	Pattern string `thrift:"pattern,3" frugal:"3,default,string" json:"pattern"`
+	Count   int32  `thrift:"count,4" frugal:"4,default,i32" json:"count"`
+}
+
+func NewScanRequest() *ScanRequest {
+	return &ScanRequest{}
+}
+
+func (p *ScanRequest) InitDefault() {
+}
+
+func (p *ScanRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *ScanRequest) GetCursor() (v string) {
+	return p.Cursor
+}
+
+func (p *ScanRequest) GetPattern() (v string) {
+	return p.Pattern
+}
+
+func (p *ScanRequest) GetCount() (v int32) {
+	return p.Count
+}
+func (p *ScanRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *ScanRequest) SetCursor(val string) {
+	p.Cursor = val
+}
+func (p *ScanRequest) SetPattern(val string) {
+	p.Pattern = val
+}
+func (p *ScanRequest) SetCount(val int32) {
+	p.Count = val
+}
+
+func (p *ScanRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ScanRequest(%+v)", *p)
+}
+
+var fieldIDToName_ScanRequest = map[int16]string{
+	1: "group",
+	2: "cursor",
+	3: "pattern",
+	4: "count",
+}
+
+type ScanResponse struct {
+	Keys   []string `thrift:"keys,1" frugal:"1,default,list<string>" json:"keys"`
+	Cursor string   `thrift:"cursor,2" frugal:"2,default,string" json:"cursor"`
+}
+
+func NewScanResponse() *ScanResponse {
+	return &ScanResponse{}
+}
+
+func (p *ScanResponse) InitDefault() {
+}
+
+func (p *ScanResponse) GetKeys() (v []string) {
+	return p.Keys
+}
+
+func (p *ScanResponse) GetCursor() (v string) {
+	return p.Cursor
+}
+func (p *ScanResponse) SetKeys(val []string) {
+	p.Keys = val
+}
+func (p *ScanResponse) SetCursor(val string) {
+	p.Cursor = val
+}
+
+func (p *ScanResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ScanResponse(%+v)", *p)
+}
+
+var fieldIDToName_ScanResponse = map[int16]string{
+	1: "keys",
+	2: "cursor",
+}
+
+type DumpRequest struct {
+	Group  string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Cursor string `thrift:"cursor,2" frugal:"2,default,string" json:"cursor"`
+	Count  int32  `thrift:"count,3" frugal:"3,default,i32" json:"count"`
+	Owner  string `thrift:"owner,4" frugal:"4,default,string" json:"owner"`
+}
+
+func NewDumpRequest() *DumpRequest {
+	return &DumpRequest{}
+}
+
+func (p *DumpRequest) InitDefault() {
+}
+
+func (p *DumpRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *DumpRequest) GetCursor() (v string) {
+	return p.Cursor
+}
+
+func (p *DumpRequest) GetCount() (v int32) {
+	return p.Count
+}
+
+func (p *DumpRequest) GetOwner() (v string) {
+	return p.Owner
+}
+func (p *DumpRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *DumpRequest) SetCursor(val string) {
+	p.Cursor = val
+}
+func (p *DumpRequest) SetCount(val int32) {
+	p.Count = val
+}
+func (p *DumpRequest) SetOwner(val string) {
+	p.Owner = val
+}
+
+func (p *DumpRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DumpRequest(%+v)", *p)
+}
+
+var fieldIDToName_DumpRequest = map[int16]string{
+	1: "group",
+	2: "cursor",
+	3: "count",
+	4: "owner",
+}
+
+type DumpEntry struct {
+	Key   string `thrift:"key,1" frugal:"1,default,string" json:"key"`
+	Value []byte `thrift:"value,2" frugal:"2,default,binary" json:"value"`
+	Ttl   int64  `thrift:"ttl,3" frugal:"3,default,i64" json:"ttl"`
+}
+
+func NewDumpEntry() *DumpEntry {
+	return &DumpEntry{}
+}
+
+func (p *DumpEntry) InitDefault() {
+}
+
+func (p *DumpEntry) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *DumpEntry) GetValue() (v []byte) {
+	return p.Value
+}
+
+func (p *DumpEntry) GetTtl() (v int64) {
+	return p.Ttl
+}
+func (p *DumpEntry) SetKey(val string) {
+	p.Key = val
+}
+func (p *DumpEntry) SetValue(val []byte) {
+	p.Value = val
+}
+func (p *DumpEntry) SetTtl(val int64) {
+	p.Ttl = val
+}
+
+func (p *DumpEntry) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DumpEntry(%+v)", *p)
+}
+
+var fieldIDToName_DumpEntry = map[int16]string{
+	1: "key",
+	2: "value",
+	3: "ttl",
+}
+
+type DumpResponse struct {
+	Entries []*DumpEntry `thrift:"entries,1" frugal:"1,default,list<DumpEntry>" json:"entries"`
+	Cursor  string       `thrift:"cursor,2" frugal:"2,default,string" json:"cursor"`
+}
+
+func NewDumpResponse() *DumpResponse {
+	return &DumpResponse{}
+}
+
+func (p *DumpResponse) InitDefault() {
+}
+
+func (p *DumpResponse) GetEntries() (v []*DumpEntry) {
+	return p.Entries
+}
+
+func (p *DumpResponse) GetCursor() (v string) {
+	return p.Cursor
+}
+func (p *DumpResponse) SetEntries(val []*DumpEntry) {
+	p.Entries = val
+}
+func (p *DumpResponse) SetCursor(val string) {
+	p.Cursor = val
+}
+
+func (p *DumpResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DumpResponse(%+v)", *p)
+}
+
+var fieldIDToName_DumpResponse = map[int16]string{
+	1: "entries",
+	2: "cursor",
+}
+
+type HotKeysRequest struct {
+	Group string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	N     int32  `thrift:"n,2" frugal:"2,default,i32" json:"n"`
+}
+
+func NewHotKeysRequest() *HotKeysRequest {
+	return &HotKeysRequest{}
+}
+
+func (p *HotKeysRequest) InitDefault() {
+}
+
+func (p *HotKeysRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *HotKeysRequest) GetN() (v int32) {
+	return p.N
+}
+func (p *HotKeysRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *HotKeysRequest) SetN(val int32) {
+	p.N = val
+}
+
+func (p *HotKeysRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HotKeysRequest(%+v)", *p)
+}
+
+var fieldIDToName_HotKeysRequest = map[int16]string{
+	1: "group",
+	2: "n",
+}
+
+type HotKey struct {
+	Key   string  `thrift:"key,1" frugal:"1,default,string" json:"key"`
+	Count int64   `thrift:"count,2" frugal:"2,default,i64" json:"count"`
+	Qps   float64 `thrift:"qps,3" frugal:"3,default,double" json:"qps"`
+}
+
+func NewHotKey() *HotKey {
+	return &HotKey{}
+}
+
+func (p *HotKey) InitDefault() {
+}
+
+func (p *HotKey) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *HotKey) GetCount() (v int64) {
+	return p.Count
+}
+
+func (p *HotKey) GetQps() (v float64) {
+	return p.Qps
+}
+func (p *HotKey) SetKey(val string) {
+	p.Key = val
+}
+func (p *HotKey) SetCount(val int64) {
+	p.Count = val
+}
+func (p *HotKey) SetQps(val float64) {
+	p.Qps = val
+}
+
+func (p *HotKey) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HotKey(%+v)", *p)
+}
+
+var fieldIDToName_HotKey = map[int16]string{
+	1: "key",
+	2: "count",
+	3: "qps",
+}
+
+type HotKeysResponse struct {
+	Keys    []*HotKey `thrift:"keys,1" frugal:"1,default,list<HotKey>" json:"keys"`
+	Enabled bool      `thrift:"enabled,2" frugal:"2,default,bool" json:"enabled"`
+}
+
+func NewHotKeysResponse() *HotKeysResponse {
+	return &HotKeysResponse{}
+}
+
+func (p *HotKeysResponse) InitDefault() {
+}
+
+func (p *HotKeysResponse) GetKeys() (v []*HotKey) {
+	return p.Keys
+}
+
+func (p *HotKeysResponse) GetEnabled() (v bool) {
+	return p.Enabled
+}
+func (p *HotKeysResponse) SetKeys(val []*HotKey) {
+	p.Keys = val
+}
+func (p *HotKeysResponse) SetEnabled(val bool) {
+	p.Enabled = val
+}
+
+func (p *HotKeysResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HotKeysResponse(%+v)", *p)
+}
+
+var fieldIDToName_HotKeysResponse = map[int16]string{
+	1: "keys",
+	2: "enabled",
+}
+
+type InvalidateTagRequest struct {
+	Group  string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Tag    string `thrift:"tag,2" frugal:"2,default,string" json:"tag"`
+	Fanout bool   `thrift:"fanout,3" frugal:"3,default,bool" json:"fanout"`
+}
+
+func NewInvalidateTagRequest() *InvalidateTagRequest {
+	return &InvalidateTagRequest{}
+}
+
+func (p *InvalidateTagRequest) InitDefault() {
+}
+
+func (p *InvalidateTagRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *InvalidateTagRequest) GetTag() (v string) {
+	return p.Tag
+}
+
+func (p *InvalidateTagRequest) GetFanout() (v bool) {
+	return p.Fanout
+}
+func (p *InvalidateTagRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *InvalidateTagRequest) SetTag(val string) {
+	p.Tag = val
+}
+func (p *InvalidateTagRequest) SetFanout(val bool) {
+	p.Fanout = val
+}
+
+func (p *InvalidateTagRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InvalidateTagRequest(%+v)", *p)
+}
+
+var fieldIDToName_InvalidateTagRequest = map[int16]string{
+	1: "group",
+	2: "tag",
+	3: "fanout",
+}
+
+type InvalidateTagResponse struct {
+	Removed int64 `thrift:"removed,1" frugal:"1,default,i64" json:"removed"`
+}
+
+func NewInvalidateTagResponse() *InvalidateTagResponse {
+	return &InvalidateTagResponse{}
+}
+
+func (p *InvalidateTagResponse) InitDefault() {
+}
+
+func (p *InvalidateTagResponse) GetRemoved() (v int64) {
+	return p.Removed
+}
+func (p *InvalidateTagResponse) SetRemoved(val int64) {
+	p.Removed = val
+}
+
+func (p *InvalidateTagResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InvalidateTagResponse(%+v)", *p)
+}
+
+var fieldIDToName_InvalidateTagResponse = map[int16]string{
+	1: "removed",
+}
+
+type BumpGenerationRequest struct {
+	Group     string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Namespace string `thrift:"namespace,2" frugal:"2,default,string" json:"namespace"`
+	Fanout    bool   `thrift:"fanout,3" frugal:"3,default,bool" json:"fanout"`
+}
+
+func NewBumpGenerationRequest() *BumpGenerationRequest {
+	return &BumpGenerationRequest{}
+}
+
+func (p *BumpGenerationRequest) InitDefault() {
+}
+
+func (p *BumpGenerationRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *BumpGenerationRequest) GetNamespace() (v string) {
+	return p.Namespace
+}
+
+func (p *BumpGenerationRequest) GetFanout() (v bool) {
+	return p.Fanout
+}
+func (p *BumpGenerationRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *BumpGenerationRequest) SetNamespace(val string) {
+	p.Namespace = val
+}
+func (p *BumpGenerationRequest) SetFanout(val bool) {
+	p.Fanout = val
+}
+
+func (p *BumpGenerationRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BumpGenerationRequest(%+v)", *p)
+}
+
+var fieldIDToName_BumpGenerationRequest = map[int16]string{
+	1: "group",
+	2: "namespace",
+	3: "fanout",
+}
+
+type BumpGenerationResponse struct {
+	Generation int64 `thrift:"generation,1" frugal:"1,default,i64" json:"generation"`
+}
+
+func NewBumpGenerationResponse() *BumpGenerationResponse {
+	return &BumpGenerationResponse{}
+}
+
+func (p *BumpGenerationResponse) InitDefault() {
+}
+
+func (p *BumpGenerationResponse) GetGeneration() (v int64) {
+	return p.Generation
+}
+func (p *BumpGenerationResponse) SetGeneration(val int64) {
+	p.Generation = val
+}
+
+func (p *BumpGenerationResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BumpGenerationResponse(%+v)", *p)
+}
+
+var fieldIDToName_BumpGenerationResponse = map[int16]string{
+	1: "generation",
+}
+
+type WatchRequest struct {
+	Group     string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key       string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Since     int64  `thrift:"since,3" frugal:"3,default,i64" json:"since"`
+	TimeoutMs int32  `thrift:"timeout_ms,4" frugal:"4,default,i32" json:"timeout_ms"`
+}
+
+func NewWatchRequest() *WatchRequest {
+	return &WatchRequest{}
+}
+
+func (p *WatchRequest) InitDefault() {
+}
+
+func (p *WatchRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *WatchRequest) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *WatchRequest) GetSince() (v int64) {
+	return p.Since
+}
+
+func (p *WatchRequest) GetTimeoutMs() (v int32) {
+	return p.TimeoutMs
+}
+func (p *WatchRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *WatchRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *WatchRequest) SetSince(val int64) {
+	p.Since = val
+}
+func (p *WatchRequest) SetTimeoutMs(val int32) {
+	p.TimeoutMs = val
+}
+
+func (p *WatchRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WatchRequest(%+v)", *p)
+}
+
+var fieldIDToName_WatchRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "since",
+	4: "timeout_ms",
+}
+
+type WatchEvent struct {
+	Seq   int64  `thrift:"seq,1" frugal:"1,default,i64" json:"seq"`
+	Key   string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Type  string `thrift:"type,3" frugal:"3,default,string" json:"type"`
+	Value []byte `thrift:"value,4" frugal:"4,default,binary" json:"value"`
+}
+
+func NewWatchEvent() *WatchEvent {
+	return &WatchEvent{}
+}
+
+func (p *WatchEvent) InitDefault() {
+}
+
+func (p *WatchEvent) GetSeq() (v int64) {
+	return p.Seq
+}
+
+func (p *WatchEvent) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *WatchEvent) GetType() (v string) {
+	return p.Type
+}
+
+func (p *WatchEvent) GetValue() (v []byte) {
+	return p.Value
+}
+func (p *WatchEvent) SetSeq(val int64) {
+	p.Seq = val
+}
+func (p *WatchEvent) SetKey(val string) {
+	p.Key = val
+}
+func (p *WatchEvent) SetType(val string) {
+	p.Type = val
+}
+func (p *WatchEvent) SetValue(val []byte) {
+	p.Value = val
+}
+
+func (p *WatchEvent) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WatchEvent(%+v)", *p)
+}
+
+var fieldIDToName_WatchEvent = map[int16]string{
+	1: "seq",
+	2: "key",
+	3: "type",
+	4: "value",
+}
+
+type WatchResponse struct {
+	Events    []*WatchEvent `thrift:"events,1" frugal:"1,default,list<WatchEvent>" json:"events"`
+	Next      int64         `thrift:"next,2" frugal:"2,default,i64" json:"next"`
+	Truncated bool          `thrift:"truncated,3" frugal:"3,default,bool" json:"truncated"`
+}
+
+func NewWatchResponse() *WatchResponse {
+	return &WatchResponse{}
+}
+
+func (p *WatchResponse) InitDefault() {
+}
+
+func (p *WatchResponse) GetEvents() (v []*WatchEvent) {
+	return p.Events
+}
+
+func (p *WatchResponse) GetNext() (v int64) {
+	return p.Next
+}
+
+func (p *WatchResponse) GetTruncated() (v bool) {
+	return p.Truncated
+}
+func (p *WatchResponse) SetEvents(val []*WatchEvent) {
+	p.Events = val
+}
+func (p *WatchResponse) SetNext(val int64) {
+	p.Next = val
+}
+func (p *WatchResponse) SetTruncated(val bool) {
+	p.Truncated = val
+}
+
+func (p *WatchResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("WatchResponse(%+v)", *p)
+}
+
+var fieldIDToName_WatchResponse = map[int16]string{
+	1: "events",
+	2: "next",
+	3: "truncated",
+}
+
+type PublishRequest struct {
+	Channel string `thrift:"channel,1" frugal:"1,default,string" json:"channel"`
+	Payload []byte `thrift:"payload,2" frugal:"2,default,binary" json:"payload"`
+}
+
+func NewPublishRequest() *PublishRequest {
+	return &PublishRequest{}
+}
+
+func (p *PublishRequest) InitDefault() {
+}
+
+func (p *PublishRequest) GetChannel() (v string) {
+	return p.Channel
+}
+
+func (p *PublishRequest) GetPayload() (v []byte) {
+	return p.Payload
+}
+func (p *PublishRequest) SetChannel(val string) {
+	p.Channel = val
+}
+func (p *PublishRequest) SetPayload(val []byte) {
+	p.Payload = val
+}
+
+func (p *PublishRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PublishRequest(%+v)", *p)
+}
+
+var fieldIDToName_PublishRequest = map[int16]string{
+	1: "channel",
+	2: "payload",
+}
+
+type PublishResponse struct {
+	Receivers int32 `thrift:"receivers,1" frugal:"1,default,i32" json:"receivers"`
+}
+
+func NewPublishResponse() *PublishResponse {
+	return &PublishResponse{}
+}
+
+func (p *PublishResponse) InitDefault() {
+}
+
+func (p *PublishResponse) GetReceivers() (v int32) {
+	return p.Receivers
+}
+func (p *PublishResponse) SetReceivers(val int32) {
+	p.Receivers = val
+}
+
+func (p *PublishResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PublishResponse(%+v)", *p)
+}
+
+var fieldIDToName_PublishResponse = map[int16]string{
+	1: "receivers",
+}
+
+type SubscribeRequest struct {
+	Channel      string `thrift:"channel,1" frugal:"1,default,string" json:"channel"`
+	Subscription string `thrift:"subscription,2" frugal:"2,default,string" json:"subscription"`
+	TimeoutMs    int32  `thrift:"timeout_ms,3" frugal:"3,default,i32" json:"timeout_ms"`
+	MaxMessages  int32  `thrift:"max_messages,4" frugal:"4,default,i32" json:"max_messages"`
+}
+
+func NewSubscribeRequest() *SubscribeRequest {
+	return &SubscribeRequest{}
+}
+
+func (p *SubscribeRequest) InitDefault() {
+}
+
+func (p *SubscribeRequest) GetChannel() (v string) {
+	return p.Channel
+}
+
+func (p *SubscribeRequest) GetSubscription() (v string) {
+	return p.Subscription
+}
+
+func (p *SubscribeRequest) GetTimeoutMs() (v int32) {
+	return p.TimeoutMs
+}
+
+func (p *SubscribeRequest) GetMaxMessages() (v int32) {
+	return p.MaxMessages
+}
+func (p *SubscribeRequest) SetChannel(val string) {
+	p.Channel = val
+}
+func (p *SubscribeRequest) SetSubscription(val string) {
+	p.Subscription = val
+}
+func (p *SubscribeRequest) SetTimeoutMs(val int32) {
+	p.TimeoutMs = val
+}
+func (p *SubscribeRequest) SetMaxMessages(val int32) {
+	p.MaxMessages = val
+}
+
+func (p *SubscribeRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubscribeRequest(%+v)", *p)
+}
+
+var fieldIDToName_SubscribeRequest = map[int16]string{
+	1: "channel",
+	2: "subscription",
+	3: "timeout_ms",
+	4: "max_messages",
+}
+
+type SubscribeResponse struct {
+	Subscription string   `thrift:"subscription,1" frugal:"1,default,string" json:"subscription"`
+	Messages     [][]byte `thrift:"messages,2" frugal:"2,default,list<binary>" json:"messages"`
+	Dropped      int64    `thrift:"dropped,3" frugal:"3,default,i64" json:"dropped"`
+}
+
+func NewSubscribeResponse() *SubscribeResponse {
+	return &SubscribeResponse{}
+}
+
+func (p *SubscribeResponse) InitDefault() {
+}
+
+func (p *SubscribeResponse) GetSubscription() (v string) {
+	return p.Subscription
+}
+
+func (p *SubscribeResponse) GetMessages() (v [][]byte) {
+	return p.Messages
+}
+
+func (p *SubscribeResponse) GetDropped() (v int64) {
+	return p.Dropped
+}
+func (p *SubscribeResponse) SetSubscription(val string) {
+	p.Subscription = val
+}
+func (p *SubscribeResponse) SetMessages(val [][]byte) {
+	p.Messages = val
+}
+func (p *SubscribeResponse) SetDropped(val int64) {
+	p.Dropped = val
+}
+
+func (p *SubscribeResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubscribeResponse(%+v)", *p)
+}
+
+var fieldIDToName_SubscribeResponse = map[int16]string{
+	1: "subscription",
+	2: "messages",
+	3: "dropped",
+}
+
+type UnsubscribeRequest struct {
+	Subscription string `thrift:"subscription,1" frugal:"1,default,string" json:"subscription"`
+}
+
+func NewUnsubscribeRequest() *UnsubscribeRequest {
+	return &UnsubscribeRequest{}
+}
+
+func (p *UnsubscribeRequest) InitDefault() {
+}
+
+func (p *UnsubscribeRequest) GetSubscription() (v string) {
+	return p.Subscription
+}
+func (p *UnsubscribeRequest) SetSubscription(val string) {
+	p.Subscription = val
+}
+
+func (p *UnsubscribeRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UnsubscribeRequest(%+v)", *p)
+}
+
+var fieldIDToName_UnsubscribeRequest = map[int16]string{
+	1: "subscription",
+}
+
+type UnsubscribeResponse struct {
+	Success bool `thrift:"success,1" frugal:"1,default,bool" json:"success"`
+}
+
+func NewUnsubscribeResponse() *UnsubscribeResponse {
+	return &UnsubscribeResponse{}
+}
+
+func (p *UnsubscribeResponse) InitDefault() {
+}
+
+func (p *UnsubscribeResponse) GetSuccess() (v bool) {
+	return p.Success
+}
+func (p *UnsubscribeResponse) SetSuccess(val bool) {
+	p.Success = val
+}
+
+func (p *UnsubscribeResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UnsubscribeResponse(%+v)", *p)
+}
+
+var fieldIDToName_UnsubscribeResponse = map[int16]string{
+	1: "success",
+}
+
+type HSetRequest struct {
+	Group  string            `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key    string            `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Fields map[string][]byte `thrift:"fields,3" frugal:"3,default,map<string:binary>" json:"fields"`
+	Ttl    int64             `thrift:"ttl,4" frugal:"4,default,i64" json:"ttl"`
+}
+
+func NewHSetRequest() *HSetRequest {
+	return &HSetRequest{}
+}
+
+func (p *HSetRequest) InitDefault() {
+}
+
+func (p *HSetRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *HSetRequest) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *HSetRequest) GetFields() (v map[string][]byte) {
+	return p.Fields
+}
+
+func (p *HSetRequest) GetTtl() (v int64) {
+	return p.Ttl
+}
+func (p *HSetRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *HSetRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *HSetRequest) SetFields(val map[string][]byte) {
+	p.Fields = val
+}
+func (p *HSetRequest) SetTtl(val int64) {
+	p.Ttl = val
+}
+
+func (p *HSetRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HSetRequest(%+v)", *p)
+}
+
+var fieldIDToName_HSetRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "fields",
+	4: "ttl",
+}
+
+type HSetResponse struct {
+	Added int32 `thrift:"added,1" frugal:"1,default,i32" json:"added"`
+}
+
+func NewHSetResponse() *HSetResponse {
+	return &HSetResponse{}
+}
+
+func (p *HSetResponse) InitDefault() {
+}
+
+func (p *HSetResponse) GetAdded() (v int32) {
+	return p.Added
+}
+func (p *HSetResponse) SetAdded(val int32) {
+	p.Added = val
+}
+
+func (p *HSetResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HSetResponse(%+v)", *p)
+}
+
+var fieldIDToName_HSetResponse = map[int16]string{
+	1: "added",
+}
+
+type HGetRequest struct {
+	Group string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key   string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Field string `thrift:"field,3" frugal:"3,default,string" json:"field"`
+}
+
+func NewHGetRequest() *HGetRequest {
+	return &HGetRequest{}
+}
+
+func (p *HGetRequest) InitDefault() {
+}
+
+func (p *HGetRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *HGetRequest) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *HGetRequest) GetField() (v string) {
+	return p.Field
+}
+func (p *HGetRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *HGetRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *HGetRequest) SetField(val string) {
+	p.Field = val
+}
+
+func (p *HGetRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HGetRequest(%+v)", *p)
+}
+
+var fieldIDToName_HGetRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "field",
+}
+
+type HGetResponse struct {
+	Value []byte `thrift:"value,1" frugal:"1,default,binary" json:"value"`
+	Found bool   `thrift:"found,2" frugal:"2,default,bool" json:"found"`
+}
+
+func NewHGetResponse() *HGetResponse {
+	return &HGetResponse{}
+}
+
+func (p *HGetResponse) InitDefault() {
+}
+
+func (p *HGetResponse) GetValue() (v []byte) {
+	return p.Value
+}
+
+func (p *HGetResponse) GetFound() (v bool) {
+	return p.Found
+}
+func (p *HGetResponse) SetValue(val []byte) {
+	p.Value = val
+}
+func (p *HGetResponse) SetFound(val bool) {
+	p.Found = val
+}
+
+func (p *HGetResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HGetResponse(%+v)", *p)
+}
+
+var fieldIDToName_HGetResponse = map[int16]string{
+	1: "value",
+	2: "found",
+}
+
+type HDelRequest struct {
+	Group  string   `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key    string   `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Fields []string `thrift:"fields,3" frugal:"3,default,list<string>" json:"fields"`
+}
+
+func NewHDelRequest() *HDelRequest {
+	return &HDelRequest{}
+}
+
+func (p *HDelRequest) InitDefault() {
+}
+
+func (p *HDelRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *HDelRequest) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *HDelRequest) GetFields() (v []string) {
+	return p.Fields
+}
+func (p *HDelRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *HDelRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *HDelRequest) SetFields(val []string) {
+	p.Fields = val
+}
+
+func (p *HDelRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HDelRequest(%+v)", *p)
+}
+
+var fieldIDToName_HDelRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "fields",
+}
+
+type HDelResponse struct {
+	Removed int32 `thrift:"removed,1" frugal:"1,default,i32" json:"removed"`
+}
+
+func NewHDelResponse() *HDelResponse {
+	return &HDelResponse{}
+}
+
+func (p *HDelResponse) InitDefault() {
+}
+
+func (p *HDelResponse) GetRemoved() (v int32) {
+	return p.Removed
+}
+func (p *HDelResponse) SetRemoved(val int32) {
+	p.Removed = val
+}
+
+func (p *HDelResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HDelResponse(%+v)", *p)
+}
+
+var fieldIDToName_HDelResponse = map[int16]string{
+	1: "removed",
+}
+
+type HGetAllResponse struct {
+	Fields map[string][]byte `thrift:"fields,1" frugal:"1,default,map<string:binary>" json:"fields"`
+	Found  bool              `thrift:"found,2" frugal:"2,default,bool" json:"found"`
+}
+
+func NewHGetAllResponse() *HGetAllResponse {
+	return &HGetAllResponse{}
+}
+
+func (p *HGetAllResponse) InitDefault() {
+}
+
+func (p *HGetAllResponse) GetFields() (v map[string][]byte) {
+	return p.Fields
+}
+
+func (p *HGetAllResponse) GetFound() (v bool) {
+	return p.Found
+}
+func (p *HGetAllResponse) SetFields(val map[string][]byte) {
+	p.Fields = val
+}
+func (p *HGetAllResponse) SetFound(val bool) {
+	p.Found = val
+}
+
+func (p *HGetAllResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HGetAllResponse(%+v)", *p)
+}
+
+var fieldIDToName_HGetAllResponse = map[int16]string{
+	1: "fields",
+	2: "found",
+}
+
+type HIncrByRequest struct {
+	Group string `thrift:"group,1" frugal:"1,default,string" json:"group"`
+	Key   string `thrift:"key,2" frugal:"2,default,string" json:"key"`
+	Field string `thrift:"field,3" frugal:"3,default,string" json:"field"`
+	Delta int64  `thrift:"delta,4" frugal:"4,default,i64" json:"delta"`
+	Ttl   int64  `thrift:"ttl,5" frugal:"5,default,i64" json:"ttl"`
+}
+
+func NewHIncrByRequest() *HIncrByRequest {
+	return &HIncrByRequest{}
+}
+
+func (p *HIncrByRequest) InitDefault() {
+}
+
+func (p *HIncrByRequest) GetGroup() (v string) {
+	return p.Group
+}
+
+func (p *HIncrByRequest) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *HIncrByRequest) GetField() (v string) {
+	return p.Field
+}
+
+func (p *HIncrByRequest) GetDelta() (v int64) {
+	return p.Delta
+}
+
+func (p *HIncrByRequest) GetTtl() (v int64) {
+	return p.Ttl
+}
+func (p *HIncrByRequest) SetGroup(val string) {
+	p.Group = val
+}
+func (p *HIncrByRequest) SetKey(val string) {
+	p.Key = val
+}
+func (p *HIncrByRequest) SetField(val string) {
+	p.Field = val
+}
+func (p *HIncrByRequest) SetDelta(val int64) {
+	p.Delta = val
+}
+func (p *HIncrByRequest) SetTtl(val int64) {
+	p.Ttl = val
+}
+
+func (p *HIncrByRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HIncrByRequest(%+v)", *p)
+}
+
+var fieldIDToName_HIncrByRequest = map[int16]string{
+	1: "group",
+	2: "key",
+	3: "field",
+	4: "delta",
+	5: "ttl",
+}
+
+type GroupCache interface {
+	Get(ctx context.Context, req *Request) (r *Response, err error)
+
+	Set(ctx context.Context, req *SetRequest) (r *SetResponse, err error)
+
+	Delete(ctx context.Context, req *DeleteRequest) (r *DeleteResponse, err error)
+
+	Clear(ctx context.Context, req *ClearRequest) (r *ClearResponse, err error)
+
+	Stats(ctx context.Context, req *StatsRequest) (r *StatsResponse, err error)
+
+	GetMulti(ctx context.Context, req *GetMultiRequest) (r *GetMultiResponse, err error)
+
+	SetMulti(ctx context.Context, req *SetMultiRequest) (r *SetMultiResponse, err error)
+
+	SetChunks(ctx context.Context, req *SetMultiRequest) (r *SetMultiResponse, err error)
+
+	GetWithVersion(ctx context.Context, req *Request) (r *GetWithVersionResponse, err error)
+
+	CompareAndSet(ctx context.Context, req *CompareAndSetRequest) (r *CompareAndSetResponse, err error)
+
+	CompareAndDelete(ctx context.Context, req *CompareAndDeleteRequest) (r *CompareAndDeleteResponse, err error)
+
+	Incr(ctx context.Context, req *IncrRequest) (r *IncrResponse, err error)
+
+	Add(ctx context.Context, req *SetRequest) (r *SetResponse, err error)
+
+	Replace(ctx context.Context, req *SetRequest) (r *SetResponse, err error)
+
+	LeaseGet(ctx context.Context, req *Request) (r *LeaseGetResponse, err error)
+
+	LeaseSet(ctx context.Context, req *LeaseSetRequest) (r *SetResponse, err error)
+
+	Scan(ctx context.Context, req *ScanRequest) (r *ScanResponse, err error)
+
+	Dump(ctx context.Context, req *DumpRequest) (r *DumpResponse, err error)
+
+	HotKeys(ctx context.Context, req *HotKeysRequest) (r *HotKeysResponse, err error)
+
+	InvalidateTag(ctx context.Context, req *InvalidateTagRequest) (r *InvalidateTagResponse, err error)
+
+	BumpGeneration(ctx context.Context, req *BumpGenerationRequest) (r *BumpGenerationResponse, err error)
+
+	Watch(ctx context.Context, req *WatchRequest) (r *WatchResponse, err error)
+
+	Publish(ctx context.Context, req *PublishRequest) (r *PublishResponse, err error)
+
+	Subscribe(ctx context.Context, req *SubscribeRequest) (r *SubscribeResponse, err error)
+
+	Unsubscribe(ctx context.Context, req *UnsubscribeRequest) (r *UnsubscribeResponse, err error)
+
+	HSet(ctx context.Context, req *HSetRequest) (r *HSetResponse, err error)
+
+	HGet(ctx context.Context, req *HGetRequest) (r *HGetResponse, err error)
+
+	HDel(ctx context.Context, req *HDelRequest) (r *HDelResponse, err error)
+
+	HGetAll(ctx context.Context, req *Request) (r *HGetAllResponse, err error)
+
+	HIncrBy(ctx context.Context, req *HIncrByRequest) (r *IncrResponse, err error)
+}
+
+type GroupCacheGetArgs struct {
+	Req *Request `thrift:"req,1" frugal:"1,default,Request" json:"req"`
+}
+
+func NewGroupCacheGetArgs() *GroupCacheGetArgs {
+	return &GroupCacheGetArgs{}
+}
+
+func (p *GroupCacheGetArgs) InitDefault() {
+}
+
+var GroupCacheGetArgs_Req_DEFAULT *Request
+
+func (p *GroupCacheGetArgs) GetReq() (v *Request) {
+	if !p.IsSetReq() {
+		return GroupCacheGetArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheGetArgs) SetReq(val *Request) {
+	p.Req = val
+}
+
+func (p *GroupCacheGetArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheGetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheGetArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheGetArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheGetResult struct {
+	Success *Response `thrift:"success,0,optional" frugal:"0,optional,Response" json:"success,omitempty"`
+}
+
+func NewGroupCacheGetResult() *GroupCacheGetResult {
+	return &GroupCacheGetResult{}
+}
+
+func (p *GroupCacheGetResult) InitDefault() {
+}
+
+var GroupCacheGetResult_Success_DEFAULT *Response
+
+func (p *GroupCacheGetResult) GetSuccess() (v *Response) {
+	if !p.IsSetSuccess() {
+		return GroupCacheGetResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheGetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*Response)
+}
+
+func (p *GroupCacheGetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheGetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheGetResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheGetResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheSetArgs struct {
+	Req *SetRequest `thrift:"req,1" frugal:"1,default,SetRequest" json:"req"`
+}
+
+func NewGroupCacheSetArgs() *GroupCacheSetArgs {
+	return &GroupCacheSetArgs{}
+}
+
+func (p *GroupCacheSetArgs) InitDefault() {
+}
+
+var GroupCacheSetArgs_Req_DEFAULT *SetRequest
+
+func (p *GroupCacheSetArgs) GetReq() (v *SetRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheSetArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheSetArgs) SetReq(val *SetRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheSetArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheSetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSetArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSetArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheSetResult struct {
+	Success *SetResponse `thrift:"success,0,optional" frugal:"0,optional,SetResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheSetResult() *GroupCacheSetResult {
+	return &GroupCacheSetResult{}
+}
+
+func (p *GroupCacheSetResult) InitDefault() {
+}
+
+var GroupCacheSetResult_Success_DEFAULT *SetResponse
+
+func (p *GroupCacheSetResult) GetSuccess() (v *SetResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheSetResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheSetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetResponse)
+}
+
+func (p *GroupCacheSetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheSetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSetResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSetResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheDeleteArgs struct {
+	Req *DeleteRequest `thrift:"req,1" frugal:"1,default,DeleteRequest" json:"req"`
+}
+
+func NewGroupCacheDeleteArgs() *GroupCacheDeleteArgs {
+	return &GroupCacheDeleteArgs{}
+}
+
+func (p *GroupCacheDeleteArgs) InitDefault() {
+}
+
+var GroupCacheDeleteArgs_Req_DEFAULT *DeleteRequest
+
+func (p *GroupCacheDeleteArgs) GetReq() (v *DeleteRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheDeleteArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheDeleteArgs) SetReq(val *DeleteRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheDeleteArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheDeleteArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheDeleteArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheDeleteArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheDeleteResult struct {
+	Success *DeleteResponse `thrift:"success,0,optional" frugal:"0,optional,DeleteResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheDeleteResult() *GroupCacheDeleteResult {
+	return &GroupCacheDeleteResult{}
+}
+
+func (p *GroupCacheDeleteResult) InitDefault() {
+}
+
+var GroupCacheDeleteResult_Success_DEFAULT *DeleteResponse
+
+func (p *GroupCacheDeleteResult) GetSuccess() (v *DeleteResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheDeleteResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheDeleteResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DeleteResponse)
+}
+
+func (p *GroupCacheDeleteResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheDeleteResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheDeleteResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheDeleteResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheClearArgs struct {
+	Req *ClearRequest `thrift:"req,1" frugal:"1,default,ClearRequest" json:"req"`
+}
+
+func NewGroupCacheClearArgs() *GroupCacheClearArgs {
+	return &GroupCacheClearArgs{}
+}
+
+func (p *GroupCacheClearArgs) InitDefault() {
+}
+
+var GroupCacheClearArgs_Req_DEFAULT *ClearRequest
+
+func (p *GroupCacheClearArgs) GetReq() (v *ClearRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheClearArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheClearArgs) SetReq(val *ClearRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheClearArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheClearArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheClearArgs(%+v)", *p)
 }
 
 var fieldIDToName_GroupCacheClearArgs = map[int16]string{
 	1: "req",
 }
 
-type GroupCacheClearResult struct {
-	Success *ClearResponse `thrift:"success,0,optional" frugal:"0,optional,ClearResponse" json:"success,omitempty"`
+type GroupCacheClearResult struct {
+	Success *ClearResponse `thrift:"success,0,optional" frugal:"0,optional,ClearResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheClearResult() *GroupCacheClearResult {
+	return &GroupCacheClearResult{}
+}
+
+func (p *GroupCacheClearResult) InitDefault() {
+}
+
+var GroupCacheClearResult_Success_DEFAULT *ClearResponse
+
+func (p *GroupCacheClearResult) GetSuccess() (v *ClearResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheClearResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheClearResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ClearResponse)
+}
+
+func (p *GroupCacheClearResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheClearResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheClearResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheClearResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheStatsArgs struct {
+	Req *StatsRequest `thrift:"req,1" frugal:"1,default,StatsRequest" json:"req"`
+}
+
+func NewGroupCacheStatsArgs() *GroupCacheStatsArgs {
+	return &GroupCacheStatsArgs{}
+}
+
+func (p *GroupCacheStatsArgs) InitDefault() {
+}
+
+var GroupCacheStatsArgs_Req_DEFAULT *StatsRequest
+
+func (p *GroupCacheStatsArgs) GetReq() (v *StatsRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheStatsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheStatsArgs) SetReq(val *StatsRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheStatsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheStatsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheStatsArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheStatsArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheStatsResult struct {
+	Success *StatsResponse `thrift:"success,0,optional" frugal:"0,optional,StatsResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheStatsResult() *GroupCacheStatsResult {
+	return &GroupCacheStatsResult{}
+}
+
+func (p *GroupCacheStatsResult) InitDefault() {
+}
+
+var GroupCacheStatsResult_Success_DEFAULT *StatsResponse
+
+func (p *GroupCacheStatsResult) GetSuccess() (v *StatsResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheStatsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheStatsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*StatsResponse)
+}
+
+func (p *GroupCacheStatsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheStatsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheStatsResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheStatsResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheGetMultiArgs struct {
+	Req *GetMultiRequest `thrift:"req,1" frugal:"1,default,GetMultiRequest" json:"req"`
+}
+
+func NewGroupCacheGetMultiArgs() *GroupCacheGetMultiArgs {
+	return &GroupCacheGetMultiArgs{}
+}
+
+func (p *GroupCacheGetMultiArgs) InitDefault() {
+}
+
+var GroupCacheGetMultiArgs_Req_DEFAULT *GetMultiRequest
+
+func (p *GroupCacheGetMultiArgs) GetReq() (v *GetMultiRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheGetMultiArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheGetMultiArgs) SetReq(val *GetMultiRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheGetMultiArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheGetMultiArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheGetMultiArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheGetMultiArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheGetMultiResult struct {
+	Success *GetMultiResponse `thrift:"success,0,optional" frugal:"0,optional,GetMultiResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheGetMultiResult() *GroupCacheGetMultiResult {
+	return &GroupCacheGetMultiResult{}
+}
+
+func (p *GroupCacheGetMultiResult) InitDefault() {
+}
+
+var GroupCacheGetMultiResult_Success_DEFAULT *GetMultiResponse
+
+func (p *GroupCacheGetMultiResult) GetSuccess() (v *GetMultiResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheGetMultiResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheGetMultiResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetMultiResponse)
+}
+
+func (p *GroupCacheGetMultiResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheGetMultiResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheGetMultiResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheGetMultiResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheSetMultiArgs struct {
+	Req *SetMultiRequest `thrift:"req,1" frugal:"1,default,SetMultiRequest" json:"req"`
+}
+
+func NewGroupCacheSetMultiArgs() *GroupCacheSetMultiArgs {
+	return &GroupCacheSetMultiArgs{}
+}
+
+func (p *GroupCacheSetMultiArgs) InitDefault() {
+}
+
+var GroupCacheSetMultiArgs_Req_DEFAULT *SetMultiRequest
+
+func (p *GroupCacheSetMultiArgs) GetReq() (v *SetMultiRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheSetMultiArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheSetMultiArgs) SetReq(val *SetMultiRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheSetMultiArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheSetMultiArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSetMultiArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSetMultiArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheSetMultiResult struct {
+	Success *SetMultiResponse `thrift:"success,0,optional" frugal:"0,optional,SetMultiResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheSetMultiResult() *GroupCacheSetMultiResult {
+	return &GroupCacheSetMultiResult{}
+}
+
+func (p *GroupCacheSetMultiResult) InitDefault() {
+}
+
+var GroupCacheSetMultiResult_Success_DEFAULT *SetMultiResponse
+
+func (p *GroupCacheSetMultiResult) GetSuccess() (v *SetMultiResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheSetMultiResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheSetMultiResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetMultiResponse)
+}
+
+func (p *GroupCacheSetMultiResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheSetMultiResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSetMultiResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSetMultiResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheSetChunksArgs struct {
+	Req *SetMultiRequest `thrift:"req,1" frugal:"1,default,SetMultiRequest" json:"req"`
+}
+
+func NewGroupCacheSetChunksArgs() *GroupCacheSetChunksArgs {
+	return &GroupCacheSetChunksArgs{}
+}
+
+func (p *GroupCacheSetChunksArgs) InitDefault() {
+}
+
+var GroupCacheSetChunksArgs_Req_DEFAULT *SetMultiRequest
+
+func (p *GroupCacheSetChunksArgs) GetReq() (v *SetMultiRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheSetChunksArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheSetChunksArgs) SetReq(val *SetMultiRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheSetChunksArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheSetChunksArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSetChunksArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSetChunksArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheSetChunksResult struct {
+	Success *SetMultiResponse `thrift:"success,0,optional" frugal:"0,optional,SetMultiResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheSetChunksResult() *GroupCacheSetChunksResult {
+	return &GroupCacheSetChunksResult{}
+}
+
+func (p *GroupCacheSetChunksResult) InitDefault() {
+}
+
+var GroupCacheSetChunksResult_Success_DEFAULT *SetMultiResponse
+
+func (p *GroupCacheSetChunksResult) GetSuccess() (v *SetMultiResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheSetChunksResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheSetChunksResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetMultiResponse)
+}
+
+func (p *GroupCacheSetChunksResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheSetChunksResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSetChunksResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSetChunksResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheGetWithVersionArgs struct {
+	Req *Request `thrift:"req,1" frugal:"1,default,Request" json:"req"`
+}
+
+func NewGroupCacheGetWithVersionArgs() *GroupCacheGetWithVersionArgs {
+	return &GroupCacheGetWithVersionArgs{}
+}
+
+func (p *GroupCacheGetWithVersionArgs) InitDefault() {
+}
+
+var GroupCacheGetWithVersionArgs_Req_DEFAULT *Request
+
+func (p *GroupCacheGetWithVersionArgs) GetReq() (v *Request) {
+	if !p.IsSetReq() {
+		return GroupCacheGetWithVersionArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheGetWithVersionArgs) SetReq(val *Request) {
+	p.Req = val
+}
+
+func (p *GroupCacheGetWithVersionArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheGetWithVersionArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheGetWithVersionArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheGetWithVersionArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheGetWithVersionResult struct {
+	Success *GetWithVersionResponse `thrift:"success,0,optional" frugal:"0,optional,GetWithVersionResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheGetWithVersionResult() *GroupCacheGetWithVersionResult {
+	return &GroupCacheGetWithVersionResult{}
+}
+
+func (p *GroupCacheGetWithVersionResult) InitDefault() {
+}
+
+var GroupCacheGetWithVersionResult_Success_DEFAULT *GetWithVersionResponse
+
+func (p *GroupCacheGetWithVersionResult) GetSuccess() (v *GetWithVersionResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheGetWithVersionResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheGetWithVersionResult) SetSuccess(x interface{}) {
+	p.Success = x.(*GetWithVersionResponse)
+}
+
+func (p *GroupCacheGetWithVersionResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheGetWithVersionResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheGetWithVersionResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheGetWithVersionResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheCompareAndSetArgs struct {
+	Req *CompareAndSetRequest `thrift:"req,1" frugal:"1,default,CompareAndSetRequest" json:"req"`
+}
+
+func NewGroupCacheCompareAndSetArgs() *GroupCacheCompareAndSetArgs {
+	return &GroupCacheCompareAndSetArgs{}
+}
+
+func (p *GroupCacheCompareAndSetArgs) InitDefault() {
+}
+
+var GroupCacheCompareAndSetArgs_Req_DEFAULT *CompareAndSetRequest
+
+func (p *GroupCacheCompareAndSetArgs) GetReq() (v *CompareAndSetRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheCompareAndSetArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheCompareAndSetArgs) SetReq(val *CompareAndSetRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheCompareAndSetArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheCompareAndSetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheCompareAndSetArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheCompareAndSetArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheCompareAndSetResult struct {
+	Success *CompareAndSetResponse `thrift:"success,0,optional" frugal:"0,optional,CompareAndSetResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheCompareAndSetResult() *GroupCacheCompareAndSetResult {
+	return &GroupCacheCompareAndSetResult{}
+}
+
+func (p *GroupCacheCompareAndSetResult) InitDefault() {
+}
+
+var GroupCacheCompareAndSetResult_Success_DEFAULT *CompareAndSetResponse
+
+func (p *GroupCacheCompareAndSetResult) GetSuccess() (v *CompareAndSetResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheCompareAndSetResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheCompareAndSetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CompareAndSetResponse)
+}
+
+func (p *GroupCacheCompareAndSetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheCompareAndSetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheCompareAndSetResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheCompareAndSetResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheCompareAndDeleteArgs struct {
+	Req *CompareAndDeleteRequest `thrift:"req,1" frugal:"1,default,CompareAndDeleteRequest" json:"req"`
+}
+
+func NewGroupCacheCompareAndDeleteArgs() *GroupCacheCompareAndDeleteArgs {
+	return &GroupCacheCompareAndDeleteArgs{}
+}
+
+func (p *GroupCacheCompareAndDeleteArgs) InitDefault() {
+}
+
+var GroupCacheCompareAndDeleteArgs_Req_DEFAULT *CompareAndDeleteRequest
+
+func (p *GroupCacheCompareAndDeleteArgs) GetReq() (v *CompareAndDeleteRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheCompareAndDeleteArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheCompareAndDeleteArgs) SetReq(val *CompareAndDeleteRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheCompareAndDeleteArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheCompareAndDeleteArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheCompareAndDeleteArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheCompareAndDeleteArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheCompareAndDeleteResult struct {
+	Success *CompareAndDeleteResponse `thrift:"success,0,optional" frugal:"0,optional,CompareAndDeleteResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheCompareAndDeleteResult() *GroupCacheCompareAndDeleteResult {
+	return &GroupCacheCompareAndDeleteResult{}
+}
+
+func (p *GroupCacheCompareAndDeleteResult) InitDefault() {
+}
+
+var GroupCacheCompareAndDeleteResult_Success_DEFAULT *CompareAndDeleteResponse
+
+func (p *GroupCacheCompareAndDeleteResult) GetSuccess() (v *CompareAndDeleteResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheCompareAndDeleteResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheCompareAndDeleteResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CompareAndDeleteResponse)
+}
+
+func (p *GroupCacheCompareAndDeleteResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheCompareAndDeleteResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheCompareAndDeleteResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheCompareAndDeleteResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheIncrArgs struct {
+	Req *IncrRequest `thrift:"req,1" frugal:"1,default,IncrRequest" json:"req"`
+}
+
+func NewGroupCacheIncrArgs() *GroupCacheIncrArgs {
+	return &GroupCacheIncrArgs{}
+}
+
+func (p *GroupCacheIncrArgs) InitDefault() {
+}
+
+var GroupCacheIncrArgs_Req_DEFAULT *IncrRequest
+
+func (p *GroupCacheIncrArgs) GetReq() (v *IncrRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheIncrArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheIncrArgs) SetReq(val *IncrRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheIncrArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheIncrArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheIncrArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheIncrArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheIncrResult struct {
+	Success *IncrResponse `thrift:"success,0,optional" frugal:"0,optional,IncrResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheIncrResult() *GroupCacheIncrResult {
+	return &GroupCacheIncrResult{}
+}
+
+func (p *GroupCacheIncrResult) InitDefault() {
+}
+
+var GroupCacheIncrResult_Success_DEFAULT *IncrResponse
+
+func (p *GroupCacheIncrResult) GetSuccess() (v *IncrResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheIncrResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheIncrResult) SetSuccess(x interface{}) {
+	p.Success = x.(*IncrResponse)
+}
+
+func (p *GroupCacheIncrResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheIncrResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheIncrResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheIncrResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheAddArgs struct {
+	Req *SetRequest `thrift:"req,1" frugal:"1,default,SetRequest" json:"req"`
+}
+
+func NewGroupCacheAddArgs() *GroupCacheAddArgs {
+	return &GroupCacheAddArgs{}
+}
+
+func (p *GroupCacheAddArgs) InitDefault() {
+}
+
+var GroupCacheAddArgs_Req_DEFAULT *SetRequest
+
+func (p *GroupCacheAddArgs) GetReq() (v *SetRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheAddArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheAddArgs) SetReq(val *SetRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheAddArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheAddArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheAddArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheAddArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheAddResult struct {
+	Success *SetResponse `thrift:"success,0,optional" frugal:"0,optional,SetResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheAddResult() *GroupCacheAddResult {
+	return &GroupCacheAddResult{}
+}
+
+func (p *GroupCacheAddResult) InitDefault() {
+}
+
+var GroupCacheAddResult_Success_DEFAULT *SetResponse
+
+func (p *GroupCacheAddResult) GetSuccess() (v *SetResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheAddResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheAddResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetResponse)
+}
+
+func (p *GroupCacheAddResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheAddResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheAddResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheAddResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheReplaceArgs struct {
+	Req *SetRequest `thrift:"req,1" frugal:"1,default,SetRequest" json:"req"`
+}
+
+func NewGroupCacheReplaceArgs() *GroupCacheReplaceArgs {
+	return &GroupCacheReplaceArgs{}
+}
+
+func (p *GroupCacheReplaceArgs) InitDefault() {
+}
+
+var GroupCacheReplaceArgs_Req_DEFAULT *SetRequest
+
+func (p *GroupCacheReplaceArgs) GetReq() (v *SetRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheReplaceArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheReplaceArgs) SetReq(val *SetRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheReplaceArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheReplaceArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheReplaceArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheReplaceArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheReplaceResult struct {
+	Success *SetResponse `thrift:"success,0,optional" frugal:"0,optional,SetResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheReplaceResult() *GroupCacheReplaceResult {
+	return &GroupCacheReplaceResult{}
+}
+
+func (p *GroupCacheReplaceResult) InitDefault() {
+}
+
+var GroupCacheReplaceResult_Success_DEFAULT *SetResponse
+
+func (p *GroupCacheReplaceResult) GetSuccess() (v *SetResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheReplaceResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheReplaceResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetResponse)
+}
+
+func (p *GroupCacheReplaceResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheReplaceResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheReplaceResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheReplaceResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheLeaseGetArgs struct {
+	Req *Request `thrift:"req,1" frugal:"1,default,Request" json:"req"`
+}
+
+func NewGroupCacheLeaseGetArgs() *GroupCacheLeaseGetArgs {
+	return &GroupCacheLeaseGetArgs{}
+}
+
+func (p *GroupCacheLeaseGetArgs) InitDefault() {
+}
+
+var GroupCacheLeaseGetArgs_Req_DEFAULT *Request
+
+func (p *GroupCacheLeaseGetArgs) GetReq() (v *Request) {
+	if !p.IsSetReq() {
+		return GroupCacheLeaseGetArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheLeaseGetArgs) SetReq(val *Request) {
+	p.Req = val
+}
+
+func (p *GroupCacheLeaseGetArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheLeaseGetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheLeaseGetArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheLeaseGetArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheLeaseGetResult struct {
+	Success *LeaseGetResponse `thrift:"success,0,optional" frugal:"0,optional,LeaseGetResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheLeaseGetResult() *GroupCacheLeaseGetResult {
+	return &GroupCacheLeaseGetResult{}
+}
+
+func (p *GroupCacheLeaseGetResult) InitDefault() {
+}
+
+var GroupCacheLeaseGetResult_Success_DEFAULT *LeaseGetResponse
+
+func (p *GroupCacheLeaseGetResult) GetSuccess() (v *LeaseGetResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheLeaseGetResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheLeaseGetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*LeaseGetResponse)
+}
+
+func (p *GroupCacheLeaseGetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheLeaseGetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheLeaseGetResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheLeaseGetResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheLeaseSetArgs struct {
+	Req *LeaseSetRequest `thrift:"req,1" frugal:"1,default,LeaseSetRequest" json:"req"`
+}
+
+func NewGroupCacheLeaseSetArgs() *GroupCacheLeaseSetArgs {
+	return &GroupCacheLeaseSetArgs{}
+}
+
+func (p *GroupCacheLeaseSetArgs) InitDefault() {
+}
+
+var GroupCacheLeaseSetArgs_Req_DEFAULT *LeaseSetRequest
+
+func (p *GroupCacheLeaseSetArgs) GetReq() (v *LeaseSetRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheLeaseSetArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheLeaseSetArgs) SetReq(val *LeaseSetRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheLeaseSetArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheLeaseSetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheLeaseSetArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheLeaseSetArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheLeaseSetResult struct {
+	Success *SetResponse `thrift:"success,0,optional" frugal:"0,optional,SetResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheLeaseSetResult() *GroupCacheLeaseSetResult {
+	return &GroupCacheLeaseSetResult{}
+}
+
+func (p *GroupCacheLeaseSetResult) InitDefault() {
+}
+
+var GroupCacheLeaseSetResult_Success_DEFAULT *SetResponse
+
+func (p *GroupCacheLeaseSetResult) GetSuccess() (v *SetResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheLeaseSetResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheLeaseSetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SetResponse)
+}
+
+func (p *GroupCacheLeaseSetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheLeaseSetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheLeaseSetResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheLeaseSetResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheScanArgs struct {
+	Req *ScanRequest `thrift:"req,1" frugal:"1,default,ScanRequest" json:"req"`
+}
+
+func NewGroupCacheScanArgs() *GroupCacheScanArgs {
+	return &GroupCacheScanArgs{}
+}
+
+func (p *GroupCacheScanArgs) InitDefault() {
+}
+
+var GroupCacheScanArgs_Req_DEFAULT *ScanRequest
+
+func (p *GroupCacheScanArgs) GetReq() (v *ScanRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheScanArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheScanArgs) SetReq(val *ScanRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheScanArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheScanArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheScanArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheScanArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheScanResult struct {
+	Success *ScanResponse `thrift:"success,0,optional" frugal:"0,optional,ScanResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheScanResult() *GroupCacheScanResult {
+	return &GroupCacheScanResult{}
+}
+
+func (p *GroupCacheScanResult) InitDefault() {
+}
+
+var GroupCacheScanResult_Success_DEFAULT *ScanResponse
+
+func (p *GroupCacheScanResult) GetSuccess() (v *ScanResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheScanResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheScanResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ScanResponse)
+}
+
+func (p *GroupCacheScanResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheScanResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheScanResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheScanResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheDumpArgs struct {
+	Req *DumpRequest `thrift:"req,1" frugal:"1,default,DumpRequest" json:"req"`
+}
+
+func NewGroupCacheDumpArgs() *GroupCacheDumpArgs {
+	return &GroupCacheDumpArgs{}
+}
+
+func (p *GroupCacheDumpArgs) InitDefault() {
+}
+
+var GroupCacheDumpArgs_Req_DEFAULT *DumpRequest
+
+func (p *GroupCacheDumpArgs) GetReq() (v *DumpRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheDumpArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheDumpArgs) SetReq(val *DumpRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheDumpArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheDumpArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheDumpArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheDumpArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheDumpResult struct {
+	Success *DumpResponse `thrift:"success,0,optional" frugal:"0,optional,DumpResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheDumpResult() *GroupCacheDumpResult {
+	return &GroupCacheDumpResult{}
+}
+
+func (p *GroupCacheDumpResult) InitDefault() {
+}
+
+var GroupCacheDumpResult_Success_DEFAULT *DumpResponse
+
+func (p *GroupCacheDumpResult) GetSuccess() (v *DumpResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheDumpResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheDumpResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DumpResponse)
+}
+
+func (p *GroupCacheDumpResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheDumpResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheDumpResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheDumpResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheHotKeysArgs struct {
+	Req *HotKeysRequest `thrift:"req,1" frugal:"1,default,HotKeysRequest" json:"req"`
+}
+
+func NewGroupCacheHotKeysArgs() *GroupCacheHotKeysArgs {
+	return &GroupCacheHotKeysArgs{}
+}
+
+func (p *GroupCacheHotKeysArgs) InitDefault() {
+}
+
+var GroupCacheHotKeysArgs_Req_DEFAULT *HotKeysRequest
+
+func (p *GroupCacheHotKeysArgs) GetReq() (v *HotKeysRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheHotKeysArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheHotKeysArgs) SetReq(val *HotKeysRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheHotKeysArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheHotKeysArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHotKeysArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHotKeysArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheHotKeysResult struct {
+	Success *HotKeysResponse `thrift:"success,0,optional" frugal:"0,optional,HotKeysResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheHotKeysResult() *GroupCacheHotKeysResult {
+	return &GroupCacheHotKeysResult{}
+}
+
+func (p *GroupCacheHotKeysResult) InitDefault() {
+}
+
+var GroupCacheHotKeysResult_Success_DEFAULT *HotKeysResponse
+
+func (p *GroupCacheHotKeysResult) GetSuccess() (v *HotKeysResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheHotKeysResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheHotKeysResult) SetSuccess(x interface{}) {
+	p.Success = x.(*HotKeysResponse)
+}
+
+func (p *GroupCacheHotKeysResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheHotKeysResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHotKeysResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHotKeysResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheInvalidateTagArgs struct {
+	Req *InvalidateTagRequest `thrift:"req,1" frugal:"1,default,InvalidateTagRequest" json:"req"`
+}
+
+func NewGroupCacheInvalidateTagArgs() *GroupCacheInvalidateTagArgs {
+	return &GroupCacheInvalidateTagArgs{}
+}
+
+func (p *GroupCacheInvalidateTagArgs) InitDefault() {
+}
+
+var GroupCacheInvalidateTagArgs_Req_DEFAULT *InvalidateTagRequest
+
+func (p *GroupCacheInvalidateTagArgs) GetReq() (v *InvalidateTagRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheInvalidateTagArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheInvalidateTagArgs) SetReq(val *InvalidateTagRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheInvalidateTagArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheInvalidateTagArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheInvalidateTagArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheInvalidateTagArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheInvalidateTagResult struct {
+	Success *InvalidateTagResponse `thrift:"success,0,optional" frugal:"0,optional,InvalidateTagResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheInvalidateTagResult() *GroupCacheInvalidateTagResult {
+	return &GroupCacheInvalidateTagResult{}
+}
+
+func (p *GroupCacheInvalidateTagResult) InitDefault() {
+}
+
+var GroupCacheInvalidateTagResult_Success_DEFAULT *InvalidateTagResponse
+
+func (p *GroupCacheInvalidateTagResult) GetSuccess() (v *InvalidateTagResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheInvalidateTagResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheInvalidateTagResult) SetSuccess(x interface{}) {
+	p.Success = x.(*InvalidateTagResponse)
+}
+
+func (p *GroupCacheInvalidateTagResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheInvalidateTagResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheInvalidateTagResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheInvalidateTagResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheBumpGenerationArgs struct {
+	Req *BumpGenerationRequest `thrift:"req,1" frugal:"1,default,BumpGenerationRequest" json:"req"`
+}
+
+func NewGroupCacheBumpGenerationArgs() *GroupCacheBumpGenerationArgs {
+	return &GroupCacheBumpGenerationArgs{}
+}
+
+func (p *GroupCacheBumpGenerationArgs) InitDefault() {
+}
+
+var GroupCacheBumpGenerationArgs_Req_DEFAULT *BumpGenerationRequest
+
+func (p *GroupCacheBumpGenerationArgs) GetReq() (v *BumpGenerationRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheBumpGenerationArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheBumpGenerationArgs) SetReq(val *BumpGenerationRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheBumpGenerationArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheBumpGenerationArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheBumpGenerationArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheBumpGenerationArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheBumpGenerationResult struct {
+	Success *BumpGenerationResponse `thrift:"success,0,optional" frugal:"0,optional,BumpGenerationResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheBumpGenerationResult() *GroupCacheBumpGenerationResult {
+	return &GroupCacheBumpGenerationResult{}
+}
+
+func (p *GroupCacheBumpGenerationResult) InitDefault() {
+}
+
+var GroupCacheBumpGenerationResult_Success_DEFAULT *BumpGenerationResponse
+
+func (p *GroupCacheBumpGenerationResult) GetSuccess() (v *BumpGenerationResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheBumpGenerationResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheBumpGenerationResult) SetSuccess(x interface{}) {
+	p.Success = x.(*BumpGenerationResponse)
+}
+
+func (p *GroupCacheBumpGenerationResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheBumpGenerationResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheBumpGenerationResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheBumpGenerationResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheWatchArgs struct {
+	Req *WatchRequest `thrift:"req,1" frugal:"1,default,WatchRequest" json:"req"`
+}
+
+func NewGroupCacheWatchArgs() *GroupCacheWatchArgs {
+	return &GroupCacheWatchArgs{}
+}
+
+func (p *GroupCacheWatchArgs) InitDefault() {
+}
+
+var GroupCacheWatchArgs_Req_DEFAULT *WatchRequest
+
+func (p *GroupCacheWatchArgs) GetReq() (v *WatchRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheWatchArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheWatchArgs) SetReq(val *WatchRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheWatchArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheWatchArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheWatchArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheWatchArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheWatchResult struct {
+	Success *WatchResponse `thrift:"success,0,optional" frugal:"0,optional,WatchResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheWatchResult() *GroupCacheWatchResult {
+	return &GroupCacheWatchResult{}
+}
+
+func (p *GroupCacheWatchResult) InitDefault() {
+}
+
+var GroupCacheWatchResult_Success_DEFAULT *WatchResponse
+
+func (p *GroupCacheWatchResult) GetSuccess() (v *WatchResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheWatchResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheWatchResult) SetSuccess(x interface{}) {
+	p.Success = x.(*WatchResponse)
+}
+
+func (p *GroupCacheWatchResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheWatchResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheWatchResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheWatchResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCachePublishArgs struct {
+	Req *PublishRequest `thrift:"req,1" frugal:"1,default,PublishRequest" json:"req"`
+}
+
+func NewGroupCachePublishArgs() *GroupCachePublishArgs {
+	return &GroupCachePublishArgs{}
+}
+
+func (p *GroupCachePublishArgs) InitDefault() {
+}
+
+var GroupCachePublishArgs_Req_DEFAULT *PublishRequest
+
+func (p *GroupCachePublishArgs) GetReq() (v *PublishRequest) {
+	if !p.IsSetReq() {
+		return GroupCachePublishArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCachePublishArgs) SetReq(val *PublishRequest) {
+	p.Req = val
+}
+
+func (p *GroupCachePublishArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCachePublishArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCachePublishArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCachePublishArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCachePublishResult struct {
+	Success *PublishResponse `thrift:"success,0,optional" frugal:"0,optional,PublishResponse" json:"success,omitempty"`
+}
+
+func NewGroupCachePublishResult() *GroupCachePublishResult {
+	return &GroupCachePublishResult{}
+}
+
+func (p *GroupCachePublishResult) InitDefault() {
+}
+
+var GroupCachePublishResult_Success_DEFAULT *PublishResponse
+
+func (p *GroupCachePublishResult) GetSuccess() (v *PublishResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCachePublishResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCachePublishResult) SetSuccess(x interface{}) {
+	p.Success = x.(*PublishResponse)
+}
+
+func (p *GroupCachePublishResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCachePublishResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCachePublishResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCachePublishResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheSubscribeArgs struct {
+	Req *SubscribeRequest `thrift:"req,1" frugal:"1,default,SubscribeRequest" json:"req"`
+}
+
+func NewGroupCacheSubscribeArgs() *GroupCacheSubscribeArgs {
+	return &GroupCacheSubscribeArgs{}
+}
+
+func (p *GroupCacheSubscribeArgs) InitDefault() {
+}
+
+var GroupCacheSubscribeArgs_Req_DEFAULT *SubscribeRequest
+
+func (p *GroupCacheSubscribeArgs) GetReq() (v *SubscribeRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheSubscribeArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheSubscribeArgs) SetReq(val *SubscribeRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheSubscribeArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheSubscribeArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheSubscribeArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheSubscribeArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheSubscribeResult struct {
+	Success *SubscribeResponse `thrift:"success,0,optional" frugal:"0,optional,SubscribeResponse" json:"success,omitempty"`
 }
 
-func NewGroupCacheClearResult() *GroupCacheClearResult {
-	return &GroupCacheClearResult{}
+func NewGroupCacheSubscribeResult() *GroupCacheSubscribeResult {
+	return &GroupCacheSubscribeResult{}
 }
 
-func (p *GroupCacheClearResult) InitDefault() {
+func (p *GroupCacheSubscribeResult) InitDefault() {
 }
 
-var GroupCacheClearResult_Success_DEFAULT *ClearResponse
+var GroupCacheSubscribeResult_Success_DEFAULT *SubscribeResponse
 
-func (p *GroupCacheClearResult) GetSuccess() (v *ClearResponse) {
+func (p *GroupCacheSubscribeResult) GetSuccess() (v *SubscribeResponse) {
 	if !p.IsSetSuccess() {
-		return GroupCacheClearResult_Success_DEFAULT
+		return GroupCacheSubscribeResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *GroupCacheClearResult) SetSuccess(x interface{}) {
-	p.Success = x.(*ClearResponse)
+func (p *GroupCacheSubscribeResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SubscribeResponse)
 }
 
-func (p *GroupCacheClearResult) IsSetSuccess() bool {
+func (p *GroupCacheSubscribeResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *GroupCacheClearResult) String() string {
+func (p *GroupCacheSubscribeResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheClearResult(%+v)", *p)
+	return fmt.Sprintf("GroupCacheSubscribeResult(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheClearResult = map[int16]string{
+var fieldIDToName_GroupCacheSubscribeResult = map[int16]string{
 	0: "success",
 }
 
-type GroupCacheStatsArgs struct {
-	Req *StatsRequest `thrift:"req,1" frugal:"1,default,StatsRequest" json:"req"`
+type GroupCacheUnsubscribeArgs struct {
+	Req *UnsubscribeRequest `thrift:"req,1" frugal:"1,default,UnsubscribeRequest" json:"req"`
 }
 
-func NewGroupCacheStatsArgs() *GroupCacheStatsArgs {
-	return &GroupCacheStatsArgs{}
+func NewGroupCacheUnsubscribeArgs() *GroupCacheUnsubscribeArgs {
+	return &GroupCacheUnsubscribeArgs{}
 }
 
-func (p *GroupCacheStatsArgs) InitDefault() {
+func (p *GroupCacheUnsubscribeArgs) InitDefault() {
 }
 
-var GroupCacheStatsArgs_Req_DEFAULT *StatsRequest
+var GroupCacheUnsubscribeArgs_Req_DEFAULT *UnsubscribeRequest
 
-func (p *GroupCacheStatsArgs) GetReq() (v *StatsRequest) {
+func (p *GroupCacheUnsubscribeArgs) GetReq() (v *UnsubscribeRequest) {
 	if !p.IsSetReq() {
-		return GroupCacheStatsArgs_Req_DEFAULT
+		return GroupCacheUnsubscribeArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *GroupCacheStatsArgs) SetReq(val *StatsRequest) {
+func (p *GroupCacheUnsubscribeArgs) SetReq(val *UnsubscribeRequest) {
 	p.Req = val
 }
 
-func (p *GroupCacheStatsArgs) IsSetReq() bool {
+func (p *GroupCacheUnsubscribeArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *GroupCacheStatsArgs) String() string {
+func (p *GroupCacheUnsubscribeArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheStatsArgs(%+v)", *p)
+	return fmt.Sprintf("GroupCacheUnsubscribeArgs(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheStatsArgs = map[int16]string{
+var fieldIDToName_GroupCacheUnsubscribeArgs = map[int16]string{
 	1: "req",
 }
 
-type GroupCacheStatsResult struct {
-	Success *StatsResponse `thrift:"success,0,optional" frugal:"0,optional,StatsResponse" json:"success,omitempty"`
+type GroupCacheUnsubscribeResult struct {
+	Success *UnsubscribeResponse `thrift:"success,0,optional" frugal:"0,optional,UnsubscribeResponse" json:"success,omitempty"`
 }
 
-func NewGroupCacheStatsResult() *GroupCacheStatsResult {
-	return &GroupCacheStatsResult{}
+func NewGroupCacheUnsubscribeResult() *GroupCacheUnsubscribeResult {
+	return &GroupCacheUnsubscribeResult{}
 }
 
-func (p *GroupCacheStatsResult) InitDefault() {
+func (p *GroupCacheUnsubscribeResult) InitDefault() {
 }
 
-var GroupCacheStatsResult_Success_DEFAULT *StatsResponse
+var GroupCacheUnsubscribeResult_Success_DEFAULT *UnsubscribeResponse
 
-func (p *GroupCacheStatsResult) GetSuccess() (v *StatsResponse) {
+func (p *GroupCacheUnsubscribeResult) GetSuccess() (v *UnsubscribeResponse) {
 	if !p.IsSetSuccess() {
-		return GroupCacheStatsResult_Success_DEFAULT
+		return GroupCacheUnsubscribeResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *GroupCacheStatsResult) SetSuccess(x interface{}) {
-	p.Success = x.(*StatsResponse)
+func (p *GroupCacheUnsubscribeResult) SetSuccess(x interface{}) {
+	p.Success = x.(*UnsubscribeResponse)
 }
 
-func (p *GroupCacheStatsResult) IsSetSuccess() bool {
+func (p *GroupCacheUnsubscribeResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *GroupCacheStatsResult) String() string {
+func (p *GroupCacheUnsubscribeResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheStatsResult(%+v)", *p)
+	return fmt.Sprintf("GroupCacheUnsubscribeResult(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheStatsResult = map[int16]string{
+var fieldIDToName_GroupCacheUnsubscribeResult = map[int16]string{
 	0: "success",
 }
 
-type GroupCacheGetMultiArgs struct {
-	Req *GetMultiRequest `thrift:"req,1" frugal:"1,default,GetMultiRequest" json:"req"`
+type GroupCacheHSetArgs struct {
+	Req *HSetRequest `thrift:"req,1" frugal:"1,default,HSetRequest" json:"req"`
 }
 
-func NewGroupCacheGetMultiArgs() *GroupCacheGetMultiArgs {
-	return &GroupCacheGetMultiArgs{}
+func NewGroupCacheHSetArgs() *GroupCacheHSetArgs {
+	return &GroupCacheHSetArgs{}
 }
 
-func (p *GroupCacheGetMultiArgs) InitDefault() {
+func (p *GroupCacheHSetArgs) InitDefault() {
 }
 
-var GroupCacheGetMultiArgs_Req_DEFAULT *GetMultiRequest
+var GroupCacheHSetArgs_Req_DEFAULT *HSetRequest
 
-func (p *GroupCacheGetMultiArgs) GetReq() (v *GetMultiRequest) {
+func (p *GroupCacheHSetArgs) GetReq() (v *HSetRequest) {
 	if !p.IsSetReq() {
-		return GroupCacheGetMultiArgs_Req_DEFAULT
+		return GroupCacheHSetArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *GroupCacheGetMultiArgs) SetReq(val *GetMultiRequest) {
+func (p *GroupCacheHSetArgs) SetReq(val *HSetRequest) {
 	p.Req = val
 }
 
-func (p *GroupCacheGetMultiArgs) IsSetReq() bool {
+func (p *GroupCacheHSetArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *GroupCacheGetMultiArgs) String() string {
+func (p *GroupCacheHSetArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheGetMultiArgs(%+v)", *p)
+	return fmt.Sprintf("GroupCacheHSetArgs(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheGetMultiArgs = map[int16]string{
+var fieldIDToName_GroupCacheHSetArgs = map[int16]string{
 	1: "req",
 }
 
-type GroupCacheGetMultiResult struct {
-	Success *GetMultiResponse `thrift:"success,0,optional" frugal:"0,optional,GetMultiResponse" json:"success,omitempty"`
+type GroupCacheHSetResult struct {
+	Success *HSetResponse `thrift:"success,0,optional" frugal:"0,optional,HSetResponse" json:"success,omitempty"`
 }
 
-func NewGroupCacheGetMultiResult() *GroupCacheGetMultiResult {
-	return &GroupCacheGetMultiResult{}
+func NewGroupCacheHSetResult() *GroupCacheHSetResult {
+	return &GroupCacheHSetResult{}
 }
 
-func (p *GroupCacheGetMultiResult) InitDefault() {
+func (p *GroupCacheHSetResult) InitDefault() {
 }
 
-var GroupCacheGetMultiResult_Success_DEFAULT *GetMultiResponse
+var GroupCacheHSetResult_Success_DEFAULT *HSetResponse
 
-func (p *GroupCacheGetMultiResult) GetSuccess() (v *GetMultiResponse) {
+func (p *GroupCacheHSetResult) GetSuccess() (v *HSetResponse) {
 	if !p.IsSetSuccess() {
-		return GroupCacheGetMultiResult_Success_DEFAULT
+		return GroupCacheHSetResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *GroupCacheGetMultiResult) SetSuccess(x interface{}) {
-	p.Success = x.(*GetMultiResponse)
+func (p *GroupCacheHSetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*HSetResponse)
 }
 
-func (p *GroupCacheGetMultiResult) IsSetSuccess() bool {
+func (p *GroupCacheHSetResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *GroupCacheGetMultiResult) String() string {
+func (p *GroupCacheHSetResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheGetMultiResult(%+v)", *p)
+	return fmt.Sprintf("GroupCacheHSetResult(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheGetMultiResult = map[int16]string{
+var fieldIDToName_GroupCacheHSetResult = map[int16]string{
 	0: "success",
 }
 
-type GroupCacheSetMultiArgs struct {
-	Req *SetMultiRequest `thrift:"req,1" frugal:"1,default,SetMultiRequest" json:"req"`
+type GroupCacheHGetArgs struct {
+	Req *HGetRequest `thrift:"req,1" frugal:"1,default,HGetRequest" json:"req"`
 }
 
-func NewGroupCacheSetMultiArgs() *GroupCacheSetMultiArgs {
-	return &GroupCacheSetMultiArgs{}
+func NewGroupCacheHGetArgs() *GroupCacheHGetArgs {
+	return &GroupCacheHGetArgs{}
 }
 
-func (p *GroupCacheSetMultiArgs) InitDefault() {
+func (p *GroupCacheHGetArgs) InitDefault() {
 }
 
-var GroupCacheSetMultiArgs_Req_DEFAULT *SetMultiRequest
+var GroupCacheHGetArgs_Req_DEFAULT *HGetRequest
 
-func (p *GroupCacheSetMultiArgs) GetReq() (v *SetMultiRequest) {
+func (p *GroupCacheHGetArgs) GetReq() (v *HGetRequest) {
 	if !p.IsSetReq() {
-		return GroupCacheSetMultiArgs_Req_DEFAULT
+		return GroupCacheHGetArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *GroupCacheSetMultiArgs) SetReq(val *SetMultiRequest) {
+func (p *GroupCacheHGetArgs) SetReq(val *HGetRequest) {
 	p.Req = val
 }
 
-func (p *GroupCacheSetMultiArgs) IsSetReq() bool {
+func (p *GroupCacheHGetArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *GroupCacheSetMultiArgs) String() string {
+func (p *GroupCacheHGetArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheSetMultiArgs(%+v)", *p)
+	return fmt.Sprintf("GroupCacheHGetArgs(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheSetMultiArgs = map[int16]string{
+var fieldIDToName_GroupCacheHGetArgs = map[int16]string{
 	1: "req",
 }
 
-type GroupCacheSetMultiResult struct {
-	Success *SetMultiResponse `thrift:"success,0,optional" frugal:"0,optional,SetMultiResponse" json:"success,omitempty"`
+type GroupCacheHGetResult struct {
+	Success *HGetResponse `thrift:"success,0,optional" frugal:"0,optional,HGetResponse" json:"success,omitempty"`
 }
 
-func NewGroupCacheSetMultiResult() *GroupCacheSetMultiResult {
-	return &GroupCacheSetMultiResult{}
+func NewGroupCacheHGetResult() *GroupCacheHGetResult {
+	return &GroupCacheHGetResult{}
 }
 
-func (p *GroupCacheSetMultiResult) InitDefault() {
+func (p *GroupCacheHGetResult) InitDefault() {
 }
 
-var GroupCacheSetMultiResult_Success_DEFAULT *SetMultiResponse
+var GroupCacheHGetResult_Success_DEFAULT *HGetResponse
 
-func (p *GroupCacheSetMultiResult) GetSuccess() (v *SetMultiResponse) {
+func (p *GroupCacheHGetResult) GetSuccess() (v *HGetResponse) {
 	if !p.IsSetSuccess() {
-		return GroupCacheSetMultiResult_Success_DEFAULT
+		return GroupCacheHGetResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *GroupCacheSetMultiResult) SetSuccess(x interface{}) {
-	p.Success = x.(*SetMultiResponse)
+func (p *GroupCacheHGetResult) SetSuccess(x interface{}) {
+	p.Success = x.(*HGetResponse)
 }
 
-func (p *GroupCacheSetMultiResult) IsSetSuccess() bool {
+func (p *GroupCacheHGetResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *GroupCacheSetMultiResult) String() string {
+func (p *GroupCacheHGetResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GroupCacheSetMultiResult(%+v)", *p)
+	return fmt.Sprintf("GroupCacheHGetResult(%+v)", *p)
 }
 
-var fieldIDToName_GroupCacheSetMultiResult = map[int16]string{
+var fieldIDToName_GroupCacheHGetResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheHDelArgs struct {
+	Req *HDelRequest `thrift:"req,1" frugal:"1,default,HDelRequest" json:"req"`
+}
+
+func NewGroupCacheHDelArgs() *GroupCacheHDelArgs {
+	return &GroupCacheHDelArgs{}
+}
+
+func (p *GroupCacheHDelArgs) InitDefault() {
+}
+
+var GroupCacheHDelArgs_Req_DEFAULT *HDelRequest
+
+func (p *GroupCacheHDelArgs) GetReq() (v *HDelRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheHDelArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheHDelArgs) SetReq(val *HDelRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheHDelArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheHDelArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHDelArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHDelArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheHDelResult struct {
+	Success *HDelResponse `thrift:"success,0,optional" frugal:"0,optional,HDelResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheHDelResult() *GroupCacheHDelResult {
+	return &GroupCacheHDelResult{}
+}
+
+func (p *GroupCacheHDelResult) InitDefault() {
+}
+
+var GroupCacheHDelResult_Success_DEFAULT *HDelResponse
+
+func (p *GroupCacheHDelResult) GetSuccess() (v *HDelResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheHDelResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheHDelResult) SetSuccess(x interface{}) {
+	p.Success = x.(*HDelResponse)
+}
+
+func (p *GroupCacheHDelResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheHDelResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHDelResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHDelResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheHGetAllArgs struct {
+	Req *Request `thrift:"req,1" frugal:"1,default,Request" json:"req"`
+}
+
+func NewGroupCacheHGetAllArgs() *GroupCacheHGetAllArgs {
+	return &GroupCacheHGetAllArgs{}
+}
+
+func (p *GroupCacheHGetAllArgs) InitDefault() {
+}
+
+var GroupCacheHGetAllArgs_Req_DEFAULT *Request
+
+func (p *GroupCacheHGetAllArgs) GetReq() (v *Request) {
+	if !p.IsSetReq() {
+		return GroupCacheHGetAllArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheHGetAllArgs) SetReq(val *Request) {
+	p.Req = val
+}
+
+func (p *GroupCacheHGetAllArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheHGetAllArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHGetAllArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHGetAllArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheHGetAllResult struct {
+	Success *HGetAllResponse `thrift:"success,0,optional" frugal:"0,optional,HGetAllResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheHGetAllResult() *GroupCacheHGetAllResult {
+	return &GroupCacheHGetAllResult{}
+}
+
+func (p *GroupCacheHGetAllResult) InitDefault() {
+}
+
+var GroupCacheHGetAllResult_Success_DEFAULT *HGetAllResponse
+
+func (p *GroupCacheHGetAllResult) GetSuccess() (v *HGetAllResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheHGetAllResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheHGetAllResult) SetSuccess(x interface{}) {
+	p.Success = x.(*HGetAllResponse)
+}
+
+func (p *GroupCacheHGetAllResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheHGetAllResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHGetAllResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHGetAllResult = map[int16]string{
+	0: "success",
+}
+
+type GroupCacheHIncrByArgs struct {
+	Req *HIncrByRequest `thrift:"req,1" frugal:"1,default,HIncrByRequest" json:"req"`
+}
+
+func NewGroupCacheHIncrByArgs() *GroupCacheHIncrByArgs {
+	return &GroupCacheHIncrByArgs{}
+}
+
+func (p *GroupCacheHIncrByArgs) InitDefault() {
+}
+
+var GroupCacheHIncrByArgs_Req_DEFAULT *HIncrByRequest
+
+func (p *GroupCacheHIncrByArgs) GetReq() (v *HIncrByRequest) {
+	if !p.IsSetReq() {
+		return GroupCacheHIncrByArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *GroupCacheHIncrByArgs) SetReq(val *HIncrByRequest) {
+	p.Req = val
+}
+
+func (p *GroupCacheHIncrByArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *GroupCacheHIncrByArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHIncrByArgs(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHIncrByArgs = map[int16]string{
+	1: "req",
+}
+
+type GroupCacheHIncrByResult struct {
+	Success *IncrResponse `thrift:"success,0,optional" frugal:"0,optional,IncrResponse" json:"success,omitempty"`
+}
+
+func NewGroupCacheHIncrByResult() *GroupCacheHIncrByResult {
+	return &GroupCacheHIncrByResult{}
+}
+
+func (p *GroupCacheHIncrByResult) InitDefault() {
+}
+
+var GroupCacheHIncrByResult_Success_DEFAULT *IncrResponse
+
+func (p *GroupCacheHIncrByResult) GetSuccess() (v *IncrResponse) {
+	if !p.IsSetSuccess() {
+		return GroupCacheHIncrByResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *GroupCacheHIncrByResult) SetSuccess(x interface{}) {
+	p.Success = x.(*IncrResponse)
+}
+
+func (p *GroupCacheHIncrByResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *GroupCacheHIncrByResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GroupCacheHIncrByResult(%+v)", *p)
+}
+
+var fieldIDToName_GroupCacheHIncrByResult = map[int16]string{
 	0: "success",
 }
@@ -18,6 +18,29 @@ type Client interface {
 	Stats(ctx context.Context, req *geecache.StatsRequest, callOptions ...callopt.Option) (r *geecache.StatsResponse, err error)
 	GetMulti(ctx context.Context, req *geecache.GetMultiRequest, callOptions ...callopt.Option) (r *geecache.GetMultiResponse, err error)
 	SetMulti(ctx context.Context, req *geecache.SetMultiRequest, callOptions ...callopt.Option) (r *geecache.SetMultiResponse, err error)
+	SetChunks(ctx context.Context, req *geecache.SetMultiRequest, callOptions ...callopt.Option) (r *geecache.SetMultiResponse, err error)
+	GetWithVersion(ctx context.Context, req *geecache.Request, callOptions ...callopt.Option) (r *geecache.GetWithVersionResponse, err error)
+	CompareAndSet(ctx context.Context, req *geecache.CompareAndSetRequest, callOptions ...callopt.Option) (r *geecache.CompareAndSetResponse, err error)
+	CompareAndDelete(ctx context.Context, req *geecache.CompareAndDeleteRequest, callOptions ...callopt.Option) (r *geecache.CompareAndDeleteResponse, err error)
+	Incr(ctx context.Context, req *geecache.IncrRequest, callOptions ...callopt.Option) (r *geecache.IncrResponse, err error)
+	Add(ctx context.Context, req *geecache.SetRequest, callOptions ...callopt.Option) (r *geecache.SetResponse, err error)
+	Replace(ctx context.Context, req *geecache.SetRequest, callOptions ...callopt.Option) (r *geecache.SetResponse, err error)
+	LeaseGet(ctx context.Context, req *geecache.Request, callOptions ...callopt.Option) (r *geecache.LeaseGetResponse, err error)
+	LeaseSet(ctx context.Context, req *geecache.LeaseSetRequest, callOptions ...callopt.Option) (r *geecache.SetResponse, err error)
+	Scan(ctx context.Context, req *geecache.ScanRequest, callOptions ...callopt.Option) (r *geecache.ScanResponse, err error)
+	Dump(ctx context.Context, req *geecache.DumpRequest, callOptions ...callopt.Option) (r *geecache.DumpResponse, err error)
+	HotKeys(ctx context.Context, req *geecache.HotKeysRequest, callOptions ...callopt.Option) (r *geecache.HotKeysResponse, err error)
+	InvalidateTag(ctx context.Context, req *geecache.InvalidateTagRequest, callOptions ...callopt.Option) (r *geecache.InvalidateTagResponse, err error)
+	BumpGeneration(ctx context.Context, req *geecache.BumpGenerationRequest, callOptions ...callopt.Option) (r *geecache.BumpGenerationResponse, err error)
+	Watch(ctx context.Context, req *geecache.WatchRequest, callOptions ...callopt.Option) (r *geecache.WatchResponse, err error)
+	Publish(ctx context.Context, req *geecache.PublishRequest, callOptions ...callopt.Option) (r *geecache.PublishResponse, err error)
+	Subscribe(ctx context.Context, req *geecache.SubscribeRequest, callOptions ...callopt.Option) (r *geecache.SubscribeResponse, err error)
+	Unsubscribe(ctx context.Context, req *geecache.UnsubscribeRequest, callOptions ...callopt.Option) (r *geecache.UnsubscribeResponse, err error)
+	HSet(ctx context.Context, req *geecache.HSetRequest, callOptions ...callopt.Option) (r *geecache.HSetResponse, err error)
+	HGet(ctx context.Context, req *geecache.HGetRequest, callOptions ...callopt.Option) (r *geecache.HGetResponse, err error)
+	HDel(ctx context.Context, req *geecache.HDelRequest, callOptions ...callopt.Option) (r *geecache.HDelResponse, err error)
+	HGetAll(ctx context.Context, req *geecache.Request, callOptions ...callopt.Option) (r *geecache.HGetAllResponse, err error)
+	HIncrBy(ctx context.Context, req *geecache.HIncrByRequest, callOptions ...callopt.Option) (r *geecache.IncrResponse, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -83,3 +106,118 @@ func (p *kGroupCacheClient) SetMulti(ctx context.Context, req *geecache.SetMulti
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.SetMulti(ctx, req)
 }
+
+func (p *kGroupCacheClient) SetChunks(ctx context.Context, req *geecache.SetMultiRequest, callOptions ...callopt.Option) (r *geecache.SetMultiResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SetChunks(ctx, req)
+}
+
+func (p *kGroupCacheClient) GetWithVersion(ctx context.Context, req *geecache.Request, callOptions ...callopt.Option) (r *geecache.GetWithVersionResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetWithVersion(ctx, req)
+}
+
+func (p *kGroupCacheClient) CompareAndSet(ctx context.Context, req *geecache.CompareAndSetRequest, callOptions ...callopt.Option) (r *geecache.CompareAndSetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CompareAndSet(ctx, req)
+}
+
+func (p *kGroupCacheClient) CompareAndDelete(ctx context.Context, req *geecache.CompareAndDeleteRequest, callOptions ...callopt.Option) (r *geecache.CompareAndDeleteResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CompareAndDelete(ctx, req)
+}
+
+func (p *kGroupCacheClient) Incr(ctx context.Context, req *geecache.IncrRequest, callOptions ...callopt.Option) (r *geecache.IncrResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Incr(ctx, req)
+}
+
+func (p *kGroupCacheClient) Add(ctx context.Context, req *geecache.SetRequest, callOptions ...callopt.Option) (r *geecache.SetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Add(ctx, req)
+}
+
+func (p *kGroupCacheClient) Replace(ctx context.Context, req *geecache.SetRequest, callOptions ...callopt.Option) (r *geecache.SetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Replace(ctx, req)
+}
+
+func (p *kGroupCacheClient) LeaseGet(ctx context.Context, req *geecache.Request, callOptions ...callopt.Option) (r *geecache.LeaseGetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.LeaseGet(ctx, req)
+}
+
+func (p *kGroupCacheClient) LeaseSet(ctx context.Context, req *geecache.LeaseSetRequest, callOptions ...callopt.Option) (r *geecache.SetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.LeaseSet(ctx, req)
+}
+
+func (p *kGroupCacheClient) Scan(ctx context.Context, req *geecache.ScanRequest, callOptions ...callopt.Option) (r *geecache.ScanResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Scan(ctx, req)
+}
+
+func (p *kGroupCacheClient) Dump(ctx context.Context, req *geecache.DumpRequest, callOptions ...callopt.Option) (r *geecache.DumpResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Dump(ctx, req)
+}
+
+func (p *kGroupCacheClient) HotKeys(ctx context.Context, req *geecache.HotKeysRequest, callOptions ...callopt.Option) (r *geecache.HotKeysResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HotKeys(ctx, req)
+}
+
+func (p *kGroupCacheClient) InvalidateTag(ctx context.Context, req *geecache.InvalidateTagRequest, callOptions ...callopt.Option) (r *geecache.InvalidateTagResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.InvalidateTag(ctx, req)
+}
+
+func (p *kGroupCacheClient) BumpGeneration(ctx context.Context, req *geecache.BumpGenerationRequest, callOptions ...callopt.Option) (r *geecache.BumpGenerationResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.BumpGeneration(ctx, req)
+}
+
+func (p *kGroupCacheClient) Watch(ctx context.Context, req *geecache.WatchRequest, callOptions ...callopt.Option) (r *geecache.WatchResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Watch(ctx, req)
+}
+
+func (p *kGroupCacheClient) Publish(ctx context.Context, req *geecache.PublishRequest, callOptions ...callopt.Option) (r *geecache.PublishResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Publish(ctx, req)
+}
+
+func (p *kGroupCacheClient) Subscribe(ctx context.Context, req *geecache.SubscribeRequest, callOptions ...callopt.Option) (r *geecache.SubscribeResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Subscribe(ctx, req)
+}
+
+func (p *kGroupCacheClient) Unsubscribe(ctx context.Context, req *geecache.UnsubscribeRequest, callOptions ...callopt.Option) (r *geecache.UnsubscribeResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Unsubscribe(ctx, req)
+}
+
+func (p *kGroupCacheClient) HSet(ctx context.Context, req *geecache.HSetRequest, callOptions ...callopt.Option) (r *geecache.HSetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HSet(ctx, req)
+}
+
+func (p *kGroupCacheClient) HGet(ctx context.Context, req *geecache.HGetRequest, callOptions ...callopt.Option) (r *geecache.HGetResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HGet(ctx, req)
+}
+
+func (p *kGroupCacheClient) HDel(ctx context.Context, req *geecache.HDelRequest, callOptions ...callopt.Option) (r *geecache.HDelResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HDel(ctx, req)
+}
+
+func (p *kGroupCacheClient) HGetAll(ctx context.Context, req *geecache.Request, callOptions ...callopt.Option) (r *geecache.HGetAllResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HGetAll(ctx, req)
+}
+
+func (p *kGroupCacheClient) HIncrBy(ctx context.Context, req *geecache.HIncrByRequest, callOptions ...callopt.Option) (r *geecache.IncrResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HIncrBy(ctx, req)
+}
@@ -62,6 +62,167 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"SetChunks": kitex.NewMethodInfo(
+		setChunksHandler,
+		newGroupCacheSetChunksArgs,
+		newGroupCacheSetChunksResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetWithVersion": kitex.NewMethodInfo(
+		getWithVersionHandler,
+		newGroupCacheGetWithVersionArgs,
+		newGroupCacheGetWithVersionResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"CompareAndSet": kitex.NewMethodInfo(
+		compareAndSetHandler,
+		newGroupCacheCompareAndSetArgs,
+		newGroupCacheCompareAndSetResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"CompareAndDelete": kitex.NewMethodInfo(
+		compareAndDeleteHandler,
+		newGroupCacheCompareAndDeleteArgs,
+		newGroupCacheCompareAndDeleteResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Incr": kitex.NewMethodInfo(
+		incrHandler,
+		newGroupCacheIncrArgs,
+		newGroupCacheIncrResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Add": kitex.NewMethodInfo(
+		addHandler,
+		newGroupCacheAddArgs,
+		newGroupCacheAddResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Replace": kitex.NewMethodInfo(
+		replaceHandler,
+		newGroupCacheReplaceArgs,
+		newGroupCacheReplaceResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"LeaseGet": kitex.NewMethodInfo(
+		leaseGetHandler,
+		newGroupCacheLeaseGetArgs,
+		newGroupCacheLeaseGetResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"LeaseSet": kitex.NewMethodInfo(
+		leaseSetHandler,
+		newGroupCacheLeaseSetArgs,
+		newGroupCacheLeaseSetResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Scan": kitex.NewMethodInfo(
+		scanHandler,
+		newGroupCacheScanArgs,
+		newGroupCacheScanResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Dump": kitex.NewMethodInfo(
+		dumpHandler,
+		newGroupCacheDumpArgs,
+		newGroupCacheDumpResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"HotKeys": kitex.NewMethodInfo(
+		hotKeysHandler,
+		newGroupCacheHotKeysArgs,
+		newGroupCacheHotKeysResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"InvalidateTag": kitex.NewMethodInfo(
+		invalidateTagHandler,
+		newGroupCacheInvalidateTagArgs,
+		newGroupCacheInvalidateTagResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"BumpGeneration": kitex.NewMethodInfo(
+		bumpGenerationHandler,
+		newGroupCacheBumpGenerationArgs,
+		newGroupCacheBumpGenerationResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Watch": kitex.NewMethodInfo(
+		watchHandler,
+		newGroupCacheWatchArgs,
+		newGroupCacheWatchResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Publish": kitex.NewMethodInfo(
+		publishHandler,
+		newGroupCachePublishArgs,
+		newGroupCachePublishResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Subscribe": kitex.NewMethodInfo(
+		subscribeHandler,
+		newGroupCacheSubscribeArgs,
+		newGroupCacheSubscribeResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Unsubscribe": kitex.NewMethodInfo(
+		unsubscribeHandler,
+		newGroupCacheUnsubscribeArgs,
+		newGroupCacheUnsubscribeResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"HSet": kitex.NewMethodInfo(
+		hSetHandler,
+		newGroupCacheHSetArgs,
+		newGroupCacheHSetResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"HGet": kitex.NewMethodInfo(
+		hGetHandler,
+		newGroupCacheHGetArgs,
+		newGroupCacheHGetResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"HDel": kitex.NewMethodInfo(
+		hDelHandler,
+		newGroupCacheHDelArgs,
+		newGroupCacheHDelResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"HGetAll": kitex.NewMethodInfo(
+		hGetAllHandler,
+		newGroupCacheHGetAllArgs,
+		newGroupCacheHGetAllResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"HIncrBy": kitex.NewMethodInfo(
+		hIncrByHandler,
+		newGroupCacheHIncrByArgs,
+		newGroupCacheHIncrByResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -254,6 +415,420 @@ func newGroupCacheSetMultiResult() interface{} {
 	return geecache.NewGroupCacheSetMultiResult()
 }
 
+func setChunksHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheSetChunksArgs)
+	realResult := result.(*geecache.GroupCacheSetChunksResult)
+	success, err := handler.(geecache.GroupCache).SetChunks(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheSetChunksArgs() interface{} {
+	return geecache.NewGroupCacheSetChunksArgs()
+}
+
+func newGroupCacheSetChunksResult() interface{} {
+	return geecache.NewGroupCacheSetChunksResult()
+}
+
+func getWithVersionHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheGetWithVersionArgs)
+	realResult := result.(*geecache.GroupCacheGetWithVersionResult)
+	success, err := handler.(geecache.GroupCache).GetWithVersion(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheGetWithVersionArgs() interface{} {
+	return geecache.NewGroupCacheGetWithVersionArgs()
+}
+
+func newGroupCacheGetWithVersionResult() interface{} {
+	return geecache.NewGroupCacheGetWithVersionResult()
+}
+
+func compareAndSetHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheCompareAndSetArgs)
+	realResult := result.(*geecache.GroupCacheCompareAndSetResult)
+	success, err := handler.(geecache.GroupCache).CompareAndSet(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheCompareAndSetArgs() interface{} {
+	return geecache.NewGroupCacheCompareAndSetArgs()
+}
+
+func newGroupCacheCompareAndSetResult() interface{} {
+	return geecache.NewGroupCacheCompareAndSetResult()
+}
+
+func compareAndDeleteHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheCompareAndDeleteArgs)
+	realResult := result.(*geecache.GroupCacheCompareAndDeleteResult)
+	success, err := handler.(geecache.GroupCache).CompareAndDelete(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheCompareAndDeleteArgs() interface{} {
+	return geecache.NewGroupCacheCompareAndDeleteArgs()
+}
+
+func newGroupCacheCompareAndDeleteResult() interface{} {
+	return geecache.NewGroupCacheCompareAndDeleteResult()
+}
+
+func incrHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheIncrArgs)
+	realResult := result.(*geecache.GroupCacheIncrResult)
+	success, err := handler.(geecache.GroupCache).Incr(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheIncrArgs() interface{} {
+	return geecache.NewGroupCacheIncrArgs()
+}
+
+func newGroupCacheIncrResult() interface{} {
+	return geecache.NewGroupCacheIncrResult()
+}
+
+func addHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheAddArgs)
+	realResult := result.(*geecache.GroupCacheAddResult)
+	success, err := handler.(geecache.GroupCache).Add(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheAddArgs() interface{} {
+	return geecache.NewGroupCacheAddArgs()
+}
+
+func newGroupCacheAddResult() interface{} {
+	return geecache.NewGroupCacheAddResult()
+}
+
+func replaceHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheReplaceArgs)
+	realResult := result.(*geecache.GroupCacheReplaceResult)
+	success, err := handler.(geecache.GroupCache).Replace(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheReplaceArgs() interface{} {
+	return geecache.NewGroupCacheReplaceArgs()
+}
+
+func newGroupCacheReplaceResult() interface{} {
+	return geecache.NewGroupCacheReplaceResult()
+}
+
+func leaseGetHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheLeaseGetArgs)
+	realResult := result.(*geecache.GroupCacheLeaseGetResult)
+	success, err := handler.(geecache.GroupCache).LeaseGet(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheLeaseGetArgs() interface{} {
+	return geecache.NewGroupCacheLeaseGetArgs()
+}
+
+func newGroupCacheLeaseGetResult() interface{} {
+	return geecache.NewGroupCacheLeaseGetResult()
+}
+
+func leaseSetHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheLeaseSetArgs)
+	realResult := result.(*geecache.GroupCacheLeaseSetResult)
+	success, err := handler.(geecache.GroupCache).LeaseSet(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheLeaseSetArgs() interface{} {
+	return geecache.NewGroupCacheLeaseSetArgs()
+}
+
+func newGroupCacheLeaseSetResult() interface{} {
+	return geecache.NewGroupCacheLeaseSetResult()
+}
+
+func scanHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheScanArgs)
+	realResult := result.(*geecache.GroupCacheScanResult)
+	success, err := handler.(geecache.GroupCache).Scan(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheScanArgs() interface{} {
+	return geecache.NewGroupCacheScanArgs()
+}
+
+func newGroupCacheScanResult() interface{} {
+	return geecache.NewGroupCacheScanResult()
+}
+
+func dumpHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheDumpArgs)
+	realResult := result.(*geecache.GroupCacheDumpResult)
+	success, err := handler.(geecache.GroupCache).Dump(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheDumpArgs() interface{} {
+	return geecache.NewGroupCacheDumpArgs()
+}
+
+func newGroupCacheDumpResult() interface{} {
+	return geecache.NewGroupCacheDumpResult()
+}
+
+func hotKeysHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheHotKeysArgs)
+	realResult := result.(*geecache.GroupCacheHotKeysResult)
+	success, err := handler.(geecache.GroupCache).HotKeys(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheHotKeysArgs() interface{} {
+	return geecache.NewGroupCacheHotKeysArgs()
+}
+
+func newGroupCacheHotKeysResult() interface{} {
+	return geecache.NewGroupCacheHotKeysResult()
+}
+
+func invalidateTagHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheInvalidateTagArgs)
+	realResult := result.(*geecache.GroupCacheInvalidateTagResult)
+	success, err := handler.(geecache.GroupCache).InvalidateTag(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheInvalidateTagArgs() interface{} {
+	return geecache.NewGroupCacheInvalidateTagArgs()
+}
+
+func newGroupCacheInvalidateTagResult() interface{} {
+	return geecache.NewGroupCacheInvalidateTagResult()
+}
+
+func bumpGenerationHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheBumpGenerationArgs)
+	realResult := result.(*geecache.GroupCacheBumpGenerationResult)
+	success, err := handler.(geecache.GroupCache).BumpGeneration(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheBumpGenerationArgs() interface{} {
+	return geecache.NewGroupCacheBumpGenerationArgs()
+}
+
+func newGroupCacheBumpGenerationResult() interface{} {
+	return geecache.NewGroupCacheBumpGenerationResult()
+}
+
+func watchHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheWatchArgs)
+	realResult := result.(*geecache.GroupCacheWatchResult)
+	success, err := handler.(geecache.GroupCache).Watch(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheWatchArgs() interface{} {
+	return geecache.NewGroupCacheWatchArgs()
+}
+
+func newGroupCacheWatchResult() interface{} {
+	return geecache.NewGroupCacheWatchResult()
+}
+
+func publishHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCachePublishArgs)
+	realResult := result.(*geecache.GroupCachePublishResult)
+	success, err := handler.(geecache.GroupCache).Publish(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCachePublishArgs() interface{} {
+	return geecache.NewGroupCachePublishArgs()
+}
+
+func newGroupCachePublishResult() interface{} {
+	return geecache.NewGroupCachePublishResult()
+}
+
+func subscribeHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheSubscribeArgs)
+	realResult := result.(*geecache.GroupCacheSubscribeResult)
+	success, err := handler.(geecache.GroupCache).Subscribe(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheSubscribeArgs() interface{} {
+	return geecache.NewGroupCacheSubscribeArgs()
+}
+
+func newGroupCacheSubscribeResult() interface{} {
+	return geecache.NewGroupCacheSubscribeResult()
+}
+
+func unsubscribeHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheUnsubscribeArgs)
+	realResult := result.(*geecache.GroupCacheUnsubscribeResult)
+	success, err := handler.(geecache.GroupCache).Unsubscribe(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheUnsubscribeArgs() interface{} {
+	return geecache.NewGroupCacheUnsubscribeArgs()
+}
+
+func newGroupCacheUnsubscribeResult() interface{} {
+	return geecache.NewGroupCacheUnsubscribeResult()
+}
+
+func hSetHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheHSetArgs)
+	realResult := result.(*geecache.GroupCacheHSetResult)
+	success, err := handler.(geecache.GroupCache).HSet(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheHSetArgs() interface{} {
+	return geecache.NewGroupCacheHSetArgs()
+}
+
+func newGroupCacheHSetResult() interface{} {
+	return geecache.NewGroupCacheHSetResult()
+}
+
+func hGetHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheHGetArgs)
+	realResult := result.(*geecache.GroupCacheHGetResult)
+	success, err := handler.(geecache.GroupCache).HGet(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheHGetArgs() interface{} {
+	return geecache.NewGroupCacheHGetArgs()
+}
+
+func newGroupCacheHGetResult() interface{} {
+	return geecache.NewGroupCacheHGetResult()
+}
+
+func hDelHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheHDelArgs)
+	realResult := result.(*geecache.GroupCacheHDelResult)
+	success, err := handler.(geecache.GroupCache).HDel(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheHDelArgs() interface{} {
+	return geecache.NewGroupCacheHDelArgs()
+}
+
+func newGroupCacheHDelResult() interface{} {
+	return geecache.NewGroupCacheHDelResult()
+}
+
+func hGetAllHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheHGetAllArgs)
+	realResult := result.(*geecache.GroupCacheHGetAllResult)
+	success, err := handler.(geecache.GroupCache).HGetAll(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheHGetAllArgs() interface{} {
+	return geecache.NewGroupCacheHGetAllArgs()
+}
+
+func newGroupCacheHGetAllResult() interface{} {
+	return geecache.NewGroupCacheHGetAllResult()
+}
+
+func hIncrByHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*geecache.GroupCacheHIncrByArgs)
+	realResult := result.(*geecache.GroupCacheHIncrByResult)
+	success, err := handler.(geecache.GroupCache).HIncrBy(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newGroupCacheHIncrByArgs() interface{} {
+	return geecache.NewGroupCacheHIncrByArgs()
+}
+
+func newGroupCacheHIncrByResult() interface{} {
+	return geecache.NewGroupCacheHIncrByResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -333,3 +908,233 @@ func (p *kClient) SetMulti(ctx context.Context, req *geecache.SetMultiRequest) (
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) SetChunks(ctx context.Context, req *geecache.SetMultiRequest) (r *geecache.SetMultiResponse, err error) {
+	var _args geecache.GroupCacheSetChunksArgs
+	_args.Req = req
+	var _result geecache.GroupCacheSetChunksResult
+	if err = p.c.Call(ctx, "SetChunks", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetWithVersion(ctx context.Context, req *geecache.Request) (r *geecache.GetWithVersionResponse, err error) {
+	var _args geecache.GroupCacheGetWithVersionArgs
+	_args.Req = req
+	var _result geecache.GroupCacheGetWithVersionResult
+	if err = p.c.Call(ctx, "GetWithVersion", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CompareAndSet(ctx context.Context, req *geecache.CompareAndSetRequest) (r *geecache.CompareAndSetResponse, err error) {
+	var _args geecache.GroupCacheCompareAndSetArgs
+	_args.Req = req
+	var _result geecache.GroupCacheCompareAndSetResult
+	if err = p.c.Call(ctx, "CompareAndSet", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CompareAndDelete(ctx context.Context, req *geecache.CompareAndDeleteRequest) (r *geecache.CompareAndDeleteResponse, err error) {
+	var _args geecache.GroupCacheCompareAndDeleteArgs
+	_args.Req = req
+	var _result geecache.GroupCacheCompareAndDeleteResult
+	if err = p.c.Call(ctx, "CompareAndDelete", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Incr(ctx context.Context, req *geecache.IncrRequest) (r *geecache.IncrResponse, err error) {
+	var _args geecache.GroupCacheIncrArgs
+	_args.Req = req
+	var _result geecache.GroupCacheIncrResult
+	if err = p.c.Call(ctx, "Incr", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Add(ctx context.Context, req *geecache.SetRequest) (r *geecache.SetResponse, err error) {
+	var _args geecache.GroupCacheAddArgs
+	_args.Req = req
+	var _result geecache.GroupCacheAddResult
+	if err = p.c.Call(ctx, "Add", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Replace(ctx context.Context, req *geecache.SetRequest) (r *geecache.SetResponse, err error) {
+	var _args geecache.GroupCacheReplaceArgs
+	_args.Req = req
+	var _result geecache.GroupCacheReplaceResult
+	if err = p.c.Call(ctx, "Replace", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) LeaseGet(ctx context.Context, req *geecache.Request) (r *geecache.LeaseGetResponse, err error) {
+	var _args geecache.GroupCacheLeaseGetArgs
+	_args.Req = req
+	var _result geecache.GroupCacheLeaseGetResult
+	if err = p.c.Call(ctx, "LeaseGet", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) LeaseSet(ctx context.Context, req *geecache.LeaseSetRequest) (r *geecache.SetResponse, err error) {
+	var _args geecache.GroupCacheLeaseSetArgs
+	_args.Req = req
+	var _result geecache.GroupCacheLeaseSetResult
+	if err = p.c.Call(ctx, "LeaseSet", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Scan(ctx context.Context, req *geecache.ScanRequest) (r *geecache.ScanResponse, err error) {
+	var _args geecache.GroupCacheScanArgs
+	_args.Req = req
+	var _result geecache.GroupCacheScanResult
+	if err = p.c.Call(ctx, "Scan", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Dump(ctx context.Context, req *geecache.DumpRequest) (r *geecache.DumpResponse, err error) {
+	var _args geecache.GroupCacheDumpArgs
+	_args.Req = req
+	var _result geecache.GroupCacheDumpResult
+	if err = p.c.Call(ctx, "Dump", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) HotKeys(ctx context.Context, req *geecache.HotKeysRequest) (r *geecache.HotKeysResponse, err error) {
+	var _args geecache.GroupCacheHotKeysArgs
+	_args.Req = req
+	var _result geecache.GroupCacheHotKeysResult
+	if err = p.c.Call(ctx, "HotKeys", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) InvalidateTag(ctx context.Context, req *geecache.InvalidateTagRequest) (r *geecache.InvalidateTagResponse, err error) {
+	var _args geecache.GroupCacheInvalidateTagArgs
+	_args.Req = req
+	var _result geecache.GroupCacheInvalidateTagResult
+	if err = p.c.Call(ctx, "InvalidateTag", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) BumpGeneration(ctx context.Context, req *geecache.BumpGenerationRequest) (r *geecache.BumpGenerationResponse, err error) {
+	var _args geecache.GroupCacheBumpGenerationArgs
+	_args.Req = req
+	var _result geecache.GroupCacheBumpGenerationResult
+	if err = p.c.Call(ctx, "BumpGeneration", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Watch(ctx context.Context, req *geecache.WatchRequest) (r *geecache.WatchResponse, err error) {
+	var _args geecache.GroupCacheWatchArgs
+	_args.Req = req
+	var _result geecache.GroupCacheWatchResult
+	if err = p.c.Call(ctx, "Watch", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Publish(ctx context.Context, req *geecache.PublishRequest) (r *geecache.PublishResponse, err error) {
+	var _args geecache.GroupCachePublishArgs
+	_args.Req = req
+	var _result geecache.GroupCachePublishResult
+	if err = p.c.Call(ctx, "Publish", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Subscribe(ctx context.Context, req *geecache.SubscribeRequest) (r *geecache.SubscribeResponse, err error) {
+	var _args geecache.GroupCacheSubscribeArgs
+	_args.Req = req
+	var _result geecache.GroupCacheSubscribeResult
+	if err = p.c.Call(ctx, "Subscribe", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Unsubscribe(ctx context.Context, req *geecache.UnsubscribeRequest) (r *geecache.UnsubscribeResponse, err error) {
+	var _args geecache.GroupCacheUnsubscribeArgs
+	_args.Req = req
+	var _result geecache.GroupCacheUnsubscribeResult
+	if err = p.c.Call(ctx, "Unsubscribe", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) HSet(ctx context.Context, req *geecache.HSetRequest) (r *geecache.HSetResponse, err error) {
+	var _args geecache.GroupCacheHSetArgs
+	_args.Req = req
+	var _result geecache.GroupCacheHSetResult
+	if err = p.c.Call(ctx, "HSet", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) HGet(ctx context.Context, req *geecache.HGetRequest) (r *geecache.HGetResponse, err error) {
+	var _args geecache.GroupCacheHGetArgs
+	_args.Req = req
+	var _result geecache.GroupCacheHGetResult
+	if err = p.c.Call(ctx, "HGet", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) HDel(ctx context.Context, req *geecache.HDelRequest) (r *geecache.HDelResponse, err error) {
+	var _args geecache.GroupCacheHDelArgs
+	_args.Req = req
+	var _result geecache.GroupCacheHDelResult
+	if err = p.c.Call(ctx, "HDel", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) HGetAll(ctx context.Context, req *geecache.Request) (r *geecache.HGetAllResponse, err error) {
+	var _args geecache.GroupCacheHGetAllArgs
+	_args.Req = req
+	var _result geecache.GroupCacheHGetAllResult
+	if err = p.c.Call(ctx, "HGetAll", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) HIncrBy(ctx context.Context, req *geecache.HIncrByRequest) (r *geecache.IncrResponse, err error) {
+	var _args geecache.GroupCacheHIncrByArgs
+	_args.Req = req
+	var _result geecache.GroupCacheHIncrByResult
+	if err = p.c.Call(ctx, "HIncrBy", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
@@ -0,0 +1,234 @@
+package mygocache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLeaseInvalid 表示填充租约不存在、已过期或已被 Delete/Set 作废
+var ErrLeaseInvalid = errors.New("lease is invalid or expired")
+
+// DefaultLeaseTTL 默认填充租约的有效期（秒），超时后其他调用方可重新获得租约
+const DefaultLeaseTTL int64 = 10
+
+// LeaseResult 表示一次 LeaseGet 的结果，三种情况互斥：
+//   - Hit 为 true：命中缓存，Value 有效
+//   - Token 非 0：未命中，调用方获得填充租约，应加载数据后调用 LeaseSet
+//   - Retry 为 true：未命中，其他调用方正在填充，应稍后重试
+type LeaseResult struct {
+	Value ByteView
+	Hit   bool
+	Token uint64
+	Retry bool
+}
+
+// lease 表示一个尚未兑现的填充租约
+type lease struct {
+	token     uint64
+	expiresAt time.Time
+	// external 表示租约经 LeaseGet 发放给外部调用方，可能被遗弃，普通加载可以接管
+	external bool
+}
+
+// leaseTable 管理每个 key 至多一个未兑现的填充租约，与缓存一样按 key 的哈希分片以降低锁竞争。
+// token 在所有 key 之间唯一，作为 key 的租约代数：租约被作废、过期后重新发放或被接管时 token 随之改变，
+// 填充在缓存分片锁内确认 token 仍是 key 的当前租约后才写入
+type leaseTable struct {
+	shards    []leaseShard
+	shardMask uint32
+	nextToken uint64 // 原子递增
+	ttl       time.Duration
+}
+
+// leaseShard 是租约表的一个分片
+type leaseShard struct {
+	mu      sync.Mutex
+	leases  map[string]lease
+	sweepAt int // 租约数量达到该值时清理过期租约
+}
+
+// minLeaseSweep 单个分片清理过期租约的最低阈值
+const minLeaseSweep = 64
+
+func newLeaseTable(ttl int64, shardCount int) *leaseTable {
+	t := &leaseTable{
+		shards:    make([]leaseShard, shardCount),
+		shardMask: uint32(shardCount - 1),
+		ttl:       time.Duration(ttl) * time.Second,
+	}
+	for i := range t.shards {
+		t.shards[i].leases = make(map[string]lease)
+		t.shards[i].sweepAt = minLeaseSweep
+	}
+	return t
+}
+
+func (t *leaseTable) shard(key string) *leaseShard {
+	return &t.shards[fnvHash(key)&t.shardMask]
+}
+
+// grant 为 key 发放租约，已有未过期的租约时返回 ok=false。
+// external 为 true 表示经 LeaseGet 发放；为 false 的普通加载会接管 LeaseGet 发放的租约，
+// 避免被遗弃的外部租约在到期前阻止 Get 写回缓存，被接管的租约随后兑现失败
+func (t *leaseTable) grant(key string, external bool) (token uint64, ok bool) {
+	s := t.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if l, exists := s.leases[key]; exists && now.Before(l.expiresAt) && (external || !l.external) {
+		return 0, false
+	}
+	if len(s.leases) >= s.sweepAt {
+		s.sweep(now)
+	}
+
+	token = atomic.AddUint64(&t.nextToken, 1)
+	s.leases[key] = lease{token: token, expiresAt: now.Add(t.ttl), external: external}
+	return token, true
+}
+
+// redeem 兑现租约：仅当 token 是 key 当前未过期的租约时调用 fill，返回 fill 的结果，随后释放租约。
+// fill 在不持有租约表锁的情况下执行，调用方应在调用前完成编码等耗时工作；
+// fill 通过 cache.addIf 写入，并在分片锁内以 held 确认租约仍然有效，
+// 保证并发的 invalidate 要么发生在写入之前（拒绝填充），要么发生在写入之后（随后的删除或写入覆盖填充的值）
+func (t *leaseTable) redeem(key string, token uint64, fill func(held func() bool) bool) bool {
+	s := t.shard(key)
+	s.mu.Lock()
+	l, exists := s.leases[key]
+	valid := exists && l.token == token && time.Now().Before(l.expiresAt)
+	if exists && l.token == token && !valid {
+		delete(s.leases, key)
+	}
+	s.mu.Unlock()
+	if !valid {
+		return false
+	}
+	defer t.release(key, token)
+	return fill(func() bool { return t.held(key, token) })
+}
+
+// held 判断 token 是否仍是 key 当前的租约
+func (t *leaseTable) held(key string, token uint64) bool {
+	s := t.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, exists := s.leases[key]
+	return exists && l.token == token
+}
+
+// release 释放 key 上的租约而不填充，token 已不是当前租约时不做任何事
+func (t *leaseTable) release(key string, token uint64) {
+	s := t.shard(key)
+	s.mu.Lock()
+	if l, exists := s.leases[key]; exists && l.token == token {
+		delete(s.leases, key)
+	}
+	s.mu.Unlock()
+}
+
+// invalidate 作废 key 上未兑现的租约
+func (t *leaseTable) invalidate(key string) {
+	s := t.shard(key)
+	s.mu.Lock()
+	delete(s.leases, key)
+	s.mu.Unlock()
+}
+
+// invalidateAll 作废所有未兑现的租约
+func (t *leaseTable) invalidateAll() {
+	for i := range t.shards {
+		s := &t.shards[i]
+		s.mu.Lock()
+		s.leases = make(map[string]lease)
+		s.mu.Unlock()
+	}
+}
+
+// sweep 清理过期租约，并按剩余数量调整下次清理的阈值。调用方必须已持有 s.mu
+func (s *leaseShard) sweep(now time.Time) {
+	for key, l := range s.leases {
+		if !now.Before(l.expiresAt) {
+			delete(s.leases, key)
+		}
+	}
+	s.sweepAt = 2 * len(s.leases)
+	if s.sweepAt < minLeaseSweep {
+		s.sweepAt = minLeaseSweep
+	}
+}
+
+// LeaseGet 以旁路缓存（look-aside）方式读取 key：命中时返回值；
+// 未命中时不调用 Getter，而是向第一个调用方发放填充租约，其余调用方得到重试提示，
+// 从而避免大量调用方同时回源（惊群）。注册了 PeerPicker 时请求会被路由到 key 所属的节点
+func (g *Group) LeaseGet(key string) (LeaseResult, error) {
+	if key == "" {
+		return LeaseResult{}, fmt.Errorf("key is required")
+	}
+
+	if g.peers != nil {
+		if peer, ok := g.peers.PickPeer(key); ok {
+			if leaser, ok := peer.(PeerLeaser); ok {
+				return leaser.LeaseGet(g.name, key)
+			}
+		}
+	}
+	return g.leaseGetLocally(key)
+}
+
+// LeaseSet 使用 LeaseGet 发放的租约填充 key。租约已过期，或在此期间 key 被 Delete/Set
+// 导致租约作废时返回 ErrLeaseInvalid，避免慢加载方写回过期数据
+func (g *Group) LeaseSet(key string, value []byte, token uint64, ttl int64) error {
+	if g.peers != nil {
+		if peer, ok := g.peers.PickPeer(key); ok {
+			if leaser, ok := peer.(PeerLeaser); ok {
+				return leaser.LeaseSet(g.name, key, value, token, ttl)
+			}
+		}
+	}
+	return g.leaseSetLocally(key, value, token, ttl)
+}
+
+func (g *Group) leaseGetLocally(key string) (LeaseResult, error) {
+	ck := g.cacheKey(key)
+	v, ok, err := g.mainCache.get(ck)
+	if err != nil {
+		return LeaseResult{}, err
+	}
+	if ok && v.Len() == 0 {
+		g.mainCache.recordHit()
+		return LeaseResult{}, ErrKeyNotFound
+	}
+	if ok {
+		// 无法还原的值删除后按未命中处理，由调用方重新加载
+		if v, err = g.decodeFull(key, v); err == nil {
+			g.mainCache.recordHit()
+			return LeaseResult{Value: v, Hit: true}, nil
+		}
+		g.dropUndecodable(key, ck, err)
+	}
+
+	g.mainCache.recordMiss()
+	if token, ok := g.leases.grant(ck, true); ok {
+		return LeaseResult{Token: token}, nil
+	}
+	return LeaseResult{Retry: true}, nil
+}
+
+func (g *Group) leaseSetLocally(key string, value []byte, token uint64, ttl int64) error {
+	// 租约以缓存 key 记录，期间代数被递增时找不到租约，填充被拒绝
+	ck := g.cacheKey(key)
+	byteView := ByteView{b: cloneBytes(value)}
+	stored := g.encodeValue(key, byteView)
+	if !g.leases.redeem(ck, token, func(held func() bool) bool {
+		return g.mainCache.addIf(ck, stored, ttl, true, func(bool) bool { return held() })
+	}) {
+		return ErrLeaseInvalid
+	}
+	g.keyWritten(ck)
+	g.notifySet(key, byteView)
+	return nil
+}
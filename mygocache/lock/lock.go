@@ -0,0 +1,194 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"mygocache"
+)
+
+// ErrLocked 表示锁已被其他持有者占用
+var ErrLocked = errors.New("lock is held by another owner")
+
+// ErrNotHeld 表示锁已过期或已被他人重新获取，调用方不再持有该锁
+var ErrNotHeld = errors.New("lock not held")
+
+// keyPrefix 锁在缓存中使用的 key 前缀，避免与业务 key 冲突
+const keyPrefix = "__lock:"
+
+// fencePrefix 锁的 fencing token 使用的命名空间前缀
+const fencePrefix = "__fence:"
+
+// defaultRetryInterval Acquire 等待锁释放时的重试间隔
+const defaultRetryInterval = 50 * time.Millisecond
+
+// Token 表示一次成功获取的锁租约
+type Token struct {
+	// Name 锁名称
+	Name string
+	// ID 持有者标识，随机生成，写入锁条目的值中
+	ID string
+	// Fence fencing token，取自锁所属节点上命名空间 fencePrefix+Name 的代数，在写入锁条目之前递增。
+	// 代数不是缓存条目，不会被淘汰，也不受 Clear 与其他命名空间的 BumpGeneration 影响，
+	// 并随追加日志与快照持久化，节点重启后不会回退；
+	// 后获取锁的持有者的 Fence 总是更大，下游存储可据此拒绝过期持有者的写入
+	Fence uint64
+
+	version uint64 // 锁条目的当前版本号，Renew 后会更新
+}
+
+// store 是锁依赖的条件写入原语，本地 Group 与远程节点均实现该接口
+type store interface {
+	Add(key string, value []byte, ttl int64) (uint64, error)
+	CompareAndSet(key string, value []byte, version uint64, ttl int64) (uint64, error)
+	CompareAndDelete(key string, version uint64) error
+}
+
+// remoteStore 将条件写入转发到 key 所属的远程节点
+type remoteStore struct {
+	peer  mygocache.PeerWriter
+	group string
+}
+
+func (r remoteStore) Add(key string, value []byte, ttl int64) (uint64, error) {
+	return r.peer.Add(r.group, key, value, ttl)
+}
+
+func (r remoteStore) CompareAndSet(key string, value []byte, version uint64, ttl int64) (uint64, error) {
+	return r.peer.CompareAndSet(r.group, key, value, version, ttl)
+}
+
+func (r remoteStore) CompareAndDelete(key string, version uint64) error {
+	return r.peer.CompareAndDelete(r.group, key, version)
+}
+
+
+// Locker 基于 Group 的 Add/CompareAndSet/CompareAndDelete 实现租约锁。
+// 锁条目通过 PeerPicker 路由到 key 所属的节点，保证一把锁只存在于一个节点上；
+// 租约到期由缓存的 TTL 机制自动回收
+type Locker struct {
+	group         *mygocache.Group
+	peers         mygocache.PeerPicker
+	retryInterval time.Duration
+}
+
+// New 创建 Locker，peers 为 nil 时仅使用本地 Group
+func New(group *mygocache.Group, peers mygocache.PeerPicker) *Locker {
+	return &Locker{
+		group:         group,
+		peers:         peers,
+		retryInterval: defaultRetryInterval,
+	}
+}
+
+// store 返回锁 key 所属节点对应的存储
+func (l *Locker) store(key string) store {
+	if l.peers != nil {
+		if peer, ok := l.peers.PickPeer(key); ok {
+			if w, ok := peer.(mygocache.PeerWriter); ok {
+				return remoteStore{peer: w, group: l.group.Name()}
+			}
+		}
+	}
+	return l.group
+}
+
+// nextFence 递增锁所属节点上 name 的 fencing 命名空间的代数并返回新值
+func (l *Locker) nextFence(name string) (uint64, error) {
+	namespace := fencePrefix + name
+	if l.peers != nil {
+		if peer, ok := l.peers.PickPeer(namespace); ok {
+			bumper, ok := peer.(mygocache.PeerGenerationBumper)
+			if !ok {
+				return 0, errors.New("peer does not support BumpGeneration")
+			}
+			return bumper.BumpGeneration(l.group.Name(), namespace)
+		}
+	}
+	return l.group.BumpGeneration(namespace), nil
+}
+
+// TryAcquire 尝试获取锁，锁已被占用时立即返回 ErrLocked
+// leaseTTL 为租约时长（秒），必须大于 0
+func (l *Locker) TryAcquire(name string, leaseTTL int64) (*Token, error) {
+	if leaseTTL <= 0 {
+		return nil, errors.New("lease ttl must be positive")
+	}
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	// 先递增 fencing token 再写入锁条目：在 Add 之后递增时，已过期的持有者可能晚于后来者递增而拿到更大的 Fence；
+	// 先递增时最坏情况是持有者拿到比上一个持有者更小的 Fence，其写入被下游拒绝
+	fence, err := l.nextFence(name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := keyPrefix + name
+	version, err := l.store(key).Add(key, []byte(id), leaseTTL)
+	if err == mygocache.ErrKeyExists {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Token{Name: name, ID: id, Fence: fence, version: version}, nil
+}
+
+// Acquire 获取锁，锁被占用时按固定间隔重试，直到成功或 ctx 结束
+func (l *Locker) Acquire(ctx context.Context, name string, leaseTTL int64) (*Token, error) {
+	for {
+		token, err := l.TryAcquire(name, leaseTTL)
+		if err != ErrLocked {
+			return token, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(l.retryInterval):
+		}
+	}
+}
+
+// Renew 为仍持有的锁续期 leaseTTL 秒，锁已过期或被他人获取时返回 ErrNotHeld
+func (l *Locker) Renew(token *Token, leaseTTL int64) error {
+	if leaseTTL <= 0 {
+		return errors.New("lease ttl must be positive")
+	}
+
+	key := keyPrefix + token.Name
+	version, err := l.store(key).CompareAndSet(key, []byte(token.ID), token.version, leaseTTL)
+	if err == mygocache.ErrVersionMismatch || err == mygocache.ErrKeyNotFound {
+		return ErrNotHeld
+	}
+	if err != nil {
+		return err
+	}
+	token.version = version
+	return nil
+}
+
+// Release 释放锁，仅当调用方仍是持有者时成功，否则返回 ErrNotHeld
+func (l *Locker) Release(token *Token) error {
+	key := keyPrefix + token.Name
+	err := l.store(key).CompareAndDelete(key, token.version)
+	if err == mygocache.ErrVersionMismatch || err == mygocache.ErrKeyNotFound {
+		return ErrNotHeld
+	}
+	return err
+}
+
+// newID 生成随机的持有者标识
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
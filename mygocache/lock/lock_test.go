@@ -0,0 +1,220 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mygocache"
+)
+
+func newGroup(t *testing.T, name string) *mygocache.Group {
+	g, err := mygocache.NewGroup(name, mygocache.GetterFunc(
+		func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }),
+		mygocache.WithCacheBytes(2<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestAcquireRelease(t *testing.T) {
+	l := New(newGroup(t, "lock-basic"), nil)
+
+	token, err := l.TryAcquire("job", 10)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	if _, err := l.TryAcquire("job", 10); err != ErrLocked {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+
+	stranger := &Token{Name: "job", ID: "stranger", version: token.version + 1}
+	if err := l.Release(stranger); err != ErrNotHeld {
+		t.Fatalf("expected ErrNotHeld for non-holder, got %v", err)
+	}
+	if err := l.Renew(token, 10); err != nil {
+		t.Fatalf("renew failed: %v", err)
+	}
+	if err := l.Release(token); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+
+	next, err := l.TryAcquire("job", 10)
+	if err != nil {
+		t.Fatalf("re-acquire failed: %v", err)
+	}
+	if next.Fence <= token.Fence {
+		t.Fatalf("fencing token should increase, got %d after %d", next.Fence, token.Fence)
+	}
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	l := New(newGroup(t, "lock-expiry"), nil)
+
+	token, err := l.TryAcquire("job", 1)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	// TTL 以秒为精度，等待足够长的时间确保租约过期
+	time.Sleep(2100 * time.Millisecond)
+
+	next, err := l.TryAcquire("job", 10)
+	if err != nil {
+		t.Fatalf("acquire after lease expiry failed: %v", err)
+	}
+	if next.Fence <= token.Fence {
+		t.Fatalf("fencing token should increase, got %d after %d", next.Fence, token.Fence)
+	}
+	if err := l.Renew(token, 10); err != ErrNotHeld {
+		t.Fatalf("expected ErrNotHeld when renewing an expired lease, got %v", err)
+	}
+	if err := l.Release(token); err != ErrNotHeld {
+		t.Fatalf("expected ErrNotHeld when releasing an expired lease, got %v", err)
+	}
+}
+
+func TestFencePersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock.aof")
+	open := func() *mygocache.Group {
+		g, err := mygocache.NewGroup("lock-fence", mygocache.GetterFunc(
+			func(key string) ([]byte, error) { return nil, fmt.Errorf("%s not exist", key) }),
+			mygocache.WithCacheBytes(2<<10), mygocache.WithAppendLog(path, mygocache.FsyncAlways))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+
+	g := open()
+	l := New(g, nil)
+	var last uint64
+	for i := 0; i < 3; i++ {
+		token, err := l.TryAcquire("job", 10)
+		if err != nil {
+			t.Fatalf("acquire failed: %v", err)
+		}
+		last = token.Fence
+		if err := l.Release(token); err != nil {
+			t.Fatalf("release failed: %v", err)
+		}
+	}
+	g.Close()
+
+	// 重启后锁条目的版本号从头分配，Fence 仍从持久化的代数继续递增
+	g = open()
+	defer g.Close()
+	token, err := New(g, nil).TryAcquire("job", 10)
+	if err != nil {
+		t.Fatalf("acquire after restart failed: %v", err)
+	}
+	if token.Fence <= last {
+		t.Fatalf("fencing token should keep increasing across restarts, got %d after %d", token.Fence, last)
+	}
+}
+
+func TestFenceSurvivesClear(t *testing.T) {
+	g := newGroup(t, "lock-fence-clear")
+	defer g.Close()
+	l := New(g, nil)
+
+	token, err := l.TryAcquire("job", 10)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	last := token.Fence
+
+	// 清空缓存、作废整个 Group 的代数并写满缓存淘汰旧条目后，Fence 仍继续递增
+	for _, reset := range []func(){
+		func() { g.Clear() },
+		func() { g.BumpGeneration("") },
+		func() {
+			for i := 0; i < 200; i++ {
+				g.Set(fmt.Sprintf("filler:%d", i), make([]byte, 64), 0)
+			}
+		},
+	} {
+		reset()
+		next, err := l.TryAcquire("job", 10)
+		if err != nil {
+			t.Fatalf("acquire failed: %v", err)
+		}
+		if next.Fence <= last {
+			t.Fatalf("fencing token should keep increasing, got %d after %d", next.Fence, last)
+		}
+		last = next.Fence
+		l.Release(next)
+	}
+}
+
+func TestAcquireContext(t *testing.T) {
+	l := New(newGroup(t, "lock-ctx"), nil)
+	if _, err := l.TryAcquire("job", 10); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx, "job", 10); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+// groupPeer 模拟远程节点，将请求转发给另一个 Group
+type groupPeer struct {
+	group *mygocache.Group
+}
+
+func (p groupPeer) Get(group string, key string) ([]byte, error) {
+	view, err := p.group.Get(key)
+	return view.ByteSlice(), err
+}
+
+func (p groupPeer) Add(group string, key string, value []byte, ttl int64) (uint64, error) {
+	return p.group.Add(key, value, ttl)
+}
+
+func (p groupPeer) CompareAndSet(group string, key string, value []byte, version uint64, ttl int64) (uint64, error) {
+	return p.group.CompareAndSet(key, value, version, ttl)
+}
+
+func (p groupPeer) CompareAndDelete(group string, key string, version uint64) error {
+	return p.group.CompareAndDelete(key, version)
+}
+
+func (p groupPeer) BumpGeneration(group string, namespace string) (uint64, error) {
+	return p.group.BumpGeneration(namespace), nil
+}
+
+type remotePicker struct {
+	peer groupPeer
+}
+
+func (p remotePicker) PickPeer(key string) (mygocache.PeerGetter, bool) {
+	return p.peer, true
+}
+
+func TestRoutesToOwner(t *testing.T) {
+	local, owner := newGroup(t, "lock-local"), newGroup(t, "lock-owner")
+	l := New(local, remotePicker{groupPeer{owner}})
+
+	token, err := l.TryAcquire("job", 10)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	if _, _, err := owner.GetWithVersion(keyPrefix + "job"); err != nil {
+		t.Fatalf("lock should live on the owner: %v", err)
+	}
+	if _, err := local.Add(keyPrefix+"job", []byte("x"), 10); err != nil {
+		t.Fatalf("lock should not be stored locally: %v", err)
+	}
+	if next := owner.BumpGeneration(fencePrefix + "job"); next != token.Fence+1 {
+		t.Fatalf("fence should be taken on the owner, got %d after %d", next, token.Fence)
+	}
+	if err := l.Release(token); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+}
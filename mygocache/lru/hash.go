@@ -0,0 +1,61 @@
+package lru
+
+// Hash 是哈希类型的值，由若干字段组成，占用字节数按字段累计，
+// 修改单个字段时缓存只按增减的字节数调整容量。
+// Hash 不是并发安全的，只能在 UpdateHash 与 View 的回调中访问
+type Hash struct {
+	fields map[string][]byte
+	size   int // 所有字段名与字段值的字节数之和
+}
+
+// NewHash 创建一个空的 Hash
+func NewHash() *Hash {
+	return &Hash{fields: make(map[string][]byte)}
+}
+
+// Len 返回 Hash 占用的字节数，实现 Value 接口
+func (h *Hash) Len() int {
+	return h.size
+}
+
+// Count 返回字段数量
+func (h *Hash) Count() int {
+	return len(h.fields)
+}
+
+// Get 返回字段的值，返回的切片不得修改
+func (h *Hash) Get(field string) ([]byte, bool) {
+	v, ok := h.fields[field]
+	return v, ok
+}
+
+// Set 设置字段的值，字段原本不存在时返回 true。value 不会被复制，调用方之后不得修改
+func (h *Hash) Set(field string, value []byte) bool {
+	old, ok := h.fields[field]
+	if ok {
+		h.size += len(value) - len(old)
+	} else {
+		h.size += len(field) + len(value)
+	}
+	h.fields[field] = value
+	return !ok
+}
+
+// Delete 删除字段，字段存在时返回 true
+func (h *Hash) Delete(field string) bool {
+	old, ok := h.fields[field]
+	if ok {
+		h.size -= len(field) + len(old)
+		delete(h.fields, field)
+	}
+	return ok
+}
+
+// Range 遍历所有字段（顺序不固定），fn 返回 false 时停止遍历
+func (h *Hash) Range(fn func(field string, value []byte) bool) {
+	for field, value := range h.fields {
+		if !fn(field, value) {
+			return
+		}
+	}
+}
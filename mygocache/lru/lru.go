@@ -3,6 +3,7 @@ package lru
 import (
 	"container/list"
 	"mygocache/pool"
+	"mygocache/sortedset"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -14,11 +15,16 @@ type Cache struct {
 	nbytes   int64                    // 当前缓存的字节数
 	ll       *list.List               // 双向链表，用于实现 LRU
 	cache    map[string]*list.Element // 键到链表元素的映射
+	order    *sortedset.Set[keyPos]   // 按哈希排序的键，供 RangeAfter 分页遍历
 	// 优先级队列（最小堆），用于过期管理
 	heap    []*pool.HeapItem // 最小堆数组
 	heapMap map[string]int   // 键到堆索引的映射
 	// 当条目被删除时执行的回调函数
 	OnEvicted func(key string, value Value)
+	// 当条目被删除或覆盖时执行的回调函数，附带原因；覆盖时 value 为旧值
+	OnRemoved func(key string, value Value, reason RemoveReason)
+	// 当条目因容量不足被淘汰时执行的回调函数，附带过期时间，在 OnRemoved 之前调用
+	OnCapacityEvicted func(key string, value Value, expiresAt int64)
 	// 过期协程的停止信号
 	stopChan  chan struct{}
 	closeOnce sync.Once // 保证 Close 幂等
@@ -30,6 +36,8 @@ type Cache struct {
 	// 统计信息
 	hits   int64 // 缓存命中次数
 	misses int64 // 缓存未命中次数
+	// 最近一次分配的版本号，单调递增
+	version uint64
 }
 
 // entry 表示缓存中的一个条目
@@ -37,6 +45,7 @@ type entry struct {
 	key       string // 键
 	value     Value  // 值
 	expiresAt int64  // 过期时间戳，0 表示永不过期
+	version   uint64 // 版本号（CAS token），每次写入都会更新
 }
 
 // Value 接口用于计算值占用的字节数
@@ -44,6 +53,31 @@ type Value interface {
 	Len() int // 返回值占用的字节数
 }
 
+// KeepTTL 作为 Update 回调返回的 ttl 时，表示沿用条目原有的过期时间
+const KeepTTL int64 = -1
+
+// RemoveReason 表示条目被移除或覆盖的原因
+type RemoveReason int
+
+const (
+	// RemoveEvicted 容量不足被淘汰
+	RemoveEvicted RemoveReason = iota
+	// RemoveExpired 已过期，由过期检查协程或访问时惰性清理
+	RemoveExpired
+	// RemoveDeleted 被显式删除（Remove、Clear、CompareAndRemove）
+	RemoveDeleted
+	// RemoveReplaced 被新值覆盖
+	RemoveReplaced
+)
+
+// replaceReason 返回覆盖写入旧条目时上报的原因：旧条目已过期但尚未被清理时视为过期
+func replaceReason(expiresAt int64) RemoveReason {
+	if expiresAt > 0 && expiresAt < time.Now().Unix() {
+		return RemoveExpired
+	}
+	return RemoveReplaced
+}
+
 // New 创建一个新的缓存实例
 // maxBytes 是缓存的最大字节数
 // onEvicted 是当条目被删除时执行的回调函数
@@ -52,6 +86,7 @@ func New(maxBytes int64, onEvicted func(string, Value)) *Cache {
 		maxBytes:     maxBytes,
 		ll:           list.New(),
 		cache:        make(map[string]*list.Element),
+		order:        newKeyOrder(),
 		heap:         make([]*pool.HeapItem, 0),
 		heapMap:      make(map[string]int),
 		OnEvicted:    onEvicted,
@@ -64,6 +99,14 @@ func New(maxBytes int64, onEvicted func(string, Value)) *Cache {
 	return c
 }
 
+// expireTime 将 TTL（秒）换算为过期时间戳，ttl <= 0 表示永不过期
+func expireTime(ttl int64) int64 {
+	if ttl > 0 {
+		return time.Now().Unix() + ttl
+	}
+	return 0
+}
+
 // Close 停止过期检查协程（幂等，可多次调用）
 func (c *Cache) Close() {
 	c.closeOnce.Do(func() {
@@ -76,17 +119,23 @@ func (c *Cache) Close() {
 // value 是缓存的值
 // ttl 是生存时间（秒），0 表示永不过期
 func (c *Cache) Add(key string, value Value, ttl int64) {
-	var expiresAt int64
-	if ttl > 0 {
-		expiresAt = time.Now().Unix() + ttl
-	}
+	c.set(key, value, expireTime(ttl))
+}
+
+// set 写入一个值并返回为其分配的新版本号
+func (c *Cache) set(key string, value Value, expiresAt int64) uint64 {
+	version := c.nextVersion()
 
 	if ele, ok := c.cache[key]; ok {
 		// 更新现有条目
 		c.ll.MoveToFront(ele)
 		kv := ele.Value.(*entry)
+		if c.OnRemoved != nil {
+			c.OnRemoved(key, kv.value, replaceReason(kv.expiresAt))
+		}
 		c.nbytes += int64(value.Len()) - int64(kv.value.Len())
 		kv.value = value
+		kv.version = version
 
 		// 更新过期时间
 		oldExpiresAt := kv.expiresAt
@@ -107,8 +156,9 @@ func (c *Cache) Add(key string, value Value, ttl int64) {
 		}
 	} else {
 		// 添加新条目
-		ele := c.ll.PushFront(&entry{key, value, expiresAt})
+		ele := c.ll.PushFront(&entry{key, value, expiresAt, version})
 		c.cache[key] = ele
+		c.order.Insert(posOf(key))
 		c.nbytes += int64(len(key)) + int64(value.Len())
 
 		// 如果有过期时间，添加到堆中
@@ -123,45 +173,211 @@ func (c *Cache) Add(key string, value Value, ttl int64) {
 	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
 		c.RemoveOldest()
 	}
+	return version
+}
+
+// nextVersion 分配一个新的版本号
+func (c *Cache) nextVersion() uint64 {
+	c.version++
+	return c.version
+}
+
+// lookup 返回 key 对应的未过期条目，过期条目会被顺带删除（惰性过期）
+func (c *Cache) lookup(key string) (*list.Element, bool) {
+	ele, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	kv := ele.Value.(*entry)
+	if kv.expiresAt > 0 && kv.expiresAt < time.Now().Unix() {
+		// 过期，删除该项
+		c.removeEntry(ele, RemoveExpired)
+		return nil, false
+	}
+	return ele, true
 }
 
 // Get 查找并返回缓存中键对应的值（惰性过期）
 // key 是要查找的键
 // 返回值和是否找到的标志
 func (c *Cache) Get(key string) (value Value, ok bool) {
-	if ele, ok := c.cache[key]; ok {
+	value, _, ok = c.GetWithVersion(key)
+	return
+}
+
+// GetWithVersion 查找并返回缓存中键对应的值及其版本号（惰性过期）
+func (c *Cache) GetWithVersion(key string) (value Value, version uint64, ok bool) {
+	ele, ok := c.lookup(key)
+	if !ok {
+		return nil, 0, false
+	}
+	// 未过期，移到队首
+	c.ll.MoveToFront(ele)
+	atomic.AddInt64(&c.hits, 1)
+	kv := ele.Value.(*entry)
+	return kv.value, kv.version, true
+}
+
+// CompareAndSwap 仅当 key 的当前版本号等于 version 时写入新值
+// 成功时返回新的版本号；失败时返回当前版本号（key 不存在或已过期时为 0）
+func (c *Cache) CompareAndSwap(key string, value Value, version uint64, ttl int64) (uint64, bool) {
+	ele, ok := c.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	if current := ele.Value.(*entry).version; current != version {
+		return current, false
+	}
+	return c.set(key, value, expireTime(ttl)), true
+}
+
+// Update 原子地读取并改写 key 对应的值，返回写入后的版本号
+// fn 接收当前未过期的值（不存在时 found=false），返回新值及其 TTL；
+// 返回的 ttl 为 KeepTTL 时沿用已有条目的过期时间，返回 error 时放弃写入
+func (c *Cache) Update(key string, fn func(old Value, found bool) (Value, int64, error)) (uint64, error) {
+	var old Value
+	var oldExpiresAt int64
+	ele, found := c.lookup(key)
+	if found {
 		kv := ele.Value.(*entry)
-		// 检查是否过期（惰性过期）
-		if kv.expiresAt > 0 && kv.expiresAt < time.Now().Unix() {
-			// 过期，删除该项
-			c.removeEntry(ele)
-			return nil, false
+		old, oldExpiresAt = kv.value, kv.expiresAt
+	}
+
+	value, ttl, err := fn(old, found)
+	if err != nil {
+		return 0, err
+	}
+	expiresAt := expireTime(ttl)
+	if ttl == KeepTTL {
+		expiresAt = oldExpiresAt
+	}
+	return c.set(key, value, expiresAt), nil
+}
+
+// CompareAndRemove 仅当 key 的当前版本号等于 version 时删除该条目
+// 失败时返回当前版本号（key 不存在或已过期时为 0）
+func (c *Cache) CompareAndRemove(key string, version uint64) (uint64, bool) {
+	ele, ok := c.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	if current := ele.Value.(*entry).version; current != version {
+		return current, false
+	}
+	c.removeEntry(ele, RemoveDeleted)
+	return version, true
+}
+
+// UpdateHash 原子地修改 key 对应的哈希值，按修改前后的字节数差调整占用容量，返回修改后的版本号。
+// 当前值为 *Hash 时 fn 在其上就地修改，old 即为该 Hash；否则 fn 收到新建的空 Hash 与当前值 old
+// （不存在或已过期时为 nil），修改后的 Hash 覆盖 old 写入。
+// fn 返回的 ttl 语义同 Update；返回 error 时放弃修改，此前不得修改 h。
+// 修改后 Hash 为空时删除该 key（新建的空 Hash 不写入），此时返回的版本号为 0
+func (c *Cache) UpdateHash(key string, fn func(h *Hash, old Value) (int64, error)) (uint64, error) {
+	var old Value
+	var oldExpiresAt int64
+	ele, found := c.lookup(key)
+	if found {
+		kv := ele.Value.(*entry)
+		old, oldExpiresAt = kv.value, kv.expiresAt
+	}
+
+	h, inPlace := old.(*Hash)
+	if !inPlace {
+		h = NewHash()
+	}
+	before := h.Len()
+	ttl, err := fn(h, old)
+	if err != nil {
+		return 0, err
+	}
+
+	if !inPlace {
+		if h.Count() == 0 {
+			return 0, nil
 		}
-		// 未过期，移到队首
-		c.ll.MoveToFront(ele)
-		atomic.AddInt64(&c.hits, 1)
-		return kv.value, true
+		expiresAt := expireTime(ttl)
+		if ttl == KeepTTL {
+			expiresAt = oldExpiresAt
+		}
+		return c.set(key, h, expiresAt), nil
 	}
-	return
+
+	// 就地修改：字段已变化，按差值调整字节数
+	c.nbytes += int64(h.Len() - before)
+	if h.Count() == 0 {
+		c.removeEntry(ele, RemoveDeleted)
+		return 0, nil
+	}
+	kv := ele.Value.(*entry)
+	kv.version = c.nextVersion()
+	c.ll.MoveToFront(ele)
+	if ttl != KeepTTL {
+		if expiresAt := expireTime(ttl); expiresAt != kv.expiresAt {
+			c.heapMu.Lock()
+			if kv.expiresAt > 0 {
+				c.removeFromHeap(key)
+			}
+			if expiresAt > 0 {
+				c.addToHeap(key, expiresAt)
+			}
+			c.heapMu.Unlock()
+			kv.expiresAt = expiresAt
+		}
+	}
+
+	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
+		c.RemoveOldest()
+	}
+	return kv.version, nil
+}
+
+// View 以 fn 访问 key 对应的未过期值（惰性过期），命中时将条目移到队首并返回 true。
+// 用于读取 *Hash 等会被就地修改的值，fn 返回后不得继续持有 value
+func (c *Cache) View(key string, fn func(value Value)) bool {
+	ele, ok := c.lookup(key)
+	if !ok {
+		return false
+	}
+	c.ll.MoveToFront(ele)
+	atomic.AddInt64(&c.hits, 1)
+	fn(ele.Value.(*entry).value)
+	return true
+}
+
+// Peek 以 fn 访问 key 对应的未过期值及其过期时间戳（0 表示永不过期），命中时返回 true。
+// 与 View 不同，不更新 LRU 顺序与命中统计，也不清理过期条目。fn 返回后不得继续持有 value
+func (c *Cache) Peek(key string, fn func(value Value, expiresAt int64)) bool {
+	ele, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	kv := ele.Value.(*entry)
+	if kv.expiresAt > 0 && kv.expiresAt < time.Now().Unix() {
+		return false
+	}
+	fn(kv.value, kv.expiresAt)
+	return true
 }
 
 // RemoveOldest 删除最旧的条目
 func (c *Cache) RemoveOldest() {
 	ele := c.ll.Back()
 	if ele != nil {
-		c.removeEntry(ele)
+		c.removeEntry(ele, RemoveEvicted)
 	}
 }
 
 // removeEntry 从缓存和堆中删除一个条目
-// ele 是要删除的链表元素
-func (c *Cache) removeEntry(ele *list.Element) {
+// ele 是要删除的链表元素，reason 是删除原因
+func (c *Cache) removeEntry(ele *list.Element, reason RemoveReason) {
 	kv := ele.Value.(*entry)
 	key := kv.key
 
 	// 从链表中删除
 	c.ll.Remove(ele)
 	delete(c.cache, key)
+	c.order.Delete(posOf(key))
 	c.nbytes -= int64(len(key)) + int64(kv.value.Len())
 
 	// 如果有过期时间，从堆中删除
@@ -172,9 +388,15 @@ func (c *Cache) removeEntry(ele *list.Element) {
 	}
 
 	// 调用删除回调
+	if reason == RemoveEvicted && c.OnCapacityEvicted != nil {
+		c.OnCapacityEvicted(key, kv.value, kv.expiresAt)
+	}
 	if c.OnEvicted != nil {
 		c.OnEvicted(key, kv.value)
 	}
+	if c.OnRemoved != nil {
+		c.OnRemoved(key, kv.value, reason)
+	}
 }
 
 // addToHeap 将键添加到过期堆中
@@ -292,7 +514,7 @@ func (c *Cache) checkExpiration() {
 
 		// 如果缓存中还存在，删除它
 		if ele, ok := c.cache[key]; ok {
-			c.removeEntry(ele)
+			c.removeEntry(ele, RemoveExpired)
 		}
 
 		c.heapMu.Lock()
@@ -305,10 +527,62 @@ func (c *Cache) Len() int {
 	return c.ll.Len()
 }
 
+// Range 按从新到旧的顺序遍历未过期的条目，fn 返回 false 时停止遍历。
+// 遍历期间不得修改缓存
+func (c *Cache) Range(fn func(key string, value Value) bool) {
+	c.RangeWithExpiry(func(key string, value Value, expiresAt int64) bool {
+		return fn(key, value)
+	})
+}
+
+// RangeWithExpiry 同 Range，额外提供条目的过期时间戳（0 表示永不过期）
+func (c *Cache) RangeWithExpiry(fn func(key string, value Value, expiresAt int64) bool) {
+	now := time.Now().Unix()
+	for ele := c.ll.Front(); ele != nil; ele = ele.Next() {
+		kv := ele.Value.(*entry)
+		if kv.expiresAt > 0 && kv.expiresAt < now {
+			continue
+		}
+		if !fn(kv.key, kv.value, kv.expiresAt) {
+			return
+		}
+	}
+}
+
+// RangeAfter 按键的哈希顺序遍历排在 after 之后（after 为空时从头开始）的未过期条目，fn 返回 false 时停止遍历。
+// 该顺序不随访问与写入改变，以上一次遍历到的键作为 after 即可分页遍历，每页只访问该页的条目。
+// 遍历期间不得修改缓存
+func (c *Cache) RangeAfter(after string, fn func(key string, value Value) bool) {
+	now := time.Now().Unix()
+	rangeOrder(c.order, after, func(key string) bool {
+		kv := c.cache[key].Value.(*entry)
+		if kv.expiresAt > 0 && kv.expiresAt < now {
+			return true
+		}
+		return fn(key, kv.value)
+	})
+}
+
+// Keys 返回所有未过期条目的键，按从新到旧排列
+func (c *Cache) Keys() []string {
+	keys := make([]string, 0, c.ll.Len())
+	c.Range(func(key string, value Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Contains 判断键是否在缓存中，不更新 LRU 顺序，也不检查过期
+func (c *Cache) Contains(key string) bool {
+	_, ok := c.cache[key]
+	return ok
+}
+
 // Remove 删除指定键的条目
 func (c *Cache) Remove(key string) {
 	if ele, ok := c.cache[key]; ok {
-		c.removeEntry(ele)
+		c.removeEntry(ele, RemoveDeleted)
 	}
 }
 
@@ -317,7 +591,7 @@ func (c *Cache) Clear() {
 	// 遍历所有条目并删除
 	for key := range c.cache {
 		if ele, ok := c.cache[key]; ok {
-			c.removeEntry(ele)
+			c.removeEntry(ele, RemoveDeleted)
 		}
 	}
 }
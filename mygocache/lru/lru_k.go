@@ -3,6 +3,8 @@ package lru
 import (
 	"container/list"
 	"mygocache/pool"
+	"mygocache/sortedset"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -10,10 +12,11 @@ import (
 
 // LRUCache 是一个带过期时间支持的 LRU-K 缓存。
 type LRUCache struct {
-	maxBytes int64      // 缓存的最大字节数
-	nbytes   int64      // 当前缓存的字节数
-	ll       *list.List // 双向链表，用于实现 LRU
-	cache    sync.Map   // 键到链表元素的映射 (string -> *list.Element)
+	maxBytes int64                  // 缓存的最大字节数
+	nbytes   int64                  // 当前缓存的字节数
+	ll       *list.List             // 双向链表，用于实现 LRU
+	cache    sync.Map               // 键到链表元素的映射 (string -> *list.Element)
+	order    *sortedset.Set[keyPos] // 按哈希排序的键，供 RangeAfter 分页遍历（受 mu 保护）
 
 	// LRU-K 相关
 	k       int      // K 值，表示需要访问 K 次才进入缓存
@@ -25,6 +28,10 @@ type LRUCache struct {
 
 	// 当条目被删除时执行的回调函数
 	OnEvicted func(key string, value Value)
+	// 当条目被删除或覆盖时执行的回调函数，附带原因；覆盖时 value 为旧值
+	OnRemoved func(key string, value Value, reason RemoveReason)
+	// 当条目因容量不足被淘汰时执行的回调函数，附带过期时间，在 OnRemoved 之前调用
+	OnCapacityEvicted func(key string, value Value, expiresAt int64)
 
 	// 过期协程的停止信号
 	stopChan  chan struct{}
@@ -39,6 +46,9 @@ type LRUCache struct {
 	// 统计信息
 	hits   int64 // 缓存命中次数
 	misses int64 // 缓存未命中次数
+
+	// 最近一次分配的版本号，单调递增（受 mu 保护）
+	version uint64
 }
 
 // entry 表示缓存中的一个条目
@@ -47,6 +57,7 @@ type lruEntry struct {
 	value      Value  // 值
 	expiresAt  int64  // 过期时间戳，0 表示永不过期
 	lastAccess int64  // 最后访问时间戳
+	version    uint64 // 版本号（CAS token），每次写入都会更新
 }
 
 // historyEntry 表示一个 key 的访问历史，内置锁保证并发安全
@@ -68,6 +79,7 @@ func NewLRUK(maxBytes int64, k int, onEvicted func(string, Value)) *LRUCache {
 		maxBytes:  maxBytes,
 		ll:        list.New(),
 		cache:     sync.Map{},
+		order:     newKeyOrder(),
 		k:         k,
 		history:   sync.Map{},
 		heap:      make([]*pool.HeapItem, 0),
@@ -93,46 +105,46 @@ func (c *LRUCache) Close() {
 // value 是缓存的值
 // ttl 是生存时间（秒），0 表示永不过期
 func (c *LRUCache) Add(key string, value Value, ttl int64) {
-	var expiresAt int64
-	if ttl > 0 {
-		expiresAt = time.Now().Unix() + ttl
+	c.AddIf(key, value, ttl, false, nil)
+}
+
+// DirectAdd 直接将值加入缓存，跳过 LRU-K 的 K 次访问历史检查。
+// 用于显式 Set 操作，确保写入的值立即可读。
+func (c *LRUCache) DirectAdd(key string, value Value, ttl int64) {
+	c.AddIf(key, value, ttl, true, nil)
+}
+
+// AddIf 同 Add（direct 为 true 时同 DirectAdd），但仅当 cond 返回 true 时写入，返回是否写入。
+// cond 在持有 c.mu 期间、写入之前调用，exists 表示 key 当前是否在缓存中，cond 中不得调用本缓存的方法；
+// cond 为 nil 时总是写入。未达到 K 次访问的 key 只记录访问历史，不调用 cond
+func (c *LRUCache) AddIf(key string, value Value, ttl int64, direct bool, cond func(exists bool) bool) bool {
+	expiresAt := expireTime(ttl)
+	if direct {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		_, exists := c.cache.Load(key)
+		if cond != nil && !cond(exists) {
+			return false
+		}
+		c.setLocked(key, value, expiresAt)
+		return true
 	}
 
 	// 检查是否已存在
 	if _, ok := c.cache.Load(key); ok {
 		c.mu.Lock()
 		// TOCTOU 修复：获取锁后重新验证 key 是否仍存在
-		ele, ok := c.cache.Load(key)
-		if !ok {
-			c.mu.Unlock()
-			// key 已被淘汰，走新增路径
-		} else {
+		if _, ok := c.cache.Load(key); ok {
 			// 更新现有条目
-			listEle := ele.(*list.Element)
-			c.ll.MoveToFront(listEle)
-			kv := listEle.Value.(*lruEntry)
-			c.nbytes += int64(value.Len()) - int64(kv.value.Len())
-			kv.value = value
-			kv.lastAccess = time.Now().Unix()
-
-			// 更新过期时间
-			oldExpiresAt := kv.expiresAt
-			kv.expiresAt = expiresAt
-
-			// 如果过期时间发生变化，更新堆
-			if oldExpiresAt > 0 || expiresAt > 0 {
-				c.heapMu.Lock()
-				if oldExpiresAt > 0 {
-					c.removeFromHeap(key)
-				}
-				if expiresAt > 0 {
-					c.addToHeap(key, expiresAt)
-				}
-				c.heapMu.Unlock()
+			defer c.mu.Unlock()
+			if cond != nil && !cond(true) {
+				return false
 			}
-			c.mu.Unlock()
-			return
+			c.setLocked(key, value, expiresAt)
+			return true
 		}
+		c.mu.Unlock()
+		// key 已被淘汰，走新增路径
 	}
 
 	// 新增路径：获取或创建访问历史
@@ -150,25 +162,71 @@ func (c *LRUCache) Add(key string, value Value, ttl int64) {
 	shouldCache := len(he.ts) >= c.k
 	he.mu.Unlock()
 
-	if shouldCache {
-		// 已达 K 次访问，清理 history 防止内存泄漏
-		c.history.Delete(key)
+	if !shouldCache {
+		return false
+	}
+	// 已达 K 次访问，清理 history 防止内存泄漏
+	c.history.Delete(key)
 
-		c.mu.Lock()
-		// 再次检查，避免并发问题
-		if _, ok := c.cache.Load(key); ok {
-			c.mu.Unlock()
-			return
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// 再次检查，避免并发问题
+	if _, ok := c.cache.Load(key); ok {
+		return false
+	}
+	if cond != nil && !cond(false) {
+		return false
+	}
+	// 添加新条目到缓存
+	c.setLocked(key, value, expiresAt)
+	return true
+}
+
+// setLocked 更新已有条目或插入新条目，返回为其分配的新版本号。
+// 调用方必须已持有 c.mu
+func (c *LRUCache) setLocked(key string, value Value, expiresAt int64) uint64 {
+	currentTime := time.Now().Unix()
+	c.version++
+	version := c.version
+
+	if ele, ok := c.cache.Load(key); ok {
+		// 更新现有条目
+		listEle := ele.(*list.Element)
+		c.ll.MoveToFront(listEle)
+		kv := listEle.Value.(*lruEntry)
+		if c.OnRemoved != nil {
+			c.OnRemoved(key, kv.value, replaceReason(kv.expiresAt))
 		}
+		c.nbytes += int64(value.Len()) - int64(kv.value.Len())
+		kv.value = value
+		kv.lastAccess = currentTime
+		kv.version = version
+
+		// 更新过期时间
+		oldExpiresAt := kv.expiresAt
+		kv.expiresAt = expiresAt
 
-		// 添加新条目到缓存
+		// 如果过期时间发生变化，更新堆
+		if oldExpiresAt > 0 || expiresAt > 0 {
+			c.heapMu.Lock()
+			if oldExpiresAt > 0 {
+				c.removeFromHeap(key)
+			}
+			if expiresAt > 0 {
+				c.addToHeap(key, expiresAt)
+			}
+			c.heapMu.Unlock()
+		}
+	} else {
 		ele := c.ll.PushFront(&lruEntry{
 			key:        key,
 			value:      value,
 			expiresAt:  expiresAt,
 			lastAccess: currentTime,
+			version:    version,
 		})
 		c.cache.Store(key, ele)
+		c.order.Insert(posOf(key))
 		c.nbytes += int64(len(key)) + int64(value.Len())
 
 		// 如果有过期时间，添加到堆中
@@ -177,88 +235,41 @@ func (c *LRUCache) Add(key string, value Value, ttl int64) {
 			c.addToHeap(key, expiresAt)
 			c.heapMu.Unlock()
 		}
-
-		// 清理超出容量的项
-		for c.maxBytes != 0 && c.maxBytes < c.nbytes {
-			c.removeOldest()
-		}
-		c.mu.Unlock()
-	}
-}
-
-// DirectAdd 直接将值加入缓存，跳过 LRU-K 的 K 次访问历史检查。
-// 用于显式 Set 操作，确保写入的值立即可读。
-func (c *LRUCache) DirectAdd(key string, value Value, ttl int64) {
-	var expiresAt int64
-	if ttl > 0 {
-		expiresAt = time.Now().Unix() + ttl
-	}
-
-	currentTime := time.Now().Unix()
-
-	// 检查是否已存在
-	if _, ok := c.cache.Load(key); ok {
-		c.mu.Lock()
-		ele, ok := c.cache.Load(key)
-		if !ok {
-			c.mu.Unlock()
-		} else {
-			listEle := ele.(*list.Element)
-			c.ll.MoveToFront(listEle)
-			kv := listEle.Value.(*lruEntry)
-			c.nbytes += int64(value.Len()) - int64(kv.value.Len())
-			kv.value = value
-			kv.lastAccess = currentTime
-
-			oldExpiresAt := kv.expiresAt
-			kv.expiresAt = expiresAt
-			if oldExpiresAt > 0 || expiresAt > 0 {
-				c.heapMu.Lock()
-				if oldExpiresAt > 0 {
-					c.removeFromHeap(key)
-				}
-				if expiresAt > 0 {
-					c.addToHeap(key, expiresAt)
-				}
-				c.heapMu.Unlock()
-			}
-			c.mu.Unlock()
-			return
-		}
 	}
 
-	// 直接添加到缓存，不检查历史访问次数
-	c.mu.Lock()
-	if _, ok := c.cache.Load(key); ok {
-		c.mu.Unlock()
-		return
+	// 清理超出容量的项
+	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
+		c.removeOldest()
 	}
+	return version
+}
 
-	ele := c.ll.PushFront(&lruEntry{
-		key:        key,
-		value:      value,
-		expiresAt:  expiresAt,
-		lastAccess: currentTime,
-	})
-	c.cache.Store(key, ele)
-	c.nbytes += int64(len(key)) + int64(value.Len())
-
-	if expiresAt > 0 {
-		c.heapMu.Lock()
-		c.addToHeap(key, expiresAt)
-		c.heapMu.Unlock()
+// lookupLocked 返回 key 对应的未过期条目，过期条目会被顺带删除（惰性过期）。
+// 调用方必须已持有 c.mu
+func (c *LRUCache) lookupLocked(key string) (*list.Element, bool) {
+	ele, ok := c.cache.Load(key)
+	if !ok {
+		return nil, false
 	}
-
-	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
-		c.removeOldest()
+	listEle := ele.(*list.Element)
+	kv := listEle.Value.(*lruEntry)
+	if kv.expiresAt > 0 && kv.expiresAt < time.Now().Unix() {
+		c.removeEntry(listEle, RemoveExpired)
+		return nil, false
 	}
-	c.mu.Unlock()
+	return listEle, true
 }
 
 // Get 查找并返回缓存中键对应的值（惰性过期）
 // key 是要查找的键
 // 返回值和是否找到的标志
 func (c *LRUCache) Get(key string) (value Value, ok bool) {
+	value, _, ok = c.GetWithVersion(key)
+	return
+}
+
+// GetWithVersion 查找并返回缓存中键对应的值及其版本号（惰性过期）
+func (c *LRUCache) GetWithVersion(key string) (value Value, version uint64, ok bool) {
 	// 检查缓存
 	if _, ok := c.cache.Load(key); ok {
 		c.mu.Lock()
@@ -273,9 +284,9 @@ func (c *LRUCache) Get(key string) (value Value, ok bool) {
 			// 检查是否过期（惰性过期）
 			if kv.expiresAt > 0 && kv.expiresAt < time.Now().Unix() {
 				// 过期，删除该项
-				c.removeEntry(listEle)
+				c.removeEntry(listEle, RemoveExpired)
 				c.mu.Unlock()
-				return nil, false
+				return nil, 0, false
 			}
 
 			// 未过期，移到队首并更新访问时间
@@ -283,7 +294,7 @@ func (c *LRUCache) Get(key string) (value Value, ok bool) {
 			kv.lastAccess = time.Now().Unix()
 			atomic.AddInt64(&c.hits, 1)
 			c.mu.Unlock()
-			return kv.value, true
+			return kv.value, kv.version, true
 		}
 	}
 
@@ -301,7 +312,170 @@ func (c *LRUCache) Get(key string) (value Value, ok bool) {
 	he.mu.Unlock()
 
 	atomic.AddInt64(&c.misses, 1)
-	return nil, false
+	return nil, 0, false
+}
+
+// CompareAndSwap 仅当 key 的当前版本号等于 version 时写入新值。
+// 与 DirectAdd 一样跳过 K 次访问门槛。
+// 成功时返回新的版本号；失败时返回当前版本号（key 不存在或已过期时为 0）
+func (c *LRUCache) CompareAndSwap(key string, value Value, version uint64, ttl int64) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ele, ok := c.lookupLocked(key)
+	if !ok {
+		return 0, false
+	}
+	if current := ele.Value.(*lruEntry).version; current != version {
+		return current, false
+	}
+	return c.setLocked(key, value, expireTime(ttl)), true
+}
+
+// Update 原子地读取并改写 key 对应的值，返回写入后的版本号。
+// 与 DirectAdd 一样跳过 K 次访问门槛。
+// fn 接收当前未过期的值（不存在时 found=false），返回新值及其 TTL；
+// 返回的 ttl 为 KeepTTL 时沿用已有条目的过期时间，返回 error 时放弃写入
+func (c *LRUCache) Update(key string, fn func(old Value, found bool) (Value, int64, error)) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var old Value
+	var oldExpiresAt int64
+	ele, found := c.lookupLocked(key)
+	if found {
+		kv := ele.Value.(*lruEntry)
+		old, oldExpiresAt = kv.value, kv.expiresAt
+	}
+
+	value, ttl, err := fn(old, found)
+	if err != nil {
+		return 0, err
+	}
+	expiresAt := expireTime(ttl)
+	if ttl == KeepTTL {
+		expiresAt = oldExpiresAt
+	}
+	return c.setLocked(key, value, expiresAt), nil
+}
+
+// CompareAndRemove 仅当 key 的当前版本号等于 version 时删除该条目
+// 失败时返回当前版本号（key 不存在或已过期时为 0）
+func (c *LRUCache) CompareAndRemove(key string, version uint64) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ele, ok := c.lookupLocked(key)
+	if !ok {
+		return 0, false
+	}
+	if current := ele.Value.(*lruEntry).version; current != version {
+		return current, false
+	}
+	c.removeEntry(ele, RemoveDeleted)
+	return version, true
+}
+
+// UpdateHash 原子地修改 key 对应的哈希值，语义同 Cache.UpdateHash。
+// 与 DirectAdd 一样跳过 K 次访问门槛
+func (c *LRUCache) UpdateHash(key string, fn func(h *Hash, old Value) (int64, error)) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var old Value
+	var oldExpiresAt int64
+	ele, found := c.lookupLocked(key)
+	if found {
+		kv := ele.Value.(*lruEntry)
+		old, oldExpiresAt = kv.value, kv.expiresAt
+	}
+
+	h, inPlace := old.(*Hash)
+	if !inPlace {
+		h = NewHash()
+	}
+	before := h.Len()
+	ttl, err := fn(h, old)
+	if err != nil {
+		return 0, err
+	}
+
+	if !inPlace {
+		if h.Count() == 0 {
+			return 0, nil
+		}
+		expiresAt := expireTime(ttl)
+		if ttl == KeepTTL {
+			expiresAt = oldExpiresAt
+		}
+		return c.setLocked(key, h, expiresAt), nil
+	}
+
+	// 就地修改：字段已变化，按差值调整字节数
+	c.nbytes += int64(h.Len() - before)
+	if h.Count() == 0 {
+		c.removeEntry(ele, RemoveDeleted)
+		return 0, nil
+	}
+	kv := ele.Value.(*lruEntry)
+	c.version++
+	kv.version = c.version
+	kv.lastAccess = time.Now().Unix()
+	c.ll.MoveToFront(ele)
+	if ttl != KeepTTL {
+		if expiresAt := expireTime(ttl); expiresAt != kv.expiresAt {
+			c.heapMu.Lock()
+			if kv.expiresAt > 0 {
+				c.removeFromHeap(key)
+			}
+			if expiresAt > 0 {
+				c.addToHeap(key, expiresAt)
+			}
+			c.heapMu.Unlock()
+			kv.expiresAt = expiresAt
+		}
+	}
+
+	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
+		c.removeOldest()
+	}
+	return kv.version, nil
+}
+
+// View 在持有锁期间以 fn 访问 key 对应的未过期值，语义同 Cache.View。
+// 未命中时不记录访问历史
+func (c *LRUCache) View(key string, fn func(value Value)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ele, ok := c.lookupLocked(key)
+	if !ok {
+		return false
+	}
+	kv := ele.Value.(*lruEntry)
+	c.ll.MoveToFront(ele)
+	kv.lastAccess = time.Now().Unix()
+	atomic.AddInt64(&c.hits, 1)
+	fn(kv.value)
+	return true
+}
+
+// Peek 在持有锁期间以 fn 访问 key 对应的未过期值及其过期时间戳，语义同 Cache.Peek。
+// 不更新 LRU 顺序、访问历史与命中统计
+func (c *LRUCache) Peek(key string, fn func(value Value, expiresAt int64)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ele, ok := c.cache.Load(key)
+	if !ok {
+		return false
+	}
+	kv := ele.(*list.Element).Value.(*lruEntry)
+	if kv.expiresAt > 0 && kv.expiresAt < time.Now().Unix() {
+		return false
+	}
+	fn(kv.value, kv.expiresAt)
+	return true
 }
 
 // RemoveOldest 删除最旧的条目
@@ -315,19 +489,20 @@ func (c *LRUCache) RemoveOldest() {
 func (c *LRUCache) removeOldest() {
 	ele := c.ll.Back()
 	if ele != nil {
-		c.removeEntry(ele)
+		c.removeEntry(ele, RemoveEvicted)
 	}
 }
 
 // removeEntry 从缓存和堆中删除一个条目
-// ele 是要删除的链表元素
-func (c *LRUCache) removeEntry(ele *list.Element) {
+// ele 是要删除的链表元素，reason 是删除原因
+func (c *LRUCache) removeEntry(ele *list.Element, reason RemoveReason) {
 	kv := ele.Value.(*lruEntry)
 	key := kv.key
 
 	// 从链表中删除
 	c.ll.Remove(ele)
 	c.cache.Delete(key)
+	c.order.Delete(posOf(key))
 	c.nbytes -= int64(len(key)) + int64(kv.value.Len())
 
 	// 如果有过期时间，从堆中删除
@@ -341,9 +516,15 @@ func (c *LRUCache) removeEntry(ele *list.Element) {
 	c.history.Delete(key)
 
 	// 调用删除回调
+	if reason == RemoveEvicted && c.OnCapacityEvicted != nil {
+		c.OnCapacityEvicted(key, kv.value, kv.expiresAt)
+	}
 	if c.OnEvicted != nil {
 		c.OnEvicted(key, kv.value)
 	}
+	if c.OnRemoved != nil {
+		c.OnRemoved(key, kv.value, reason)
+	}
 }
 
 // addToHeap 将键添加到过期堆中
@@ -460,7 +641,7 @@ func (c *LRUCache) checkExpiration() {
 		// 如果缓存中还存在，删除它
 		c.mu.Lock()
 		if ele, ok := c.cache.Load(key); ok {
-			c.removeEntry(ele.(*list.Element))
+			c.removeEntry(ele.(*list.Element), RemoveExpired)
 		}
 		c.mu.Unlock()
 
@@ -496,13 +677,112 @@ func (c *LRUCache) Len() int {
 	return c.ll.Len()
 }
 
+// Range 按从新到旧的顺序遍历未过期的条目，fn 返回 false 时停止遍历。
+// 遍历期间持有 c.mu，fn 中不得调用本缓存的其他方法
+func (c *LRUCache) Range(fn func(key string, value Value) bool) {
+	c.RangeWithExpiry(func(key string, value Value, expiresAt int64) bool {
+		return fn(key, value)
+	})
+}
+
+// RangeWithExpiry 同 Range，额外提供条目的过期时间戳（0 表示永不过期）
+func (c *LRUCache) RangeWithExpiry(fn func(key string, value Value, expiresAt int64) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().Unix()
+	for ele := c.ll.Front(); ele != nil; ele = ele.Next() {
+		kv := ele.Value.(*lruEntry)
+		if kv.expiresAt > 0 && kv.expiresAt < now {
+			continue
+		}
+		if !fn(kv.key, kv.value, kv.expiresAt) {
+			return
+		}
+	}
+}
+
+// RangeAfter 按键的哈希顺序遍历排在 after 之后（after 为空时从头开始）的未过期条目，fn 返回 false 时停止遍历。
+// 该顺序不随访问与写入改变，以上一次遍历到的键作为 after 即可分页遍历，每页只访问该页的条目。
+// 遍历期间持有 c.mu，fn 中不得调用本缓存的其他方法
+func (c *LRUCache) RangeAfter(after string, fn func(key string, value Value) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().Unix()
+	rangeOrder(c.order, after, func(key string) bool {
+		ele, _ := c.cache.Load(key)
+		kv := ele.(*list.Element).Value.(*lruEntry)
+		if kv.expiresAt > 0 && kv.expiresAt < now {
+			return true
+		}
+		return fn(key, kv.value)
+	})
+}
+
+// RangeHistory 遍历尚未进入缓存的 key 的访问历史（时间戳从旧到新），fn 返回 false 时停止遍历。
+// ts 是副本，调用方可以持有
+func (c *LRUCache) RangeHistory(fn func(key string, ts []int64) bool) {
+	c.history.Range(func(key, value interface{}) bool {
+		if _, ok := c.cache.Load(key); ok {
+			return true
+		}
+		he := value.(*historyEntry)
+		he.mu.Lock()
+		ts := append([]int64(nil), he.ts...)
+		he.mu.Unlock()
+		if len(ts) == 0 {
+			return true
+		}
+		return fn(key.(string), ts)
+	})
+}
+
+// RestoreHistory 将 ts 合并到 key 的访问历史中，只保留最近的 K 个时间戳，key 已在缓存中时忽略。
+// 用于从快照恢复，使重启前已被访问过的 key 不必重新累计 K 次访问
+func (c *LRUCache) RestoreHistory(key string, ts []int64) {
+	if len(ts) == 0 {
+		return
+	}
+	if _, ok := c.cache.Load(key); ok {
+		return
+	}
+	actual, _ := c.history.LoadOrStore(key, &historyEntry{})
+	he := actual.(*historyEntry)
+
+	he.mu.Lock()
+	merged := append(append([]int64(nil), he.ts...), ts...)
+	sort.Slice(merged, func(i, j int) bool { return merged[i] < merged[j] })
+	if len(merged) > c.k {
+		merged = merged[len(merged)-c.k:]
+	}
+	he.ts = merged
+	he.mu.Unlock()
+}
+
+// Keys 返回所有未过期条目的键，按从新到旧排列
+func (c *LRUCache) Keys() []string {
+	var keys []string
+	c.Range(func(key string, value Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Contains 判断键是否在缓存中，不更新 LRU 顺序与访问历史，也不检查过期
+func (c *LRUCache) Contains(key string) bool {
+	_, ok := c.cache.Load(key)
+	return ok
+}
+
 // Remove 删除指定键的条目
 func (c *LRUCache) Remove(key string) {
 	if _, ok := c.cache.Load(key); ok {
 		c.mu.Lock()
 		// TOCTOU 修复：获取锁后重新验证
 		if ele, ok := c.cache.Load(key); ok {
-			c.removeEntry(ele.(*list.Element))
+			c.removeEntry(ele.(*list.Element), RemoveDeleted)
 		}
 		c.mu.Unlock()
 	}
@@ -527,7 +807,7 @@ func (c *LRUCache) Clear() {
 		c.mu.Lock()
 		// TOCTOU 修复：获取锁后重新验证
 		if ele, ok := c.cache.Load(key); ok {
-			c.removeEntry(ele.(*list.Element))
+			c.removeEntry(ele.(*list.Element), RemoveDeleted)
 		}
 		c.mu.Unlock()
 	}
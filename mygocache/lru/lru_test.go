@@ -1,7 +1,9 @@
 package lru
 
 import (
+	"container/list"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -63,3 +65,264 @@ func TestAdd(t *testing.T) {
 		t.Fatal("expected 6 but got", lru.nbytes)
 	}
 }
+
+func TestCompareAndSwap(t *testing.T) {
+	lru := New(int64(0), nil)
+	lru.Add("key", String("1"), 0)
+	_, v1, ok := lru.GetWithVersion("key")
+	if !ok || v1 == 0 {
+		t.Fatalf("expected a non-zero version, got %d", v1)
+	}
+
+	v2, ok := lru.CompareAndSwap("key", String("2"), v1, 0)
+	if !ok || v2 <= v1 {
+		t.Fatalf("cas with current version failed, got version %d", v2)
+	}
+	if current, ok := lru.CompareAndSwap("key", String("3"), v1, 0); ok || current != v2 {
+		t.Fatalf("cas with stale version should fail and report %d, got %d", v2, current)
+	}
+	if current, ok := lru.CompareAndRemove("missing", v2); ok || current != 0 {
+		t.Fatalf("cad on missing key should fail with version 0, got %d", current)
+	}
+	if _, ok := lru.CompareAndRemove("key", v2); !ok || lru.Len() != 0 {
+		t.Fatalf("cad with current version failed")
+	}
+}
+
+func TestLRUKCompareAndSwap(t *testing.T) {
+	lru := NewLRUK(int64(0), 2, nil)
+	defer lru.Close()
+	if _, ok := lru.CompareAndSwap("key", String("1"), 0, 0); ok {
+		t.Fatalf("cas on missing key should fail")
+	}
+
+	lru.DirectAdd("key", String("1"), 0)
+	_, v1, _ := lru.GetWithVersion("key")
+	lru.DirectAdd("key", String("2"), 0)
+	_, v2, _ := lru.GetWithVersion("key")
+	if v2 <= v1 {
+		t.Fatalf("version should increase on every write, got %d then %d", v1, v2)
+	}
+
+	if _, ok := lru.CompareAndSwap("key", String("3"), v1, 0); ok {
+		t.Fatalf("cas with stale version should fail")
+	}
+	if _, ok := lru.CompareAndSwap("key", String("3"), v2, 0); !ok {
+		t.Fatalf("cas with current version failed")
+	}
+	if v, _ := lru.Get("key"); string(v.(String)) != "3" {
+		t.Fatalf("expected 3 after cas, got %s", v)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	lru := New(int64(0), nil)
+	appendOne := func(old Value, found bool) (Value, int64, error) {
+		if !found {
+			return String("1"), 100, nil
+		}
+		return old.(String) + "1", KeepTTL, nil
+	}
+
+	lru.Update("key", appendOne)
+	expiresAt := lru.cache["key"].Value.(*entry).expiresAt
+	lru.Update("key", appendOne)
+
+	kv := lru.cache["key"].Value.(*entry)
+	if kv.value.(String) != "11" || kv.expiresAt != expiresAt {
+		t.Fatalf("expected 11 with original expiry, got %s expiring at %d", kv.value, kv.expiresAt)
+	}
+	if lru.nbytes != int64(len("key")+len("11")) {
+		t.Fatalf("expected nbytes to track updated value, got %d", lru.nbytes)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	lru := New(int64(0), nil)
+	lru.Add("k1", String("1"), 0)
+	lru.Add("k2", String("2"), 0)
+	lru.Add("k3", String("3"), 0)
+	lru.Get("k1")
+
+	if keys := lru.Keys(); !reflect.DeepEqual(keys, []string{"k1", "k3", "k2"}) {
+		t.Fatalf("expected keys ordered from newest to oldest, got %v", keys)
+	}
+
+	lruK := NewLRUK(int64(0), 2, nil)
+	defer lruK.Close()
+	lruK.DirectAdd("k1", String("1"), 0)
+	lruK.Add("k2", String("2"), 0) // 未达到 K 次访问，不会进入缓存
+	if keys := lruK.Keys(); !reflect.DeepEqual(keys, []string{"k1"}) {
+		t.Fatalf("expected only cached keys, got %v", keys)
+	}
+}
+
+func TestRangeAfter(t *testing.T) {
+	lru := New(int64(0), nil)
+	lruK := NewLRUK(int64(0), 2, nil)
+	defer lruK.Close()
+	for i := 0; i < 500; i++ {
+		key := "k" + strconv.Itoa(i)
+		lru.Add(key, String("v"), 0)
+		lruK.DirectAdd(key, String("v"), 0)
+	}
+	lru.Remove("k7")
+	lruK.Remove("k7")
+
+	for name, rangeAfter := range map[string]func(string, func(string, Value) bool){
+		"lru":   lru.RangeAfter,
+		"lru-k": lruK.RangeAfter,
+	} {
+		// 每页 9 个键，以上一页最后一个键作为 after，页之间访问与覆盖写入不影响顺序
+		seen := make(map[string]bool)
+		after := ""
+		for {
+			var page []string
+			rangeAfter(after, func(key string, value Value) bool {
+				page = append(page, key)
+				return len(page) < 9
+			})
+			for _, key := range page {
+				if seen[key] {
+					t.Fatalf("%s: key %s returned twice", name, key)
+				}
+				seen[key] = true
+			}
+			lru.Get(page[0])
+			lru.Add(page[0], String("v2"), 0)
+			lruK.Get(page[0])
+			if len(page) < 9 {
+				break
+			}
+			after = page[len(page)-1]
+		}
+		if len(seen) != 499 || seen["k7"] {
+			t.Fatalf("%s: expected 499 keys without the removed one, got %d", name, len(seen))
+		}
+	}
+}
+
+func TestOnRemoved(t *testing.T) {
+	type event struct {
+		key    string
+		reason RemoveReason
+	}
+	var events []event
+	onRemoved := func(key string, value Value, reason RemoveReason) {
+		events = append(events, event{key, reason})
+	}
+	var capacityEvicted []string
+	onCapacityEvicted := func(key string, value Value, expiresAt int64) {
+		capacityEvicted = append(capacityEvicted, key)
+	}
+	expect := []event{
+		{"k1", RemoveReplaced},
+		{"k2", RemoveDeleted},
+		{"k1", RemoveExpired},
+		{"k3", RemoveEvicted},
+	}
+
+	lru := New(int64(8), nil)
+	lruK := NewLRUK(int64(8), 2, nil)
+	defer lru.Close()
+	defer lruK.Close()
+	lru.OnRemoved, lruK.OnRemoved = onRemoved, onRemoved
+	lru.OnCapacityEvicted, lruK.OnCapacityEvicted = onCapacityEvicted, onCapacityEvicted
+
+	for _, c := range []interface {
+		Get(key string) (Value, bool)
+		Remove(key string)
+		Update(key string, fn func(Value, bool) (Value, int64, error)) (uint64, error)
+	}{lru, lruK} {
+		events, capacityEvicted = nil, nil
+		set := func(key, value string) {
+			c.Update(key, func(Value, bool) (Value, int64, error) { return String(value), 0, nil })
+		}
+		set("k1", "1")
+		set("k1", "2")
+		set("k2", "2")
+		c.Remove("k2")
+
+		// 直接改写过期时间，触发访问时的惰性过期
+		switch c := c.(type) {
+		case *Cache:
+			c.cache["k1"].Value.(*entry).expiresAt = 1
+		case *LRUCache:
+			ele, _ := c.cache.Load("k1")
+			ele.(*list.Element).Value.(*lruEntry).expiresAt = 1
+		}
+		c.Get("k1")
+
+		set("k3", "3")
+		set("k4", "4444")
+		if !reflect.DeepEqual(events, expect) {
+			t.Fatalf("%T: expected events %v, got %v", c, expect, events)
+		}
+		// 只有容量淘汰触发 OnCapacityEvicted
+		if !reflect.DeepEqual(capacityEvicted, []string{"k3"}) {
+			t.Fatalf("%T: expected capacity eviction of k3, got %v", c, capacityEvicted)
+		}
+	}
+}
+
+func TestUpdateHash(t *testing.T) {
+	lru := New(int64(20), nil)
+	lruK := NewLRUK(int64(20), 2, nil)
+	defer lru.Close()
+	defer lruK.Close()
+
+	setField := func(field, value string) func(*Hash, Value) (int64, error) {
+		return func(h *Hash, old Value) (int64, error) {
+			h.Set(field, []byte(value))
+			return KeepTTL, nil
+		}
+	}
+
+	for _, c := range []interface {
+		Add(key string, value Value, ttl int64)
+		Contains(key string) bool
+		UpdateHash(key string, fn func(*Hash, Value) (int64, error)) (uint64, error)
+		View(key string, fn func(Value)) bool
+	}{lru, lruK} {
+		nbytes := func() int64 {
+			switch c := c.(type) {
+			case *Cache:
+				return c.nbytes
+			case *LRUCache:
+				return c.nbytes
+			}
+			return 0
+		}
+
+		if lruK, ok := c.(*LRUCache); ok {
+			lruK.DirectAdd("s", String("12345"), 0)
+		} else {
+			c.Add("s", String("12345"), 0)
+		}
+		c.UpdateHash("h", setField("f1", "aa"))
+		if n := nbytes(); n != 6+5 {
+			t.Fatalf("%T: expected 11 bytes after creating hash, got %d", c, n)
+		}
+
+		// 只按字段增减的字节数调整容量，超出容量时淘汰其他条目
+		c.UpdateHash("h", setField("f2", "1234567890"))
+		if n := nbytes(); n != 1+4+12 || c.Contains("s") {
+			t.Fatalf("%T: expected hash growth to evict s, got %d bytes", c, n)
+		}
+		c.View("h", func(v Value) {
+			if f2, _ := v.(*Hash).Get("f2"); string(f2) != "1234567890" {
+				t.Fatalf("%T: expected f2 to be updated in place, got %s", c, f2)
+			}
+		})
+
+		// 删除最后一个字段时 key 一并删除
+		c.UpdateHash("h", func(h *Hash, old Value) (int64, error) {
+			h.Delete("f1")
+			h.Delete("f2")
+			return KeepTTL, nil
+		})
+		if n := nbytes(); n != 0 || c.Contains("h") {
+			t.Fatalf("%T: expected empty hash to be removed, got %d bytes", c, n)
+		}
+	}
+}
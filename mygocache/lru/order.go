@@ -0,0 +1,46 @@
+package lru
+
+import "mygocache/sortedset"
+
+// keyPos 是键在遍历顺序中的位置：先按键的 64 位哈希排序，哈希相同时按键排序。
+// 该顺序只由键决定，不随访问、写入与淘汰改变，供 RangeAfter 分页遍历
+type keyPos struct {
+	hash uint64
+	key  string
+}
+
+func lessKeyPos(a, b keyPos) bool {
+	if a.hash != b.hash {
+		return a.hash < b.hash
+	}
+	return a.key < b.key
+}
+
+func newKeyOrder() *sortedset.Set[keyPos] {
+	return sortedset.New(lessKeyPos)
+}
+
+// posOf 返回 key 的遍历位置
+func posOf(key string) keyPos {
+	return keyPos{hash: hashKey(key), key: key}
+}
+
+// hashKey 计算键的 64 位 FNV-1a 哈希，不分配内存
+func hashKey(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// rangeOrder 按遍历顺序从 after 之后（after 为空时从头）开始遍历 order 中的键
+func rangeOrder(order *sortedset.Set[keyPos], after string, fn func(key string) bool) {
+	visit := func(p keyPos) bool { return fn(p.key) }
+	if after == "" {
+		order.Ascend(visit)
+		return
+	}
+	order.AscendAfter(posOf(after), visit)
+}
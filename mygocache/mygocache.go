@@ -4,14 +4,27 @@ import (
 	"errors"
 	"fmt"
 	"mygocache/asynclog"
+	"mygocache/diskstore"
+	"mygocache/hotkey"
 	"mygocache/pool"
 	"mygocache/singleflight"
+	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
 // ErrKeyNotFound 表示 key 不存在（负缓存命中时返回）
 var ErrKeyNotFound = errors.New("key not found")
 
+// ErrVersionMismatch 表示 CAS 操作时 key 的当前版本号与预期不符
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// ErrKeyExists 表示 Add 时 key 已存在
+var ErrKeyExists = errors.New("key already exists")
+
+// ErrGroupExists 表示 NewGroup 时同名 Group 已存在或正在创建
+var ErrGroupExists = errors.New("group already exists")
+
 // DefaultNegativeCacheTTL 默认负缓存的 TTL（秒），防止不存在的 key 反复穿透
 const DefaultNegativeCacheTTL int64 = 10
 
@@ -29,6 +42,48 @@ type Group struct {
 	negativeCacheTTL int64
 	// 并发操作使用的协程池
 	goroutinePool *pool.GoroutinePool
+	// 填充租约，防止 Delete 与慢加载并发时写回过期数据
+	leases *leaseTable
+	// 标签索引，用于按标签批量失效
+	tags *tagIndex
+	// Group 与命名空间的代数，用于 O(1) 批量失效
+	gens *generations
+	// 通过协程池异步派发的淘汰、过期、删除与覆盖钩子，包括 WithEvictionCallback 注册的回调
+	evictionHooks []EvictionHook
+	// key 变更的订阅者与最近事件
+	watches *watchHub
+	// 值压缩算法，nil 表示不压缩
+	compressor Compressor
+	// 不小于该字节数的值才压缩
+	compressThreshold int
+	// 从远程节点获取数据时保持压缩形式传输
+	compressedTransfer bool
+	// 值加密，nil 表示不加密
+	envelope *envelope
+	// 无法解密、解压或分块校验失败的缓存值数量
+	integrityFailures int64
+	// 超过该字节数的值分块存储，0 表示不分块
+	chunkThreshold int
+	// 分块大小（字节）
+	chunkSize int
+	// 周期写入的快照文件，nil 表示未启用
+	snapshots *snapshotter
+	// 记录显式写入的追加日志，nil 表示未启用
+	aof *appendLog
+	// 存放被淘汰值的磁盘层，nil 表示未启用
+	disk *diskstore.Store
+	// 等待后台写入磁盘层的被淘汰值
+	spills *spillQueue
+	// 磁盘层命中次数
+	diskHits int64
+	// 进行中的预热数量，ready 在其归零时关闭
+	warmMu  sync.Mutex
+	warming int
+	ready   chan struct{}
+	// 统计 Get 访问的热点 key，nil 表示未启用
+	hotKeys *hotkey.Tracker
+	// 保证 Close 幂等
+	closeOnce sync.Once
 }
 
 // Getter 用于加载某个 key 的数据
@@ -47,36 +102,178 @@ func (f GetterFunc) Get(key string) ([]byte, error) {
 var (
 	mu     sync.RWMutex
 	groups = make(map[string]*Group)
+	// creating 正在打开磁盘层、恢复快照与重放日志、尚未注册的 Group 名称
+	creating = make(map[string]bool)
 )
 
-// NewGroup 创建 Group 实例
-func NewGroup(name string, cacheBytes int64, getter Getter) *Group {
-	return NewGroupWithOptions(name, cacheBytes, getter, 0, StrategyLRUK, 2)
+// NewGroup 使用函数式选项创建 Group 实例，参数非法时返回描述具体问题的错误。
+// 未指定的参数使用默认值：容量 DefaultCacheBytes、LRU-K（K=2）、32 个分片、不过期。
+// 同名 Group 已存在时返回 ErrGroupExists，需要先 Close 或 UnregisterGroup 旧实例（旧构造函数则会替换旧实例）。
+// 新实例在打开磁盘层、恢复快照与重放日志之后才注册，此前 GetGroup 返回 nil
+func NewGroup(name string, getter Getter, opts ...Option) (*Group, error) {
+	if name == "" {
+		return nil, fmt.Errorf("group name is required")
+	}
+	if getter == nil {
+		return nil, fmt.Errorf("nil Getter")
+	}
+	o := defaultGroupOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, fmt.Errorf("group %s: %v", name, err)
+	}
+
+	// 先占用名称，避免与同名的旧实例或并发创建的实例共用快照、日志与磁盘层文件
+	mu.Lock()
+	if groups[name] != nil || creating[name] {
+		mu.Unlock()
+		return nil, ErrGroupExists
+	}
+	creating[name] = true
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		delete(creating, name)
+		mu.Unlock()
+	}()
+
+	g := &Group{
+		name:               name,
+		getter:             getter,
+		loader:             &singleflight.Group{},
+		defaultTTL:         o.defaultTTL,
+		negativeCacheTTL:   o.negativeCacheTTL,
+		goroutinePool:      pool.NewGoroutinePool(o.poolMinWorkers, o.poolMaxWorkers, o.poolQueueSize),
+		leases:             newLeaseTable(o.leaseTTL, o.shardCount),
+		tags:               newTagIndex(),
+		gens:               newGenerations(),
+		evictionHooks:      o.evictionHooks,
+		watches:            newWatchHub(),
+		compressor:         o.compressor,
+		compressThreshold:  o.compressThreshold,
+		compressedTransfer: o.compressedTransfer,
+		chunkThreshold:     o.chunkThreshold,
+		chunkSize:          o.chunkSize,
+		ready:              make(chan struct{}),
+	}
+	close(g.ready)
+	if o.onEvicted != nil {
+		g.evictionHooks = append(g.evictionHooks, callbackHook(o.onEvicted))
+	}
+	if o.keys != nil {
+		g.envelope = newEnvelope(o.keys)
+		g.watches.envelope, g.watches.aad = g.envelope, g.valueAAD
+	}
+	g.mainCache = newCache(o.cacheBytes, o.strategy, o.k, o.shardCount, g.onRemoved)
+	if o.diskDir != "" {
+		g.disk = diskstore.New(o.diskDir, o.diskBytes)
+		g.disk.OnDropped = g.tags.remove
+		g.spills = newSpillQueue()
+		g.mainCache.setOnCapacityEvicted(g.spill)
+	}
+	if o.hotKeyCapacity > 0 {
+		g.hotKeys = hotkey.New(o.hotKeyCapacity, o.hotKeySampleRate, hotkey.DefaultWindow)
+	}
+	if o.snapshotPath != "" {
+		g.startSnapshots(o.snapshotPath, o.snapshotInterval)
+	}
+	if o.appendLogPath != "" {
+		g.aof = newAppendLog(o.appendLogPath, o.fsyncPolicy, o.appendLogRewriteSize)
+	}
+
+	if g.disk != nil {
+		if err := g.disk.Open(); err != nil {
+			g.Close()
+			return nil, fmt.Errorf("group %s: open disk tier: %v", name, err)
+		}
+		g.spills.start(g.disk, g.tags.remove)
+	}
+	// 日志中的写入比快照更新，在恢复快照之后重放
+	g.loadSnapshot()
+	if g.aof != nil {
+		if err := g.openAppendLog(); err != nil {
+			g.Close()
+			return nil, fmt.Errorf("group %s: %v", name, err)
+		}
+	}
+
+	mu.Lock()
+	groups[name] = g
+	mu.Unlock()
+	return g, nil
+}
+
+// mustNewGroup 供不返回错误的旧构造函数使用：参数非法时 panic（与旧版本对 nil Getter 的处理一致）；
+// 同名 Group 已存在时保持旧版本的语义，关闭并替换旧实例
+func mustNewGroup(name string, getter Getter, opts ...Option) *Group {
+	for {
+		g, err := NewGroup(name, getter, opts...)
+		if err != ErrGroupExists {
+			if err != nil {
+				panic(err)
+			}
+			return g
+		}
+		// 先关闭旧实例，释放它的协程与快照、日志、磁盘层文件，再重新创建
+		if old := GetGroup(name); old != nil {
+			old.Close()
+		} else {
+			// 同名实例正在创建，等待其注册后再替换
+			runtime.Gosched()
+		}
+	}
 }
 
-// NewGroupWithTTL 创建带默认 TTL 的 Group 实例
+// NewGroupWithTTL 创建带默认 TTL 的 Group 实例，同名 Group 已存在时关闭并替换它，参数非法时 panic
 func NewGroupWithTTL(name string, cacheBytes int64, getter Getter, defaultTTL int64) *Group {
-	return NewGroupWithOptions(name, cacheBytes, getter, defaultTTL, StrategyLRUK, 2)
+	return mustNewGroup(name, getter, WithCacheBytes(cacheBytes), WithDefaultTTL(defaultTTL))
 }
 
-// NewGroupWithOptions 创建带自定义参数的 Group 实例
+// NewGroupWithOptions 创建带自定义参数的 Group 实例，同名 Group 已存在时关闭并替换它，参数非法时 panic
 func NewGroupWithOptions(name string, cacheBytes int64, getter Getter, defaultTTL int64, strategy CacheStrategy, k int) *Group {
-	if getter == nil {
-		panic("nil Getter")
-	}
-	mu.Lock()
-	defer mu.Unlock()
-	g := &Group{
-		name:             name,
-		getter:           getter,
-		mainCache:        NewCache(cacheBytes, strategy, k),
-		loader:           &singleflight.Group{},
-		defaultTTL:       defaultTTL,
-		negativeCacheTTL: DefaultNegativeCacheTTL,
-		goroutinePool:    pool.NewGoroutinePool(10, 500, 1000), // 动态伸缩：[10, 500] worker，队列容量 1000
+	return mustNewGroup(name, getter, WithCacheBytes(cacheBytes), WithDefaultTTL(defaultTTL), WithStrategy(strategy, k))
+}
+
+// Close 停止 Group 的协程池与各分片的过期检查协程，并将其从注册表中移除（幂等，可多次调用）。
+// 使用 WithSnapshotFile 时还会写入最后一次快照，使用 WithAppendLog 时同步并关闭日志，
+// 使用 WithDiskTier 时删除磁盘层的段文件。
+// 关闭后缓存仍可读写，但过期条目只在访问时惰性清理，依赖协程池的并发操作退化为同步执行。
+// 不能在 Group 的协程池任务中调用
+func (g *Group) Close() {
+	g.closeOnce.Do(func() {
+		mu.Lock()
+		if groups[g.name] == g {
+			delete(groups, g.name)
+		}
+		mu.Unlock()
+
+		g.stopSnapshots()
+		g.closeAppendLog()
+		if g.disk != nil {
+			g.spills.close()
+			g.disk.Close()
+		}
+		g.watches.close()
+		g.goroutinePool.Close()
+		g.mainCache.close()
+	})
+}
+
+// UnregisterGroup 从注册表中移除并关闭指定名称的 Group，不存在时返回 false
+func UnregisterGroup(name string) bool {
+	g := GetGroup(name)
+	if g == nil {
+		return false
 	}
-	groups[name] = g
-	return g
+	g.Close()
+	return true
+}
+
+// Name 返回 Group 的名称
+func (g *Group) Name() string {
+	return g.name
 }
 
 // SetNegativeCacheTTL 设置负缓存 TTL
@@ -102,8 +299,19 @@ func (g *Group) GetWithTTL(key string, ttl int64) (ByteView, error) {
 	if key == "" {
 		return ByteView{}, fmt.Errorf("key is required")
 	}
+	if g.hotKeys != nil {
+		g.hotKeys.Record(key)
+	}
+	return g.getResolved(key, ttl, g.decodeValue)
+}
 
-	if v, ok := g.mainCache.get(key); ok {
+// getStored 返回 key 在缓存中的存储形式（可能已压缩），未命中时加载
+func (g *Group) getStored(key, ck string, ttl int64) (ByteView, error) {
+	v, ok, err := g.mainCache.get(ck)
+	if err != nil {
+		return ByteView{}, err
+	}
+	if ok {
 		if v.Len() == 0 {
 			// 负缓存命中：key 不存在，短时间内不再穿透
 			asynclog.Println("[GeeCache] negative cache hit")
@@ -116,25 +324,174 @@ func (g *Group) GetWithTTL(key string, ttl int64) (ByteView, error) {
 	}
 
 	// 缓存未命中，通过 singleflight 加载
-	return g.loadWithTTL(key, ttl)
+	return g.loadWithTTL(key, ck, ttl)
 }
 
-// Set 设置 key 对应的缓存值，并指定 TTL
-func (g *Group) Set(key string, value []byte, ttl int64) error {
+// Set 设置 key 对应的缓存值，并指定 TTL。
+// tags 为该值附加的标签（替换 key 原有的标签），可通过 InvalidateTag 批量失效。
+// 超过分块阈值的值分块写入所属节点，写入失败时返回错误且 key 保持不变
+func (g *Group) Set(key string, value []byte, ttl int64, tags ...string) error {
 	byteView := ByteView{b: cloneBytes(value)}
-	g.mainCache.directAdd(key, byteView, ttl)
+	// 显式写入的值比进行中的加载更新，作废未兑现的填充租约
+	ck := g.cacheKey(key)
+	g.leases.invalidate(ck)
+	var stored ByteView
+	if g.shouldChunk(value) {
+		var err error
+		if stored, err = g.storeChunks(key, byteView.b, ttl); err != nil {
+			return err
+		}
+	} else {
+		stored = g.encodeValue(key, byteView)
+	}
+	g.mainCache.directAdd(ck, stored, ttl)
+	g.tagKey(ck, tags)
+	g.keyWritten(ck)
+	g.notifySet(key, byteView)
 	return nil
 }
 
+// Add 仅当 key 不存在（或已过期、命中负缓存）时写入，返回新的版本号，key 已存在时返回 ErrKeyExists。
+// 判断与写入在同一把分片锁内完成，适用于幂等 key 与轻量级锁
+func (g *Group) Add(key string, value []byte, ttl int64) (uint64, error) {
+	byteView := ByteView{b: cloneBytes(value)}
+	stored := g.encodeValue(key, byteView)
+	ck := g.cacheKey(key)
+	// 先移回磁盘层中的值并作废租约，避免进行中的加载以旧数据覆盖本次写入
+	g.prepareUpdate(key, ck)
+	version, err := g.mainCache.update(ck, func(old ByteView, found bool) (ByteView, int64, error) {
+		if found && old.Len() > 0 {
+			return ByteView{}, 0, ErrKeyExists
+		}
+		return stored, ttl, nil
+	})
+	if err == nil {
+		g.keyWritten(ck)
+		g.notifySet(key, byteView)
+	}
+	return version, err
+}
+
+// Replace 仅当 key 已存在时写入，返回新的版本号，key 不存在时返回 ErrKeyNotFound
+func (g *Group) Replace(key string, value []byte, ttl int64) (uint64, error) {
+	byteView := ByteView{b: cloneBytes(value)}
+	stored := g.encodeValue(key, byteView)
+	ck := g.cacheKey(key)
+	g.prepareUpdate(key, ck)
+	version, err := g.mainCache.update(ck, func(old ByteView, found bool) (ByteView, int64, error) {
+		if !found || old.Len() == 0 {
+			return ByteView{}, 0, ErrKeyNotFound
+		}
+		return stored, ttl, nil
+	})
+	if err == nil {
+		g.keyWritten(ck)
+		g.notifySet(key, byteView)
+	}
+	return version, err
+}
+
+// GetWithVersion 获取 key 对应的缓存值及其版本号（CAS token）。
+// 未命中时先按 Get 的流程加载，再读取加载后的版本号
+func (g *Group) GetWithVersion(key string) (ByteView, uint64, error) {
+	if key == "" {
+		return ByteView{}, 0, fmt.Errorf("key is required")
+	}
+
+	ck := g.cacheKey(key)
+	v, version, ok, err := g.mainCache.getWithVersion(ck)
+	if err != nil {
+		return ByteView{}, 0, err
+	}
+	if ok && v.Len() == 0 {
+		g.mainCache.recordHit()
+		return ByteView{}, 0, ErrKeyNotFound
+	}
+	if ok {
+		if v, err = g.decodeFull(key, v); err == nil {
+			g.mainCache.recordHit()
+			return v, version, nil
+		}
+		// 无法还原的值删除后按未命中重新加载
+		g.dropUndecodable(key, ck, err)
+	}
+
+	view, err := g.loadWithTTL(key, ck, g.defaultTTL)
+	if err != nil {
+		return ByteView{}, 0, err
+	}
+	v, version, ok, err = g.mainCache.getWithVersion(ck)
+	if !ok && err == nil {
+		// LRU-K 下首次加载的值可能尚未达到 K 次访问而未进入缓存，
+		// 此时直接写入以分配版本号，保证后续 CAS 可用
+		g.mainCache.directAdd(ck, g.encodeValue(key, view), g.defaultTTL)
+		v, version, ok, err = g.mainCache.getWithVersion(ck)
+	}
+	if err != nil {
+		return ByteView{}, 0, err
+	}
+	if !ok || v.Len() == 0 {
+		return ByteView{}, 0, ErrKeyNotFound
+	}
+	v, err = g.decodeFull(key, v)
+	return v, version, err
+}
+
+// CompareAndSet 仅当 key 的当前版本号等于 version 时写入新值，返回新的版本号。
+// key 不存在时返回 ErrKeyNotFound，版本号不一致时返回 ErrVersionMismatch
+func (g *Group) CompareAndSet(key string, value []byte, version uint64, ttl int64) (uint64, error) {
+	byteView := ByteView{b: cloneBytes(value)}
+	ck := g.cacheKey(key)
+	g.prepareUpdate(key, ck)
+	current, ok := g.mainCache.compareAndSwap(ck, g.encodeValue(key, byteView), version, ttl)
+	if ok {
+		g.keyWritten(ck)
+		g.notifySet(key, byteView)
+		return current, nil
+	}
+	if current == 0 {
+		return 0, ErrKeyNotFound
+	}
+	return current, ErrVersionMismatch
+}
+
+// CompareAndDelete 仅当 key 的当前版本号等于 version 时删除该 key。
+// key 不存在时返回 ErrKeyNotFound，版本号不一致时返回 ErrVersionMismatch
+func (g *Group) CompareAndDelete(key string, version uint64) error {
+	ck := g.cacheKey(key)
+	g.prepareUpdate(key, ck)
+	current, ok := g.mainCache.compareAndRemove(ck, version)
+	if ok {
+		g.keyWritten(ck)
+		return nil
+	}
+	if current == 0 {
+		return ErrKeyNotFound
+	}
+	return ErrVersionMismatch
+}
+
 // Delete 删除缓存中的 key
 func (g *Group) Delete(key string) error {
-	g.mainCache.delete(key)
+	g.removeCacheKey(g.cacheKey(key))
 	return nil
 }
 
-// Clear 清空缓存
+// removeCacheKey 删除实际写入缓存的 key
+func (g *Group) removeCacheKey(ck string) {
+	// 先作废租约再删除，避免慢加载方在删除后写回过期数据
+	g.leases.invalidate(ck)
+	g.mainCache.delete(ck)
+	g.keyWritten(ck)
+}
+
+// Clear 清空缓存。需要遍历并删除所有条目，缓存较大时可改用 BumpGeneration
 func (g *Group) Clear() error {
-	g.mainCache.clear()
+	g.leases.invalidateAll()
+	g.logged(g.mainCache.clear, func(sw *snapshotWriter) { sw.writeByte(recordClear) })
+	if g.disk != nil {
+		g.clearDisk()
+	}
 	return nil
 }
 
@@ -144,18 +501,36 @@ type Stats struct {
 	HitCount   int
 	MissCount  int
 	TotalCount int
+	// IntegrityFailures 无法解密或解压而被按未命中处理的缓存值数量
+	IntegrityFailures int64
+	// DiskItemCount 磁盘层中的条目数量，DiskHitCount 内存未命中而磁盘层命中的次数
+	DiskItemCount int
+	DiskHitCount  int
 }
 
 // Stats 返回缓存统计信息
 func (g *Group) Stats() Stats {
-	return g.mainCache.stats()
+	stats := g.mainCache.stats()
+	stats.IntegrityFailures = atomic.LoadInt64(&g.integrityFailures)
+	if g.disk != nil {
+		stats.DiskItemCount = g.diskLen()
+		stats.DiskHitCount = int(atomic.LoadInt64(&g.diskHits))
+	}
+	return stats
 }
 
-// GetMulti 批量获取缓存
+// GetMulti 批量获取缓存，值为哈希或无法还原的 key 按未命中处理
 func (g *Group) GetMulti(keys []string) (map[string][]byte, error) {
 	result := make(map[string][]byte)
 	for _, key := range keys {
-		if v, ok := g.mainCache.get(key); ok {
+		v, ok, _ := g.mainCache.get(g.cacheKey(key))
+		if ok {
+			var err error
+			if v, err = g.decodeFull(key, v); err != nil {
+				ok = false
+			}
+		}
+		if ok {
 			result[key] = v.ByteSlice()
 			g.mainCache.recordHit()
 		} else {
@@ -165,11 +540,24 @@ func (g *Group) GetMulti(keys []string) (map[string][]byte, error) {
 	return result, nil
 }
 
-// SetMulti 批量设置缓存
-func (g *Group) SetMulti(values map[string][]byte, ttl int64) error {
+// SetMulti 批量设置缓存，tags 附加到本次写入的每个 key 上
+func (g *Group) SetMulti(values map[string][]byte, ttl int64, tags ...string) error {
 	for key, value := range values {
 		byteView := ByteView{b: cloneBytes(value)}
-		g.mainCache.add(key, byteView, ttl)
+		ck := g.cacheKey(key)
+		g.leases.invalidate(ck)
+		if g.shouldChunk(value) {
+			stored, err := g.storeChunks(key, byteView.b, ttl)
+			if err != nil {
+				return err
+			}
+			g.mainCache.directAdd(ck, stored, ttl)
+		} else {
+			g.mainCache.add(ck, g.encodeValue(key, byteView), ttl)
+		}
+		g.tagKey(ck, tags)
+		g.keyWritten(ck)
+		g.notifySet(key, byteView)
 	}
 	return nil
 }
@@ -182,9 +570,50 @@ func (g *Group) RegisterPeers(peers PeerPicker) {
 	g.peers = peers
 }
 
-func (g *Group) loadWithTTL(key string, ttl int64) (value ByteView, err error) {
+// broadcast 通过协程池并发地对 peers 执行 call，返回各节点结果之和及遇到的第一个错误
+func (g *Group) broadcast(peers []PeerGetter, call func(peer PeerGetter) (int64, error)) (int64, error) {
+	type result struct {
+		n   int64
+		err error
+	}
+	resultCh := make(chan result, len(peers))
+	for _, peer := range peers {
+		peer := peer
+		task := func() {
+			n, err := call(peer)
+			resultCh <- result{n, err}
+		}
+		// 提交失败时同步执行
+		if err := g.goroutinePool.Submit(task); err != nil {
+			task()
+		}
+	}
+
+	var total int64
+	var firstErr error
+	for range peers {
+		res := <-resultCh
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			continue
+		}
+		total += res.n
+	}
+	return total, firstErr
+}
+
+// loadWithTTL 加载 key 并写入 ck，返回的值可能是存储形式，由调用方还原。ck 在加载开始前确定，
+// 加载期间代数被递增时结果写入旧代数，不会被新的读取看到
+func (g *Group) loadWithTTL(key, ck string, ttl int64) (value ByteView, err error) {
 	// 每个 key 只会被加载一次，无论并发调用有多少
-	viewi, err, shared := g.loader.Do(key, func() (interface{}, error) {
+	viewi, err, shared := g.loader.Do(ck, func() (interface{}, error) {
+		if g.disk != nil {
+			if value, ok := g.getFromDisk(key, ck); ok {
+				return value, nil
+			}
+		}
 		if g.peers != nil {
 			if peer, ok := g.peers.PickPeer(key); ok {
 				// 通过协程池限流后端 RPC 调用
@@ -193,25 +622,41 @@ func (g *Group) loadWithTTL(key string, ttl int64) (value ByteView, err error) {
 					err   error
 				}
 				resultCh := make(chan result, 1)
+				// 与 Getter 加载相同，在填充租约内写回，RPC 期间 key 被 Delete/Set 时不写回
+				token, leased := g.leases.grant(ck, false)
 				submitErr := g.goroutinePool.Submit(func() {
 					peerValue, peerErr := g.getFromPeer(peer, key)
 					resultCh <- result{peerValue, peerErr}
 				})
 				if submitErr != nil {
 					asynclog.Printf("[GeeCache] pool submit failed: %v, falling back to local", submitErr)
+					if leased {
+						// 释放租约，由随后的本地加载重新申请
+						g.leases.release(ck, token)
+					}
 				} else {
 					res := <-resultCh
 
 					if res.err == nil {
-						g.mainCache.add(key, res.value, ttl)
+						// 需要分块的大值由所属节点分块缓存，本节点不保留副本
+						if leased {
+							if g.shouldChunk(res.value.b) {
+								g.leases.release(ck, token)
+							} else {
+								g.fillLease(ck, token, g.encodeValue(key, res.value), ttl, false, nil)
+							}
+						}
 						return res.value, nil
 					}
 					asynclog.Println("[GeeCache] Failed to get from peer", res.err)
+					if leased {
+						g.leases.release(ck, token)
+					}
 				}
 			}
 		}
 
-		return g.getLocallyWithTTL(key, ttl)
+		return g.getLocallyWithTTL(key, ck, ttl, false)
 	})
 
 	if err == nil {
@@ -229,24 +674,76 @@ func (g *Group) loadWithTTL(key string, ttl int64) (value ByteView, err error) {
 	return
 }
 
-func (g *Group) populateCache(key string, value ByteView, ttl int64) {
-	g.mainCache.add(key, value, ttl)
-}
-
-func (g *Group) getLocallyWithTTL(key string, ttl int64) (ByteView, error) {
-	bytes, err := g.getter.Get(key)
+// getLocallyWithTTL 通过 Getter 加载 key 并写入 ck：读取路径 direct 为 false（LRU-K 下需达到 K 次访问才进入缓存），
+// 预热时为 true
+func (g *Group) getLocallyWithTTL(key, ck string, ttl int64, direct bool) (ByteView, error) {
+	// 加载前申请填充租约：加载期间 key 被 Delete/Set 时租约作废，结果不再写回缓存；
+	// 已有其他加载持有租约时同样只返回结果而不写回
+	token, leased := g.leases.grant(ck, false)
+
+	var bytes []byte
+	var tags []string
+	var err error
+	if tagged, ok := g.getter.(TaggedGetter); ok {
+		bytes, tags, err = tagged.GetWithTags(key)
+	} else {
+		bytes, err = g.getter.Get(key)
+	}
 	if err != nil {
 		// 负缓存：缓存空值，短 TTL 防穿透
-		g.populateCache(key, ByteView{}, g.negativeCacheTTL)
-		asynclog.Printf("[GeeCache] negative cache set for key=%s ttl=%ds", key, g.negativeCacheTTL)
+		if leased && g.fillLease(ck, token, ByteView{}, g.negativeCacheTTL, direct, nil) {
+			asynclog.Printf("[GeeCache] negative cache set for key=%s ttl=%ds", key, g.negativeCacheTTL)
+		}
 		return ByteView{}, err
 	}
 	value := ByteView{b: cloneBytes(bytes)}
-	g.populateCache(key, value, ttl)
+	if !leased {
+		return value, nil
+	}
+	if !g.shouldChunk(value.b) {
+		g.fillLease(ck, token, g.encodeValue(key, value), ttl, direct, tags)
+		return value, nil
+	}
+	// 分块在租约外写入，避免持有租约期间访问远程节点；清单跳过 LRU-K 的门槛，与分块一同进入缓存
+	stored, err := g.storeChunks(key, value.b, ttl)
+	if err != nil {
+		asynclog.Printf("[GeeCache] %v, not caching", err)
+		g.leases.release(ck, token)
+		return value, nil
+	}
+	g.fillLease(ck, token, stored, ttl, true, tags)
 	return value, nil
 }
 
+// fillLease 兑现 ck 上的填充租约，将已编码的 stored 写入缓存并记录标签，返回是否写入。
+// 租约在写入前于分片锁内确认，期间被作废或接管时不写入
+func (g *Group) fillLease(ck string, token uint64, stored ByteView, ttl int64, direct bool, tags []string) bool {
+	return g.leases.redeem(ck, token, func(held func() bool) bool {
+		return g.mainCache.addIf(ck, stored, ttl, direct, func(bool) bool {
+			if !held() {
+				return false
+			}
+			g.tags.set(ck, tags)
+			return true
+		})
+	})
+}
+
 func (g *Group) getFromPeer(peer PeerGetter, key string) (ByteView, error) {
+	if g.compressedTransfer {
+		if encoded, ok := peer.(PeerEncodedGetter); ok {
+			bytes, compressed, err := encoded.GetEncoded(g.name, key, g.compressor.Name())
+			if err != nil {
+				return ByteView{}, err
+			}
+			view := ByteView{b: bytes}
+			if compressed {
+				view.enc = encCompressed
+			}
+			return view, nil
+		}
+	}
+
 	bytes, err := peer.Get(g.name, key)
 	if err != nil {
 		return ByteView{}, err
@@ -0,0 +1,246 @@
+package mygocache
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultCacheBytes NewGroup 未指定容量时使用的默认缓存容量（字节）
+const DefaultCacheBytes int64 = 64 << 20
+
+// groupOptions 汇总构造 Group 时可配置的参数
+type groupOptions struct {
+	cacheBytes           int64
+	defaultTTL           int64
+	negativeCacheTTL     int64
+	leaseTTL             int64
+	strategy             CacheStrategy
+	k                    int
+	shardCount           int
+	poolMinWorkers       int
+	poolMaxWorkers       int
+	poolQueueSize        int
+	onEvicted            func(key string, value ByteView)
+	evictionHooks        []EvictionHook
+	compressor           Compressor
+	compressThreshold    int
+	compressedTransfer   bool
+	encryption           bool
+	keys                 KeyProvider
+	chunkThreshold       int
+	chunkSize            int
+	snapshotPath         string
+	snapshotInterval     time.Duration
+	appendLogPath        string
+	fsyncPolicy          FsyncPolicy
+	appendLogRewriteSize int64
+	diskDir              string
+	diskBytes            int64
+	hotKeyCapacity       int
+	hotKeySampleRate     int
+}
+
+// defaultGroupOptions 返回与 NewGroupWithOptions 历史行为一致的默认参数
+func defaultGroupOptions() groupOptions {
+	return groupOptions{
+		cacheBytes:           DefaultCacheBytes,
+		negativeCacheTTL:     DefaultNegativeCacheTTL,
+		leaseTTL:             DefaultLeaseTTL,
+		strategy:             StrategyLRUK,
+		k:                    2,
+		shardCount:           defaultShardCount,
+		poolMinWorkers:       10,
+		poolMaxWorkers:       500,
+		poolQueueSize:        1000,
+		appendLogRewriteSize: DefaultAppendLogRewriteSize,
+	}
+}
+
+// Option 用于配置 NewGroup 创建的 Group
+type Option func(*groupOptions)
+
+// WithCacheBytes 设置缓存容量（字节），在各分片间平均分配
+func WithCacheBytes(cacheBytes int64) Option {
+	return func(o *groupOptions) { o.cacheBytes = cacheBytes }
+}
+
+// WithDefaultTTL 设置 Get 加载的值的默认 TTL（秒），0 表示永不过期
+func WithDefaultTTL(ttl int64) Option {
+	return func(o *groupOptions) { o.defaultTTL = ttl }
+}
+
+// WithNegativeCacheTTL 设置负缓存 TTL（秒），0 表示负缓存永不过期
+func WithNegativeCacheTTL(ttl int64) Option {
+	return func(o *groupOptions) { o.negativeCacheTTL = ttl }
+}
+
+// WithLeaseTTL 设置填充租约的有效期（秒）
+func WithLeaseTTL(ttl int64) Option {
+	return func(o *groupOptions) { o.leaseTTL = ttl }
+}
+
+// WithStrategy 设置淘汰策略，k 为 LRU-K 的访问次数阈值（StrategyLRU 与 StrategyArena 下忽略）
+func WithStrategy(strategy CacheStrategy, k int) Option {
+	return func(o *groupOptions) {
+		o.strategy = strategy
+		o.k = k
+	}
+}
+
+// WithShardCount 设置缓存分片数，必须是 2 的幂
+func WithShardCount(n int) Option {
+	return func(o *groupOptions) { o.shardCount = n }
+}
+
+// WithPoolSize 设置协程池的最小、最大 worker 数与任务队列容量
+func WithPoolSize(minWorkers, maxWorkers, queueSize int) Option {
+	return func(o *groupOptions) {
+		o.poolMinWorkers = minWorkers
+		o.poolMaxWorkers = maxWorkers
+		o.poolQueueSize = queueSize
+	}
+}
+
+// WithEvictionCallback 设置条目被淘汰、过期或删除时的回调，key 为调用方写入时使用的 key。
+// 回调与 WithEvictionHook 的钩子一样通过协程池异步执行，可以访问 Group；负缓存条目与覆盖写入不会触发回调
+func WithEvictionCallback(fn func(key string, value ByteView)) Option {
+	return func(o *groupOptions) { o.onEvicted = fn }
+}
+
+// WithEvictionHook 添加条目被淘汰、过期、删除或覆盖时的钩子，可多次使用以添加多个钩子。
+// 钩子通过协程池异步执行，可以安全地访问 Group；负缓存条目不会触发钩子
+func WithEvictionHook(hook EvictionHook) Option {
+	return func(o *groupOptions) { o.evictionHooks = append(o.evictionHooks, hook) }
+}
+
+// WithCompression 对不小于 threshold 字节的值使用 c 压缩后存储，容量按压缩后的大小计算，
+// 读取时再解压。压缩后不小于原值的数据按原样存储
+func WithCompression(c Compressor, threshold int) Option {
+	return func(o *groupOptions) {
+		o.compressor = c
+		o.compressThreshold = threshold
+	}
+}
+
+// WithCompressedTransfer 从远程节点获取数据时保持压缩形式传输，由本节点在读取时解压。
+// 需要同时使用 WithCompression，远程节点的压缩算法不同名时退化为传输原值
+func WithCompressedTransfer() Option {
+	return func(o *groupOptions) { o.compressedTransfer = true }
+}
+
+// WithEncryption 使用 keys 提供的密钥以 AES-GCM 加密存储在本节点缓存中的值（在压缩之后），读取时再解密。
+// 密钥轮换后以旧密钥加密的值仍可读取；解密或校验失败的值被删除并按未命中处理，计入 Stats.IntegrityFailures。
+// 加密只作用于本节点的存储，节点间传输的是解密后的值
+func WithEncryption(keys KeyProvider) Option {
+	return func(o *groupOptions) {
+		o.encryption = true
+		o.keys = keys
+	}
+}
+
+// WithChunking 将超过 threshold 字节的值切分为 chunkSize 字节的分块，经一致性哈希分散到各节点存储，
+// 读取时并发获取并校验后组装，使单个值不再受限于一个分片的容量。threshold 为 0 表示不分块
+func WithChunking(threshold, chunkSize int) Option {
+	return func(o *groupOptions) {
+		o.chunkThreshold = threshold
+		o.chunkSize = chunkSize
+	}
+}
+
+// WithSnapshotFile 创建 Group 时从 path 加载快照（文件不存在时跳过，加载失败只记录日志），
+// 之后每隔 interval 将快照写入 path，Close 时再写入最后一次。interval 为 0 表示只在 Close 时写入
+func WithSnapshotFile(path string, interval time.Duration) Option {
+	return func(o *groupOptions) {
+		o.snapshotPath = path
+		o.snapshotInterval = interval
+	}
+}
+
+// WithAppendLog 将本节点上的显式写入（Set、SetMulti、Add、Replace、CompareAndSet、Incr、LeaseSet 与哈希操作）、
+// 删除、清空、代数递增与过期事件追加记录到 path，创建 Group 时重放日志以恢复这些无法从 Getter 加载的值。
+// policy 决定同步到磁盘的时机；日志增长到上次压缩后的两倍且不小于 DefaultAppendLogRewriteSize 时在后台压缩
+func WithAppendLog(path string, policy FsyncPolicy) Option {
+	return func(o *groupOptions) {
+		o.appendLogPath = path
+		o.fsyncPolicy = policy
+	}
+}
+
+// WithAppendLogRewriteSize 设置追加日志自动压缩的最小文件大小（字节），0 表示不自动压缩
+func WithAppendLogRewriteSize(size int64) Option {
+	return func(o *groupOptions) { o.appendLogRewriteSize = size }
+}
+
+// WithDiskTier 在内存之下启用容量为 maxBytes 字节的磁盘层，段文件存放在 dir 中（每个 Group 使用独立的目录）。
+// 因容量不足被淘汰的值由后台协程写入磁盘并保留原有的 TTL，内存未命中时先查磁盘再访问远程节点或 Getter，命中的值移回内存
+func WithDiskTier(dir string, maxBytes int64) Option {
+	return func(o *groupOptions) {
+		o.diskDir = dir
+		o.diskBytes = maxBytes
+	}
+}
+
+// WithHotKeys 以 Space-Saving 算法统计 Get 访问最频繁的 key，最多跟踪 capacity 个，
+// 每 sampleRate 次访问记录一次以降低开销，可通过 HotKeys 查询。未启用时 Get 只多一次判空
+func WithHotKeys(capacity, sampleRate int) Option {
+	return func(o *groupOptions) {
+		o.hotKeyCapacity = capacity
+		o.hotKeySampleRate = sampleRate
+	}
+}
+
+// validate 检查参数是否合法，返回描述具体问题的错误
+func (o *groupOptions) validate() error {
+	switch {
+	case o.cacheBytes <= 0:
+		return fmt.Errorf("cache bytes must be positive, got %d", o.cacheBytes)
+	case o.defaultTTL < 0:
+		return fmt.Errorf("default ttl must not be negative, got %d", o.defaultTTL)
+	case o.negativeCacheTTL < 0:
+		return fmt.Errorf("negative cache ttl must not be negative, got %d", o.negativeCacheTTL)
+	case o.leaseTTL <= 0:
+		return fmt.Errorf("lease ttl must be positive, got %d", o.leaseTTL)
+	case o.strategy != StrategyLRU && o.strategy != StrategyLRUK && o.strategy != StrategyArena:
+		return fmt.Errorf("unknown cache strategy %d", o.strategy)
+	case o.strategy == StrategyLRUK && o.k < 1:
+		return fmt.Errorf("lru-k threshold must be at least 1, got %d", o.k)
+	case o.shardCount <= 0 || o.shardCount&(o.shardCount-1) != 0:
+		return fmt.Errorf("shard count must be a power of 2, got %d", o.shardCount)
+	case o.poolMinWorkers <= 0:
+		return fmt.Errorf("pool min workers must be positive, got %d", o.poolMinWorkers)
+	case o.poolMaxWorkers < o.poolMinWorkers:
+		return fmt.Errorf("pool max workers %d is less than min workers %d", o.poolMaxWorkers, o.poolMinWorkers)
+	case o.poolQueueSize <= 0:
+		return fmt.Errorf("pool queue size must be positive, got %d", o.poolQueueSize)
+	case o.compressThreshold < 0:
+		return fmt.Errorf("compress threshold must not be negative, got %d", o.compressThreshold)
+	case o.compressedTransfer && o.compressor == nil:
+		return fmt.Errorf("compressed transfer requires a compressor")
+	case o.encryption && o.keys == nil:
+		return fmt.Errorf("encryption requires a key provider")
+	case o.chunkThreshold < 0:
+		return fmt.Errorf("chunk threshold must not be negative, got %d", o.chunkThreshold)
+	case o.chunkThreshold > 0 && (o.chunkSize <= 0 || o.chunkSize > o.chunkThreshold):
+		return fmt.Errorf("chunk size must be in (0, %d], got %d", o.chunkThreshold, o.chunkSize)
+	case o.snapshotInterval < 0:
+		return fmt.Errorf("snapshot interval must not be negative, got %v", o.snapshotInterval)
+	case o.snapshotInterval > 0 && o.snapshotPath == "":
+		return fmt.Errorf("snapshot interval requires a snapshot file")
+	case o.fsyncPolicy < FsyncEverySecond || o.fsyncPolicy > FsyncNever:
+		return fmt.Errorf("unknown fsync policy %d", o.fsyncPolicy)
+	case o.appendLogRewriteSize < 0:
+		return fmt.Errorf("append log rewrite size must not be negative, got %d", o.appendLogRewriteSize)
+	case o.diskDir != "" && o.diskBytes <= 0:
+		return fmt.Errorf("disk tier size must be positive, got %d", o.diskBytes)
+	case o.hotKeyCapacity < 0:
+		return fmt.Errorf("hot key capacity must not be negative, got %d", o.hotKeyCapacity)
+	case o.hotKeyCapacity > 0 && o.hotKeySampleRate < 1:
+		return fmt.Errorf("hot key sample rate must be at least 1, got %d", o.hotKeySampleRate)
+	}
+	for _, hook := range o.evictionHooks {
+		if hook == nil {
+			return fmt.Errorf("eviction hook must not be nil")
+		}
+	}
+	return nil
+}
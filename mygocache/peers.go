@@ -9,3 +9,69 @@ type PeerPicker interface {
 type PeerGetter interface {
 	Get(group string, key string) ([]byte, error)
 }
+
+// PeerWriter 用于在远程节点上执行条件写入，错误语义与 Group 上的同名方法一致
+type PeerWriter interface {
+	Add(group string, key string, value []byte, ttl int64) (uint64, error)
+	CompareAndSet(group string, key string, value []byte, version uint64, ttl int64) (uint64, error)
+	CompareAndDelete(group string, key string, version uint64) error
+}
+
+// PeerLeaser 用于在 key 所属的远程节点上申请与兑现填充租约
+type PeerLeaser interface {
+	LeaseGet(group string, key string) (LeaseResult, error)
+	LeaseSet(group string, key string, value []byte, token uint64, ttl int64) error
+}
+
+// PeerLister 用于列出除本节点外的所有远程节点，供需要广播的操作使用
+type PeerLister interface {
+	ListPeers() []PeerGetter
+}
+
+// PeerTagInvalidator 用于让远程节点删除其本地携带指定标签的条目
+type PeerTagInvalidator interface {
+	InvalidateTag(group string, tag string) (int64, error)
+}
+
+// PeerIncrementer 用于在远程节点上原子地增减计数器
+type PeerIncrementer interface {
+	Incr(group string, key string, delta, initial, ttl int64) (int64, error)
+}
+
+// PeerGenerationBumper 用于让远程节点递增其本地命名空间的代数
+type PeerGenerationBumper interface {
+	BumpGeneration(group string, namespace string) (uint64, error)
+}
+
+// PeerEncodedGetter 用于从远程节点获取保持压缩形式的值。
+// 远程节点使用名为 compression 的压缩算法且该值已压缩时返回 compressed=true，否则返回原值
+type PeerEncodedGetter interface {
+	GetEncoded(group string, key string, compression string) (value []byte, compressed bool, err error)
+}
+
+// PeerHasher 用于在 key 所属的远程节点上操作哈希值，错误语义与 Group 上的同名方法一致
+type PeerHasher interface {
+	HSet(group string, key string, fields map[string][]byte, ttl int64) (int, error)
+	HGet(group string, key string, field string) ([]byte, error)
+	HDel(group string, key string, fields []string) (int, error)
+	HGetAll(group string, key string) (map[string][]byte, error)
+	HIncrBy(group string, key string, field string, delta, ttl int64) (int64, error)
+}
+
+// PeerChunkStore 用于在远程节点上批量写入与读取大值的分块。
+// GetMulti 只返回远程节点已缓存的分块，不触发加载
+type PeerChunkStore interface {
+	SetChunks(group string, chunks map[string][]byte, ttl int64) error
+	GetMulti(group string, keys []string) (map[string][]byte, error)
+}
+
+// PeerLocator 用于查询本节点的地址与 key 按一致性哈希所属节点的地址
+type PeerLocator interface {
+	Self() string
+	Owner(key string) string
+}
+
+// PeerDumper 用于分页读取远程节点已缓存的值，语义与 Group.Dump 一致
+type PeerDumper interface {
+	Dump(group string, cursor string, count int, owner string) ([]DumpEntry, string, error)
+}
@@ -0,0 +1,237 @@
+package pubsub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnknownSubscription 表示远程订阅会话不存在或已因空闲超时被回收
+var ErrUnknownSubscription = errors.New("unknown subscription")
+
+// DefaultBuffer 每个订阅者的默认队列容量，队列已满时新消息被丢弃
+const DefaultBuffer = 256
+
+// Message 表示一条发布到频道的消息
+type Message struct {
+	Channel string
+	Payload []byte
+}
+
+// Subscription 表示对一个频道的订阅。消息至多投递一次：
+// 订阅者队列已满时新消息被丢弃并计入 Dropped
+type Subscription struct {
+	channel string
+	ch      chan Message
+	dropped uint64
+	broker  *Broker
+	once    sync.Once
+}
+
+// C 返回接收消息的 channel，订阅关闭后该 channel 被关闭
+func (s *Subscription) C() <-chan Message {
+	return s.ch
+}
+
+// Dropped 返回累计因队列已满而丢弃的消息数
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close 取消订阅并关闭消息 channel（幂等）
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.broker.remove(s)
+		close(s.ch)
+	})
+}
+
+// Broker 是单个节点上的内存消息代理，负责将消息分发给本节点上的订阅者。
+// Broker 不在节点间转发消息：发布方与订阅方需按一致性哈希将同一频道路由到同一节点
+type Broker struct {
+	mu       sync.RWMutex
+	channels map[string]map[*Subscription]struct{}
+}
+
+// NewBroker 创建消息代理
+func NewBroker() *Broker {
+	return &Broker{channels: make(map[string]map[*Subscription]struct{})}
+}
+
+// Publish 以非阻塞方式将 payload 投递给 channel 的所有订阅者，返回成功入队的订阅者数量
+func (b *Broker) Publish(channel string, payload []byte) int {
+	msg := Message{Channel: channel, Payload: payload}
+
+	// 持有读锁期间发送，保证不会向已关闭的 channel 发送
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	delivered := 0
+	for s := range b.channels[channel] {
+		select {
+		case s.ch <- msg:
+			delivered++
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+	return delivered
+}
+
+// Subscribe 订阅 channel，buffer 为订阅者的队列容量，不大于 0 时使用 DefaultBuffer
+func (b *Broker) Subscribe(channel string, buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	s := &Subscription{channel: channel, ch: make(chan Message, buffer), broker: b}
+
+	b.mu.Lock()
+	subs, ok := b.channels[channel]
+	if !ok {
+		subs = make(map[*Subscription]struct{})
+		b.channels[channel] = subs
+	}
+	subs[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+// remove 将订阅从频道中移除，频道没有订阅者时一并删除
+func (b *Broker) remove(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if subs, ok := b.channels[s.channel]; ok {
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(b.channels, s.channel)
+		}
+	}
+}
+
+// session 表示一个远程订阅会话
+type session struct {
+	sub      *Subscription
+	lastPoll time.Time
+	reported uint64 // 已通过 Poll 报告过的丢弃数
+}
+
+// Poller 为无法保持长连接的远程订阅者维护订阅会话，订阅者以长轮询方式拉取消息。
+// 超过 idleTimeout 未轮询的会话会在随后的 Open 或 Poll 中被回收
+type Poller struct {
+	broker      *Broker
+	mu          sync.Mutex
+	sessions    map[string]*session
+	idleTimeout time.Duration
+	sweepAt     int       // 会话数量达到该值时回收空闲会话
+	nextSweep   time.Time // 到达该时间后回收空闲会话，会话数量很少时同样能被回收
+}
+
+// NewPoller 创建基于 broker 的长轮询会话管理器
+func NewPoller(broker *Broker, idleTimeout time.Duration) *Poller {
+	return &Poller{
+		broker:      broker,
+		sessions:    make(map[string]*session),
+		idleTimeout: idleTimeout,
+		sweepAt:     64,
+		nextSweep:   time.Now().Add(idleTimeout),
+	}
+}
+
+// Open 创建订阅 channel 的会话并返回会话 ID
+func (p *Poller) Open(channel string, buffer int) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maybeSweep(time.Now())
+	p.sessions[id] = &session{sub: p.broker.Subscribe(channel, buffer), lastPoll: time.Now()}
+	return id, nil
+}
+
+// Poll 拉取会话中至多 max 条消息（不大于 0 时为 DefaultBuffer），队列为空时等待新消息或 ctx 结束。
+// dropped 为自上次 Poll 以来因队列已满而丢弃的消息数
+func (p *Poller) Poll(ctx context.Context, id string, max int) (msgs []Message, dropped uint64, err error) {
+	if max <= 0 {
+		max = DefaultBuffer
+	}
+	p.mu.Lock()
+	now := time.Now()
+	s, ok := p.sessions[id]
+	if ok {
+		s.lastPoll = now
+	}
+	p.maybeSweep(now)
+	p.mu.Unlock()
+	if !ok {
+		return nil, 0, ErrUnknownSubscription
+	}
+
+	select {
+	case msg, ok := <-s.sub.C():
+		if !ok {
+			return nil, 0, ErrUnknownSubscription
+		}
+		msgs = append(msgs, msg)
+	case <-ctx.Done():
+	}
+drain:
+	for len(msgs) > 0 && len(msgs) < max {
+		select {
+		case msg, ok := <-s.sub.C():
+			if !ok {
+				break drain
+			}
+			msgs = append(msgs, msg)
+		default:
+			break drain
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.lastPoll = time.Now()
+	total := s.sub.Dropped()
+	dropped, s.reported = total-s.reported, total
+	return msgs, dropped, nil
+}
+
+// Close 关闭会话并取消订阅，会话不存在时返回 false
+func (p *Poller) Close(id string) bool {
+	p.mu.Lock()
+	s, ok := p.sessions[id]
+	delete(p.sessions, id)
+	p.mu.Unlock()
+	if ok {
+		s.sub.Close()
+	}
+	return ok
+}
+
+// maybeSweep 在会话数量达到阈值或距上次回收超过 idleTimeout 时回收空闲会话。调用方必须已持有 p.mu
+func (p *Poller) maybeSweep(now time.Time) {
+	if len(p.sessions) >= p.sweepAt || !now.Before(p.nextSweep) {
+		p.sweep(now)
+	}
+}
+
+// sweep 回收空闲会话，并按剩余数量调整下次回收的阈值。调用方必须已持有 p.mu
+func (p *Poller) sweep(now time.Time) {
+	for id, s := range p.sessions {
+		if now.Sub(s.lastPoll) > p.idleTimeout {
+			delete(p.sessions, id)
+			s.sub.Close()
+		}
+	}
+	p.sweepAt = 2 * len(p.sessions)
+	if p.sweepAt < 64 {
+		p.sweepAt = 64
+	}
+	p.nextSweep = now.Add(p.idleTimeout)
+}
@@ -0,0 +1,99 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPublishSubscribe(t *testing.T) {
+	b := NewBroker()
+	news, sports := b.Subscribe("news", 2), b.Subscribe("sports", 2)
+	defer sports.Close()
+
+	if n := b.Publish("news", []byte("a")); n != 1 {
+		t.Fatalf("expected 1 receiver, got %d", n)
+	}
+	b.Publish("news", []byte("b"))
+	// 队列已满，至多一次投递：丢弃而不阻塞发布方
+	if n := b.Publish("news", []byte("c")); n != 0 || news.Dropped() != 1 {
+		t.Fatalf("expected message dropped, got receivers=%d dropped=%d", n, news.Dropped())
+	}
+	for _, want := range []string{"a", "b"} {
+		if msg := <-news.C(); string(msg.Payload) != want || msg.Channel != "news" {
+			t.Fatalf("expected %s on news, got %+v", want, msg)
+		}
+	}
+	if len(sports.C()) != 0 {
+		t.Fatalf("sports should not receive news")
+	}
+
+	news.Close()
+	news.Close()
+	if _, ok := <-news.C(); ok {
+		t.Fatalf("channel should be closed after unsubscribe")
+	}
+	if n := b.Publish("news", []byte("d")); n != 0 {
+		t.Fatalf("expected no receivers after unsubscribe, got %d", n)
+	}
+}
+
+func TestPoller(t *testing.T) {
+	b := NewBroker()
+	p := NewPoller(b, time.Minute)
+	id, err := p.Open("jobs", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	if msgs, _, err := p.Poll(ctx, id, 10); err != nil || len(msgs) != 0 {
+		t.Fatalf("expected empty poll on timeout, got %v %v", msgs, err)
+	}
+	cancel()
+
+	for _, payload := range []string{"1", "2", "3"} {
+		b.Publish("jobs", []byte(payload))
+	}
+	msgs, dropped, err := p.Poll(context.Background(), id, 10)
+	if err != nil || len(msgs) != 2 || dropped != 1 {
+		t.Fatalf("expected 2 messages and 1 dropped, got %d %d %v", len(msgs), dropped, err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		b.Publish("jobs", []byte("4"))
+	}()
+	msgs, dropped, err = p.Poll(context.Background(), id, 10)
+	if err != nil || len(msgs) != 1 || string(msgs[0].Payload) != "4" || dropped != 0 {
+		t.Fatalf("expected message 4, got %v %d %v", msgs, dropped, err)
+	}
+
+	if !p.Close(id) {
+		t.Fatalf("expected session to be closed")
+	}
+	if _, _, err := p.Poll(context.Background(), id, 10); err != ErrUnknownSubscription {
+		t.Fatalf("expected ErrUnknownSubscription, got %v", err)
+	}
+}
+
+func TestPollerSweepsIdleSessions(t *testing.T) {
+	b := NewBroker()
+	p := NewPoller(b, 20*time.Millisecond)
+	idle, _ := p.Open("jobs", 2)
+	active, _ := p.Open("jobs", 2)
+
+	// 会话数量远低于数量阈值，空闲会话仍应在超时后被其他会话的 Poll 回收
+	time.Sleep(40 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, _, err := p.Poll(ctx, active, 10); err != nil {
+		t.Fatalf("active session should survive the sweep: %v", err)
+	}
+	if n := b.Publish("jobs", []byte("x")); n != 1 {
+		t.Fatalf("expected only the active session to receive the message, got %d receivers", n)
+	}
+	if _, _, err := p.Poll(context.Background(), idle, 10); err != ErrUnknownSubscription {
+		t.Fatalf("expected the idle session to be swept, got %v", err)
+	}
+}
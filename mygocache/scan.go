@@ -0,0 +1,110 @@
+package mygocache
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCursor 表示 Scan 的游标无法解析
+var ErrInvalidCursor = errors.New("invalid scan cursor")
+
+// defaultScanCount Scan 未指定 count 时每次检查的条目数量上限
+const defaultScanCount = 10
+
+// Scan 增量遍历本节点缓存中的键，用法类似 Redis SCAN：
+// 以空游标开始，每次至多检查 count 个条目，返回其中匹配 pattern 的键及下一次调用使用的游标，
+// 返回的游标为空时遍历结束。与 Redis 一样，一页返回的键可能少于 count 个甚至为空，
+// 游标非空时应继续遍历；pattern 很少匹配时单次调用的开销也不会随缓存大小增长。
+// pattern 支持 * 与 ? 通配符（? 匹配单个字节），为空时匹配所有键。
+// 各分片内按键的哈希顺序遍历，游标记录最后访问的位置，每次调用只访问本页经过的条目，
+// 且只在复制一批键时短暂持有分片锁；
+// 遍历全程存在的键恰好返回一次，遍历期间新增或删除的键可能返回也可能不返回；
+// 已被 BumpGeneration 作废的旧代数条目不会返回
+func (g *Group) Scan(cursor string, pattern string, count int) ([]string, string, error) {
+	shard, after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if count <= 0 {
+		count = defaultScanCount
+	}
+
+	var keys []string
+	examined := 0
+	for shard < len(g.mainCache.shards) {
+		need := count - examined
+		batch := g.mainCache.shardKeys(shard, after, need)
+		examined += len(batch)
+		for _, ck := range batch {
+			// 跳过属于旧代数的条目与内部的分块
+			key, ok := g.gens.userKey(ck)
+			if ok && !isChunkKey(key) && matchPattern(pattern, key) {
+				keys = append(keys, key)
+			}
+		}
+		if len(batch) == need {
+			// 已检查 count 个条目，分片中可能还有更多的键，游标记录最后访问的缓存键
+			return keys, encodeCursor(shard, batch[len(batch)-1]), nil
+		}
+		shard++
+		after = ""
+	}
+	return keys, "", nil
+}
+
+// encodeCursor 将分片序号与该分片内最后访问的缓存键编码为不透明游标
+func encodeCursor(shard int, after string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(shard) + ":" + after))
+}
+
+// decodeCursor 解析 encodeCursor 生成的游标，空游标表示从头开始
+func decodeCursor(cursor string) (shard int, after string, err error) {
+	if cursor == "" {
+		return 0, "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return 0, "", ErrInvalidCursor
+	}
+	shard, err = strconv.Atoi(parts[0])
+	if err != nil || shard < 0 {
+		return 0, "", ErrInvalidCursor
+	}
+	return shard, parts[1], nil
+}
+
+// matchPattern 判断 key 是否匹配 glob 模式（* 匹配任意字节序列，? 匹配单个字节）
+func matchPattern(pattern, key string) bool {
+	if pattern == "" {
+		return true
+	}
+	// 回溯匹配：记录最近一个 * 的位置，失配时让它多吞一个字符
+	p, k := 0, 0
+	star, mark := -1, 0
+	for k < len(key) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == key[k]):
+			p++
+			k++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, k
+			p++
+		case star >= 0:
+			p = star + 1
+			mark++
+			k = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,547 @@
+package mygocache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"mygocache/asynclog"
+	"mygocache/lru"
+)
+
+// ErrSnapshotCorrupt 表示快照被截断、格式错误或校验和不匹配
+var ErrSnapshotCorrupt = errors.New("snapshot is corrupt")
+
+// 快照格式（整数为 varint，字节串与字符串以 uvarint 长度为前缀）：
+//
+//	"GCSN" | 版本号（1 字节）| 创建时间（Unix 秒）| Group 名称
+//	记录 ...（类型 1 字节，内容见 record* 常量）
+//	recordEnd | 此前所有字节的 CRC-32C（4 字节，大端序）
+const (
+	snapshotMagic   = "GCSN"
+	snapshotVersion = 1
+)
+
+// 快照记录的类型
+const (
+	recordEnd        byte = iota // 记录结束，其后为校验和
+	recordGeneration             // 代数：命名空间（空表示整个 Group）| 代数
+	recordValue                  // 值：key | 编码 | 剩余 TTL | 值 | 标签
+	recordHash                   // 哈希值：key | 剩余 TTL | 字段数 | 字段名与值 ... | 标签
+	recordHistory                // LRU-K 访问历史：key | 时间戳数 | 时间戳 ...
+)
+
+// snapshotPrealloc 不超过该长度的字节串按记录的长度一次分配，更长的按实际读到的数据增长，
+// 避免损坏的长度导致过大的分配
+const snapshotPrealloc = 1 << 20
+
+var snapshotTable = crc32.MakeTable(crc32.Castagnoli)
+
+// snapshotEntry 是快照中的一个缓存条目，key 为嵌入代数后实际写入缓存的 key。
+// fields 非 nil 时条目为哈希值，否则为 value（存储形式，可能经过压缩或加密）
+type snapshotEntry struct {
+	key       string
+	value     ByteView
+	fields    map[string][]byte
+	expiresAt int64 // 过期时间戳，0 表示永不过期
+	tags      []string
+}
+
+// snapshotHistory 是 LRU-K 下尚未进入缓存的 key 的访问历史
+type snapshotHistory struct {
+	key string
+	ts  []int64
+}
+
+// Snapshot 将本节点缓存的内容写入 w，包括各条目的 key、值、剩余 TTL 与标签，Group 与命名空间的代数，
+// 以及 LRU-K 下尚未进入缓存的 key 的访问历史。值按存储形式写入，压缩与加密的值保持原样。
+// 各分片依次加锁复制，快照不是整个缓存在某一时刻的原子视图
+func (g *Group) Snapshot(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	sw := &snapshotWriter{w: bw, crc: crc32.New(snapshotTable)}
+	now := time.Now().Unix()
+	sw.writeRaw([]byte(snapshotMagic))
+	sw.writeByte(snapshotVersion)
+	sw.writeVarint(now)
+	sw.writeString(g.name)
+
+	group, namespaces := g.gens.snapshot()
+	if group > 0 {
+		sw.writeByte(recordGeneration)
+		sw.writeString("")
+		sw.writeUvarint(group)
+	}
+	for ns, gen := range namespaces {
+		sw.writeByte(recordGeneration)
+		sw.writeString(ns)
+		sw.writeUvarint(gen)
+	}
+
+	for i := range g.mainCache.shards {
+		for _, e := range g.mainCache.shardEntries(i) {
+			var ttl int64
+			if e.expiresAt > 0 {
+				// 至少保留 1 秒，0 表示永不过期
+				if ttl = e.expiresAt - now; ttl < 1 {
+					ttl = 1
+				}
+			}
+			e.tags = g.tags.tagsOf(e.key)
+			sw.writeEntry(e, uint64(ttl))
+		}
+		if sw.err != nil {
+			return sw.err
+		}
+	}
+
+	g.mainCache.rangeHistory(func(key string, ts []int64) bool {
+		sw.writeByte(recordHistory)
+		sw.writeString(key)
+		sw.writeUvarint(uint64(len(ts)))
+		for _, t := range ts {
+			sw.writeVarint(t)
+		}
+		return sw.err == nil
+	})
+
+	sw.finish()
+	if sw.err != nil {
+		return sw.err
+	}
+	return bw.Flush()
+}
+
+// Restore 读取 Snapshot 写入的快照并写回缓存。快照先完整读取并校验，校验失败时返回 ErrSnapshotCorrupt，
+// 缓存保持不变。条目的剩余 TTL 扣除快照创建以来经过的时间，已过期的条目被跳过；
+// 条目直接写入缓存，不经过 LRU-K 的 K 次访问门槛，也不通知 Watch 订阅者。
+// 代数只会被提升，不会回退。快照中的 Group 名称必须与本 Group 相同
+func (g *Group) Restore(r io.Reader) error {
+	sr := &snapshotReader{r: bufio.NewReader(r), crc: crc32.New(snapshotTable)}
+	magic := sr.readRaw(len(snapshotMagic))
+	if sr.err == nil && string(magic) != snapshotMagic {
+		return ErrSnapshotCorrupt
+	}
+	if version := sr.readByte(); sr.err == nil && version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", version)
+	}
+	createdAt := sr.readVarint()
+	if name := sr.readString(); sr.err == nil && name != g.name {
+		return fmt.Errorf("snapshot belongs to group %s", name)
+	}
+
+	var (
+		group      uint64
+		namespaces = make(map[string]uint64)
+		entries    []snapshotEntry
+		history    []snapshotHistory
+	)
+	for done := false; !done && sr.err == nil; {
+		switch kind := sr.readByte(); {
+		case sr.err != nil:
+		case kind == recordEnd:
+			done = true
+		case kind == recordGeneration:
+			ns, gen := sr.readString(), sr.readUvarint()
+			if ns == "" {
+				group = gen
+			} else {
+				namespaces[ns] = gen
+			}
+		case kind == recordValue || kind == recordHash:
+			e, ttl := sr.readEntry(kind)
+			if ttl > 0 {
+				e.expiresAt = createdAt + int64(ttl)
+			}
+			entries = append(entries, e)
+		case kind == recordHistory:
+			h := snapshotHistory{key: sr.readString()}
+			for n := sr.readUvarint(); n > 0 && sr.err == nil; n-- {
+				h.ts = append(h.ts, sr.readVarint())
+			}
+			history = append(history, h)
+		default:
+			sr.fail(ErrSnapshotCorrupt)
+		}
+	}
+	if err := sr.verify(); err != nil {
+		return err
+	}
+
+	g.gens.restore(group, namespaces)
+	now := time.Now().Unix()
+	for _, e := range entries {
+		g.restoreEntry(e, now)
+	}
+	for _, h := range history {
+		g.mainCache.restoreHistory(h.key, h.ts)
+	}
+	return nil
+}
+
+// restoreEntry 将 e 写回缓存，e 在 now 时已过期时跳过并返回 false
+func (g *Group) restoreEntry(e snapshotEntry, now int64) bool {
+	var ttl int64
+	if e.expiresAt > 0 {
+		if ttl = e.expiresAt - now; ttl <= 0 {
+			return false
+		}
+	}
+	if e.fields != nil {
+		if err := g.restoreHash(e.key, e.fields, ttl); err != nil {
+			asynclog.Printf("[GeeCache] restore hash %q of group %s failed: %v", e.key, g.name, err)
+			return false
+		}
+	} else {
+		g.mainCache.directAdd(e.key, e.value, ttl)
+	}
+	if len(e.tags) > 0 {
+		g.tagKey(e.key, e.tags)
+	}
+	return true
+}
+
+// restoreHash 用 fields 替换缓存 key ck 上的值
+func (g *Group) restoreHash(ck string, fields map[string][]byte, ttl int64) error {
+	_, err := g.mainCache.updateHash(ck, func(h *lru.Hash, old lru.Value) (int64, error) {
+		h.Range(func(field string, value []byte) bool {
+			h.Delete(field)
+			return true
+		})
+		for field, value := range fields {
+			h.Set(field, value)
+		}
+		return ttl, nil
+	})
+	return err
+}
+
+// SnapshotToFile 将快照写入 path：先写入同目录下的临时文件并同步到磁盘，再重命名覆盖 path，
+// 写入中途失败或进程崩溃不会破坏已有的快照
+func (g *Group) SnapshotToFile(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if err = g.Snapshot(f); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// RestoreFromFile 从 path 恢复快照，文件不存在时返回的错误满足 os.IsNotExist
+func (g *Group) RestoreFromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.Restore(f)
+}
+
+// snapshotter 周期性地将 Group 的快照写入文件
+type snapshotter struct {
+	path string
+	stop chan struct{}
+	done chan struct{}
+}
+
+// startSnapshots 启动周期写入快照的协程（interval 大于 0 时）
+func (g *Group) startSnapshots(path string, interval time.Duration) {
+	s := &snapshotter{path: path, stop: make(chan struct{}), done: make(chan struct{})}
+	g.snapshots = s
+	go func() {
+		defer close(s.done)
+		if interval <= 0 {
+			<-s.stop
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := g.SnapshotToFile(path); err != nil {
+					asynclog.Printf("[GeeCache] snapshot group %s to %s failed: %v", g.name, path, err)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// loadSnapshot 从快照文件恢复缓存，文件不存在时跳过
+func (g *Group) loadSnapshot() {
+	if g.snapshots == nil {
+		return
+	}
+	start := time.Now()
+	err := g.RestoreFromFile(g.snapshots.path)
+	switch {
+	case err == nil:
+		asynclog.Printf("[GeeCache] group %s restored %d entries from %s in %v",
+			g.name, g.mainCache.stats().ItemCount, g.snapshots.path, time.Since(start))
+	case !os.IsNotExist(err):
+		asynclog.Printf("[GeeCache] restore group %s from %s failed: %v", g.name, g.snapshots.path, err)
+	}
+}
+
+// stopSnapshots 停止周期写入快照的协程，并写入最后一次快照
+func (g *Group) stopSnapshots() {
+	if g.snapshots == nil {
+		return
+	}
+	close(g.snapshots.stop)
+	<-g.snapshots.done
+	if err := g.SnapshotToFile(g.snapshots.path); err != nil {
+		asynclog.Printf("[GeeCache] snapshot group %s to %s failed: %v", g.name, g.snapshots.path, err)
+	}
+}
+
+// snapshotWriter 在写入的同时计算 CRC-32C。写入出错后忽略后续写入，错误记录在 err 中
+type snapshotWriter struct {
+	w   io.Writer
+	crc hash.Hash32
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (sw *snapshotWriter) writeRaw(p []byte) {
+	if sw.err != nil {
+		return
+	}
+	if _, sw.err = sw.w.Write(p); sw.err == nil {
+		sw.crc.Write(p)
+	}
+}
+
+func (sw *snapshotWriter) writeByte(b byte) {
+	sw.buf[0] = b
+	sw.writeRaw(sw.buf[:1])
+}
+
+func (sw *snapshotWriter) writeUvarint(v uint64) {
+	sw.writeRaw(sw.buf[:binary.PutUvarint(sw.buf[:], v)])
+}
+
+func (sw *snapshotWriter) writeVarint(v int64) {
+	sw.writeRaw(sw.buf[:binary.PutVarint(sw.buf[:], v)])
+}
+
+func (sw *snapshotWriter) writeBytes(p []byte) {
+	sw.writeUvarint(uint64(len(p)))
+	sw.writeRaw(p)
+}
+
+func (sw *snapshotWriter) writeString(s string) {
+	sw.writeBytes([]byte(s))
+}
+
+func (sw *snapshotWriter) writeStrings(ss []string) {
+	sw.writeUvarint(uint64(len(ss)))
+	for _, s := range ss {
+		sw.writeString(s)
+	}
+}
+
+// writeEntry 写入 recordValue 或 recordHash 记录，ttl 写入 TTL 字段
+func (sw *snapshotWriter) writeEntry(e snapshotEntry, ttl uint64) {
+	if e.fields != nil {
+		sw.writeByte(recordHash)
+		sw.writeString(e.key)
+		sw.writeUvarint(ttl)
+		sw.writeUvarint(uint64(len(e.fields)))
+		for field, value := range e.fields {
+			sw.writeString(field)
+			sw.writeBytes(value)
+		}
+	} else {
+		sw.writeByte(recordValue)
+		sw.writeString(e.key)
+		sw.writeByte(byte(e.value.enc))
+		sw.writeUvarint(ttl)
+		sw.writeBytes(e.value.b)
+	}
+	sw.writeStrings(e.tags)
+}
+
+// finish 写入结束记录与校验和，校验和本身不计入 CRC
+func (sw *snapshotWriter) finish() {
+	sw.writeByte(recordEnd)
+	if sw.err != nil {
+		return
+	}
+	binary.BigEndian.PutUint32(sw.buf[:4], sw.crc.Sum32())
+	_, sw.err = sw.w.Write(sw.buf[:4])
+}
+
+// byteReader 是 snapshotReader 读取的数据源，如 *bufio.Reader 与 *bytes.Reader
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// snapshotReader 在读取的同时计算 CRC-32C。出错后后续读取返回零值，错误记录在 err 中：
+// 截断与格式错误记为 ErrSnapshotCorrupt，底层读取的其他错误原样保留
+type snapshotReader struct {
+	r       byteReader
+	crc     hash.Hash32
+	buf     [1]byte
+	readErr error // 底层读取返回的最近一个错误
+	err     error
+}
+
+func (sr *snapshotReader) fail(err error) {
+	if err == nil || sr.err != nil {
+		return
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = ErrSnapshotCorrupt
+	}
+	sr.err = err
+}
+
+// ReadByte 实现 io.ByteReader，供 binary.ReadUvarint 使用
+func (sr *snapshotReader) ReadByte() (byte, error) {
+	b, err := sr.r.ReadByte()
+	if err != nil {
+		sr.readErr = err
+		return 0, err
+	}
+	sr.buf[0] = b
+	sr.crc.Write(sr.buf[:])
+	return b, nil
+}
+
+func (sr *snapshotReader) readByte() byte {
+	if sr.err != nil {
+		return 0
+	}
+	b, err := sr.ReadByte()
+	sr.fail(err)
+	return b
+}
+
+func (sr *snapshotReader) readUvarint() uint64 {
+	if sr.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(sr)
+	if err != nil && sr.readErr == nil {
+		// 溢出等编码错误
+		err = ErrSnapshotCorrupt
+	}
+	sr.fail(err)
+	return v
+}
+
+func (sr *snapshotReader) readVarint() int64 {
+	if sr.err != nil {
+		return 0
+	}
+	v, err := binary.ReadVarint(sr)
+	if err != nil && sr.readErr == nil {
+		err = ErrSnapshotCorrupt
+	}
+	sr.fail(err)
+	return v
+}
+
+func (sr *snapshotReader) readRaw(n int) []byte {
+	if sr.err != nil {
+		return nil
+	}
+	var p []byte
+	if n <= snapshotPrealloc {
+		p = make([]byte, n)
+		if _, err := io.ReadFull(sr.r, p); err != nil {
+			sr.fail(err)
+			return nil
+		}
+	} else {
+		var buf bytes.Buffer
+		if _, err := io.CopyN(&buf, sr.r, int64(n)); err != nil {
+			sr.fail(err)
+			return nil
+		}
+		p = buf.Bytes()
+	}
+	sr.crc.Write(p)
+	return p
+}
+
+func (sr *snapshotReader) readBytes() []byte {
+	n := sr.readUvarint()
+	if sr.err == nil && n > math.MaxInt {
+		sr.fail(ErrSnapshotCorrupt)
+	}
+	return sr.readRaw(int(n))
+}
+
+func (sr *snapshotReader) readString() string {
+	return string(sr.readBytes())
+}
+
+func (sr *snapshotReader) readStrings() []string {
+	var ss []string
+	for n := sr.readUvarint(); n > 0 && sr.err == nil; n-- {
+		ss = append(ss, sr.readString())
+	}
+	return ss
+}
+
+// readEntry 读取类型为 kind（recordValue 或 recordHash）的记录的其余部分，返回条目与 TTL 字段
+func (sr *snapshotReader) readEntry(kind byte) (snapshotEntry, uint64) {
+	var ttl uint64
+	e := snapshotEntry{key: sr.readString()}
+	if kind == recordHash {
+		e.fields = make(map[string][]byte)
+		ttl = sr.readUvarint()
+		for n := sr.readUvarint(); n > 0 && sr.err == nil; n-- {
+			field := sr.readString()
+			e.fields[field] = sr.readBytes()
+		}
+	} else {
+		e.value.enc = valueEncoding(sr.readByte())
+		ttl = sr.readUvarint()
+		e.value.b = sr.readBytes()
+	}
+	e.tags = sr.readStrings()
+	return e, ttl
+}
+
+// verify 读取结束记录之后的校验和，与此前读取内容的 CRC-32C 比较
+func (sr *snapshotReader) verify() error {
+	if sr.err != nil {
+		return sr.err
+	}
+	want := sr.crc.Sum32()
+	var sum [4]byte
+	if _, err := io.ReadFull(sr.r, sum[:]); err != nil {
+		sr.fail(err)
+		return sr.err
+	}
+	if binary.BigEndian.Uint32(sum[:]) != want {
+		return ErrSnapshotCorrupt
+	}
+	return nil
+}
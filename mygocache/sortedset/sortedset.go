@@ -0,0 +1,142 @@
+// Package sortedset 实现按分块存储的有序集合。
+// 元素按顺序存放在若干个不超过 maxBlock 的有序切片中，插入、删除与定位的开销为 O(log n + maxBlock)，
+// 从任意位置按序遍历 m 个元素的开销为 O(log n + m)。元素不含指针时，整个集合只有每个块一个指针，
+// 大量元素时 GC 扫描开销很小
+package sortedset
+
+import "sort"
+
+// maxBlock 每个块的元素数量上限，超过时分裂为两个块
+const maxBlock = 128
+
+// Set 是有序集合，元素的顺序由 less 决定，less 判断不出先后的两个元素视为同一个元素。
+// Set 不是并发安全的
+type Set[T any] struct {
+	less   func(a, b T) bool
+	blocks [][]T
+	n      int
+}
+
+// New 创建以 less 排序的空集合
+func New[T any](less func(a, b T) bool) *Set[T] {
+	return &Set[T]{less: less}
+}
+
+// Len 返回集合中的元素数量
+func (s *Set[T]) Len() int {
+	return s.n
+}
+
+// Insert 插入 x，x 已存在时返回 false
+func (s *Set[T]) Insert(x T) bool {
+	if len(s.blocks) == 0 {
+		s.blocks = append(s.blocks, []T{x})
+		s.n++
+		return true
+	}
+	i := s.search(x)
+	if i == len(s.blocks) {
+		// 大于所有元素，追加到最后一个块
+		i--
+	}
+	b := s.blocks[i]
+	j := sort.Search(len(b), func(j int) bool { return !s.less(b[j], x) })
+	if j < len(b) && !s.less(x, b[j]) {
+		return false
+	}
+	var zero T
+	b = append(b, zero)
+	copy(b[j+1:], b[j:])
+	b[j] = x
+	s.n++
+
+	if len(b) <= maxBlock {
+		s.blocks[i] = b
+		return true
+	}
+	// 分裂为两个块，后半部分复制到新的切片，避免两个块共用底层数组
+	half := len(b) / 2
+	right := make([]T, len(b)-half, maxBlock)
+	copy(right, b[half:])
+	for k := half; k < len(b); k++ {
+		b[k] = zero
+	}
+	s.blocks[i] = b[:half]
+	s.blocks = append(s.blocks, nil)
+	copy(s.blocks[i+2:], s.blocks[i+1:])
+	s.blocks[i+1] = right
+	return true
+}
+
+// Delete 删除 x，x 不存在时返回 false
+func (s *Set[T]) Delete(x T) bool {
+	i := s.search(x)
+	if i == len(s.blocks) {
+		return false
+	}
+	b := s.blocks[i]
+	j := sort.Search(len(b), func(j int) bool { return !s.less(b[j], x) })
+	if j == len(b) || s.less(x, b[j]) {
+		return false
+	}
+	var zero T
+	copy(b[j:], b[j+1:])
+	b[len(b)-1] = zero
+	b = b[:len(b)-1]
+	s.n--
+
+	if len(b) > 0 {
+		s.blocks[i] = b
+		return true
+	}
+	copy(s.blocks[i:], s.blocks[i+1:])
+	s.blocks[len(s.blocks)-1] = nil
+	s.blocks = s.blocks[:len(s.blocks)-1]
+	return true
+}
+
+// Clear 删除所有元素
+func (s *Set[T]) Clear() {
+	s.blocks = nil
+	s.n = 0
+}
+
+// Ascend 从最小的元素开始按序遍历，fn 返回 false 时停止遍历。遍历期间不得修改集合
+func (s *Set[T]) Ascend(fn func(x T) bool) {
+	s.ascendFrom(0, 0, fn)
+}
+
+// AscendAfter 从第一个大于 pivot 的元素开始按序遍历（pivot 不必在集合中），fn 返回 false 时停止遍历。
+// 遍历期间不得修改集合
+func (s *Set[T]) AscendAfter(pivot T, fn func(x T) bool) {
+	i := sort.Search(len(s.blocks), func(i int) bool {
+		b := s.blocks[i]
+		return s.less(pivot, b[len(b)-1])
+	})
+	if i == len(s.blocks) {
+		return
+	}
+	b := s.blocks[i]
+	j := sort.Search(len(b), func(j int) bool { return s.less(pivot, b[j]) })
+	s.ascendFrom(i, j, fn)
+}
+
+// ascendFrom 从第 i 个块的第 j 个元素开始按序遍历
+func (s *Set[T]) ascendFrom(i, j int, fn func(x T) bool) {
+	for ; i < len(s.blocks); i++ {
+		for _, x := range s.blocks[i][j:] {
+			if !fn(x) {
+				return
+			}
+		}
+		j = 0
+	}
+}
+
+// search 返回第一个最大元素不小于 x 的块，所有块的元素都小于 x 时返回块的数量
+func (s *Set[T]) search(x T) int {
+	return sort.Search(len(s.blocks), func(i int) bool {
+		b := s.blocks[i]
+		return !s.less(b[len(b)-1], x)
+	})
+}
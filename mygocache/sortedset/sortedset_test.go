@@ -0,0 +1,105 @@
+package sortedset
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func lessInt(a, b int) bool { return a < b }
+
+func collect(s *Set[int]) []int {
+	var got []int
+	s.Ascend(func(x int) bool {
+		got = append(got, x)
+		return true
+	})
+	return got
+}
+
+func TestInsertDelete(t *testing.T) {
+	s := New(lessInt)
+	want := make(map[int]bool)
+	r := rand.New(rand.NewSource(1))
+	// 足够多的元素使块多次分裂与删空
+	for i := 0; i < 20000; i++ {
+		x := r.Intn(5000)
+		if r.Intn(3) == 0 {
+			if s.Delete(x) != want[x] {
+				t.Fatalf("Delete(%d) disagrees with membership %v", x, want[x])
+			}
+			delete(want, x)
+		} else {
+			if s.Insert(x) == want[x] {
+				t.Fatalf("Insert(%d) disagrees with membership %v", x, want[x])
+			}
+			want[x] = true
+		}
+	}
+
+	expected := make([]int, 0, len(want))
+	for x := range want {
+		expected = append(expected, x)
+	}
+	sort.Ints(expected)
+	got := collect(s)
+	if s.Len() != len(expected) || len(got) != len(expected) {
+		t.Fatalf("expected %d elements, Len=%d, iterated %d", len(expected), s.Len(), len(got))
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("element %d = %d, want %d", i, got[i], expected[i])
+		}
+	}
+	for _, b := range s.blocks {
+		if len(b) == 0 || len(b) > maxBlock {
+			t.Fatalf("unexpected block size %d", len(b))
+		}
+	}
+
+	s.Clear()
+	if s.Len() != 0 || len(collect(s)) != 0 {
+		t.Fatalf("expected Clear to remove all elements")
+	}
+}
+
+func TestAscendAfter(t *testing.T) {
+	s := New(lessInt)
+	for i := 0; i < 1000; i += 2 {
+		s.Insert(i)
+	}
+	// pivot 可以在集合中，也可以不在
+	for _, pivot := range []int{-1, 0, 1, 255, 256, 997, 998, 2000} {
+		var got []int
+		s.AscendAfter(pivot, func(x int) bool {
+			got = append(got, x)
+			return len(got) < 3
+		})
+		var want []int
+		for x := pivot + 1; x < 1000 && len(want) < 3; x++ {
+			if x >= 0 && x%2 == 0 {
+				want = append(want, x)
+			}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("AscendAfter(%d) = %v, want %v", pivot, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("AscendAfter(%d) = %v, want %v", pivot, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	s := New(lessInt)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		x := r.Int()
+		s.Insert(x)
+		if s.Len() > 1<<20 {
+			s.Delete(x)
+		}
+	}
+}
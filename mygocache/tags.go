@@ -0,0 +1,128 @@
+package mygocache
+
+import (
+	"sync"
+
+	"mygocache/asynclog"
+)
+
+// TaggedGetter 是可选接口：Getter 同时实现它时，加载结果会附带返回的标签
+type TaggedGetter interface {
+	GetWithTags(key string) ([]byte, []string, error)
+}
+
+// tagIndex 维护标签与缓存 key（嵌入代数后实际写入缓存的 key）之间的双向索引，用于按标签批量失效
+type tagIndex struct {
+	mu   sync.Mutex
+	keys map[string]map[string]struct{} // tag -> keys
+	tags map[string][]string            // key -> tags
+}
+
+func newTagIndex() *tagIndex {
+	return &tagIndex{
+		keys: make(map[string]map[string]struct{}),
+		tags: make(map[string][]string),
+	}
+}
+
+// set 用 tags 替换 key 当前的标签，tags 为空时移除 key 的所有标签
+func (t *tagIndex) set(key string, tags []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.removeLocked(key)
+	if len(tags) == 0 {
+		return
+	}
+	t.tags[key] = append([]string(nil), tags...)
+	for _, tag := range tags {
+		keys, ok := t.keys[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			t.keys[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// remove 移除 key 的所有标签，在条目被淘汰、过期或删除时调用
+func (t *tagIndex) remove(key string) {
+	t.mu.Lock()
+	t.removeLocked(key)
+	t.mu.Unlock()
+}
+
+// removeLocked 移除 key 的所有标签。调用方必须已持有 t.mu
+func (t *tagIndex) removeLocked(key string) {
+	for _, tag := range t.tags[key] {
+		if keys, ok := t.keys[tag]; ok {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(t.keys, tag)
+			}
+		}
+	}
+	delete(t.tags, key)
+}
+
+// keysOf 返回携带 tag 的所有 key
+func (t *tagIndex) keysOf(tag string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := make([]string, 0, len(t.keys[tag]))
+	for key := range t.keys[tag] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// tagsOf 返回 key 当前携带的标签
+func (t *tagIndex) tagsOf(key string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.tags[key]...)
+}
+
+// tagKey 为已写入缓存的 key 记录标签。
+// LRU-K 下经 add 写入的值可能尚未进入缓存，此时不记录，避免索引中残留无效 key
+func (g *Group) tagKey(key string, tags []string) {
+	if len(tags) > 0 && !g.mainCache.contains(key) {
+		return
+	}
+	g.tags.set(key, tags)
+}
+
+// InvalidateTag 删除本节点上所有携带 tag 的条目，返回删除的 key 数量。
+// 标签的索引在条目被删除时经 OnEvicted 回调清理
+func (g *Group) InvalidateTag(tag string) int {
+	// 先复制 key 列表再逐个删除：删除会触发 OnEvicted 回调并获取索引锁
+	keys := g.tags.keysOf(tag)
+	for _, key := range keys {
+		g.removeCacheKey(key)
+	}
+	return len(keys)
+}
+
+// InvalidateTagCluster 删除本节点及所有远程节点上携带 tag 的条目，返回删除的 key 总数。
+// 携带同一标签的 key 可能分布在任意节点上，因此需要向全部节点广播
+func (g *Group) InvalidateTagCluster(tag string) (int, error) {
+	removed := g.InvalidateTag(tag)
+
+	lister, ok := g.peers.(PeerLister)
+	if !ok {
+		return removed, nil
+	}
+	n, err := g.broadcast(lister.ListPeers(), func(peer PeerGetter) (int64, error) {
+		invalidator, ok := peer.(PeerTagInvalidator)
+		if !ok {
+			return 0, nil
+		}
+		n, err := invalidator.InvalidateTag(g.name, tag)
+		if err != nil {
+			asynclog.Printf("[GeeCache] invalidate tag %s on peer failed: %v", tag, err)
+		}
+		return n, err
+	})
+	return removed + int(n), err
+}
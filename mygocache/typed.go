@@ -0,0 +1,183 @@
+package mygocache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// Codec 在 T 与缓存中的字节之间转换。
+// Encode 不应返回空切片：空值在缓存中表示负缓存，读取时会返回 ErrKeyNotFound
+type Codec[T any] interface {
+	Encode(value T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec 使用 encoding/json 编解码
+type JSONCodec[T any] struct{}
+
+// Encode 实现 Codec 接口
+func (JSONCodec[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Decode 实现 Codec 接口
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// GobCodec 使用 encoding/gob 编解码，每个值独立编码，包含类型信息
+type GobCodec[T any] struct{}
+
+// Encode 实现 Codec 接口
+func (GobCodec[T]) Encode(value T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode 实现 Codec 接口
+func (GobCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return value, err
+}
+
+// BytesCodec 原样存取字节，不做任何转换。空切片会被当作负缓存，读取时返回 ErrKeyNotFound
+type BytesCodec struct{}
+
+// Encode 实现 Codec 接口
+func (BytesCodec) Encode(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+// Decode 实现 Codec 接口，返回的切片为副本
+func (BytesCodec) Decode(data []byte) ([]byte, error) {
+	return cloneBytes(data), nil
+}
+
+// DecodeError 表示缓存中 key 的值无法解码为目标类型
+type DecodeError struct {
+	Key string
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode value of key %s: %v", e.Key, e.Err)
+}
+
+// Unwrap 返回解码器返回的原始错误
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// TypedGetterFunc 加载 key 对应的类型化数据
+type TypedGetterFunc[T any] func(key string) (T, error)
+
+// TypedGroup 是 Group 的类型化封装，通过 Codec 在 T 与缓存中的字节之间转换
+type TypedGroup[T any] struct {
+	group *Group
+	codec Codec[T]
+}
+
+// NewTypedGroup 创建底层 Group 并返回其类型化封装，getter 返回的值经 codec 编码后写入缓存。
+// 编码失败按加载失败处理，与 Getter 返回错误一样会写入负缓存
+func NewTypedGroup[T any](name string, getter TypedGetterFunc[T], codec Codec[T], opts ...Option) (*TypedGroup[T], error) {
+	if getter == nil {
+		return nil, fmt.Errorf("nil Getter")
+	}
+	if codec == nil {
+		return nil, fmt.Errorf("nil Codec")
+	}
+	g, err := NewGroup(name, GetterFunc(func(key string) ([]byte, error) {
+		value, err := getter(key)
+		if err != nil {
+			return nil, err
+		}
+		return codec.Encode(value)
+	}), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedGroup[T]{group: g, codec: codec}, nil
+}
+
+// WrapGroup 返回已有 Group 的类型化封装，Getter 加载的字节需能被 codec 解码
+func WrapGroup[T any](g *Group, codec Codec[T]) *TypedGroup[T] {
+	return &TypedGroup[T]{group: g, codec: codec}
+}
+
+// Group 返回底层的 Group，用于执行未类型化的操作
+func (tg *TypedGroup[T]) Group() *Group {
+	return tg.group
+}
+
+// Get 获取 key 对应的值并解码。缓存中的值无法解码时（如数据格式已变更）删除本节点上的该条目
+// 并重新加载一次，仍无法解码时返回 *DecodeError，避免无法解码的值长期占据缓存
+func (tg *TypedGroup[T]) Get(key string) (T, error) {
+	value, err := tg.get(key)
+	if _, ok := err.(*DecodeError); ok {
+		tg.group.Delete(key)
+		value, err = tg.get(key)
+		if _, ok := err.(*DecodeError); ok {
+			tg.group.Delete(key)
+		}
+	}
+	return value, err
+}
+
+func (tg *TypedGroup[T]) get(key string) (T, error) {
+	var zero T
+	view, err := tg.group.Get(key)
+	if err != nil {
+		return zero, err
+	}
+	value, err := tg.codec.Decode(view.b)
+	if err != nil {
+		return zero, &DecodeError{Key: key, Err: err}
+	}
+	return value, nil
+}
+
+// Set 编码 value 并写入 key，tags 语义同 Group.Set
+func (tg *TypedGroup[T]) Set(key string, value T, ttl int64, tags ...string) error {
+	data, err := tg.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+	return tg.group.Set(key, data, ttl, tags...)
+}
+
+// GetMulti 批量获取本节点已缓存的值并解码，未命中的 key 不出现在结果中。
+// 无法解码的条目会被删除且不出现在结果中，此时返回第一个 *DecodeError 以及其余解码成功的值
+func (tg *TypedGroup[T]) GetMulti(keys []string) (map[string]T, error) {
+	raw, err := tg.group.GetMulti(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]T, len(raw))
+	var firstErr error
+	for key, data := range raw {
+		// 负缓存命中表示 key 不存在
+		if len(data) == 0 {
+			continue
+		}
+		value, err := tg.codec.Decode(data)
+		if err != nil {
+			tg.group.Delete(key)
+			if firstErr == nil {
+				firstErr = &DecodeError{Key: key, Err: err}
+			}
+			continue
+		}
+		result[key] = value
+	}
+	return result, firstErr
+}
@@ -0,0 +1,141 @@
+package mygocache
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"mygocache/asynclog"
+)
+
+// valueEncoding 记录缓存中的字节相对于原值经过的变换
+type valueEncoding uint8
+
+const (
+	// encCompressed 值经 Group 的 Compressor 压缩
+	encCompressed valueEncoding = 1 << iota
+	// encEncrypted 值（压缩之后）经 AES-GCM 加密
+	encEncrypted
+	// encManifest 值为分块存储的清单，原值需按清单组装，不压缩
+	encManifest
+)
+
+// encodeValue 将写入 key 的原值转换为存储形式：不小于压缩阈值的值先压缩，配置了加密时再加密。
+// 压缩失败或压缩后不小于原值时不压缩；加密失败时不写入明文，返回空值（即负缓存）。
+// 负缓存与已完成相应变换的值不再重复处理
+func (g *Group) encodeValue(key string, v ByteView) ByteView {
+	if v.Len() == 0 {
+		return v
+	}
+	if v.enc == 0 && g.compressor != nil && v.Len() >= g.compressThreshold {
+		data, err := g.compressor.Compress(v.b)
+		if err != nil {
+			asynclog.Printf("[GeeCache] %s compress failed, storing uncompressed: %v", g.compressor.Name(), err)
+		} else if len(data) < v.Len() {
+			v = ByteView{b: data, enc: encCompressed}
+		}
+	}
+	if v.enc&encEncrypted == 0 && g.envelope != nil {
+		data, err := g.envelope.seal(v.b, g.valueAAD(key))
+		if err != nil {
+			asynclog.Printf("[GeeCache] encrypt value of key=%s failed, not caching: %v", key, err)
+			return ByteView{}
+		}
+		v = ByteView{b: data, enc: v.enc | encEncrypted}
+	}
+	return v
+}
+
+// decodeValue 将 key 在缓存中的存储形式还原为原值，原值直接返回。
+// 还原失败（数据损坏、认证失败或密钥已移除）时计入 IntegrityFailures
+func (g *Group) decodeValue(key string, v ByteView) (ByteView, error) {
+	v, err := g.decryptValue(key, v)
+	if err != nil {
+		return ByteView{}, err
+	}
+	if v.enc&encCompressed != 0 {
+		if g.compressor == nil {
+			atomic.AddInt64(&g.integrityFailures, 1)
+			return ByteView{}, fmt.Errorf("compressed value without compressor")
+		}
+		data, err := g.compressor.Decompress(v.b)
+		if err != nil {
+			atomic.AddInt64(&g.integrityFailures, 1)
+			return ByteView{}, fmt.Errorf("%s decompress: %v", g.compressor.Name(), err)
+		}
+		v = ByteView{b: data}
+	}
+	return v, nil
+}
+
+// decryptValue 去掉存储形式中的加密，保留压缩
+func (g *Group) decryptValue(key string, v ByteView) (ByteView, error) {
+	if v.enc&encEncrypted == 0 {
+		return v, nil
+	}
+	if g.envelope == nil {
+		atomic.AddInt64(&g.integrityFailures, 1)
+		return ByteView{}, fmt.Errorf("encrypted value without key provider")
+	}
+	data, err := g.envelope.open(v.b, g.valueAAD(key))
+	if err != nil {
+		atomic.AddInt64(&g.integrityFailures, 1)
+		return ByteView{}, fmt.Errorf("decrypt: %v", err)
+	}
+	return ByteView{b: data, enc: v.enc &^ encEncrypted}, nil
+}
+
+// valueAAD 返回加密 key 的值时使用的附加认证数据，防止密文被挪用到其他 Group 或 key
+func (g *Group) valueAAD(key string) []byte {
+	return []byte(g.name + "\x00" + key)
+}
+
+// decodeFull 还原 key 的存储形式，分块存储的值按清单组装为原值
+func (g *Group) decodeFull(key string, v ByteView) (ByteView, error) {
+	v, err := g.decodeValue(key, v)
+	if err == nil && v.enc&encManifest != 0 {
+		v, err = g.assembleChunks(key, v)
+	}
+	return v, err
+}
+
+// getResolved 获取 key 并以 decode 还原存储形式，分块存储的值还原后按清单组装。
+// 缓存中的值无法还原、分块缺失或校验失败时删除该值并按未命中重新加载一次
+func (g *Group) getResolved(key string, ttl int64, decode func(key string, v ByteView) (ByteView, error)) (ByteView, error) {
+	ck := g.cacheKey(key)
+	for attempt := 0; ; attempt++ {
+		stored, err := g.getStored(key, ck, ttl)
+		if err != nil {
+			return ByteView{}, err
+		}
+		view, err := decode(key, stored)
+		if err == nil && view.enc&encManifest != 0 {
+			view, err = g.assembleChunks(key, view)
+		}
+		if err == nil || attempt > 0 {
+			return view, err
+		}
+		g.dropUndecodable(key, ck, err)
+	}
+}
+
+// getForTransfer 返回发送给远程节点的 key 的值：加密只在本节点内有效，总是先解密；
+// 远程节点使用同名压缩算法时保持压缩形式，由远程节点在 Get 时解压，否则返回原值
+func (g *Group) getForTransfer(key string, compression string) (ByteView, error) {
+	if key == "" {
+		return ByteView{}, fmt.Errorf("key is required")
+	}
+	view, err := g.getResolved(key, g.defaultTTL, g.decryptValue)
+	if err != nil {
+		return ByteView{}, err
+	}
+	if view.enc == encCompressed && compression != "" && compression == g.compressor.Name() {
+		return view, nil
+	}
+	return g.decodeValue(key, view)
+}
+
+// dropUndecodable 删除无法还原或组装的缓存值，之后的读取按未命中处理
+func (g *Group) dropUndecodable(key, ck string, err error) {
+	asynclog.Printf("[GeeCache] drop undecodable value of key=%s: %v", key, err)
+	g.removeCacheKey(ck)
+}
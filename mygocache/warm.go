@@ -0,0 +1,382 @@
+package mygocache
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mygocache/asynclog"
+)
+
+// DefaultWarmConcurrency 预热时经 Getter 并发加载的默认数量上限
+const DefaultWarmConcurrency = 16
+
+const (
+	// warmDumpCount 从远程节点拉取时每页的条目数
+	warmDumpCount = 256
+	// warmProgressEvery 从 KeySource 加载时每提交多少个 key 报告一次进度
+	warmProgressEvery = 100
+)
+
+// KeySource 提供预热时需要经 Getter 加载的 key
+type KeySource interface {
+	// Keys 依次对每个 key 调用 fn，fn 返回 false 时停止遍历
+	Keys(fn func(key string) bool) error
+}
+
+// KeySourceFunc 使用函数实现 KeySource
+type KeySourceFunc func(fn func(key string) bool) error
+
+// Keys 实现 KeySource 接口
+func (f KeySourceFunc) Keys(fn func(key string) bool) error {
+	return f(fn)
+}
+
+// KeyList 是内存中的 key 列表
+type KeyList []string
+
+// Keys 实现 KeySource 接口
+func (l KeyList) Keys(fn func(key string) bool) error {
+	for _, key := range l {
+		if !fn(key) {
+			break
+		}
+	}
+	return nil
+}
+
+// FileKeySource 返回从文件逐行读取 key 的 KeySource。行首尾的空白被去掉，空行与以 # 开头的行被忽略
+func FileKeySource(path string) KeySource {
+	return KeySourceFunc(func(fn func(key string) bool) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key := strings.TrimSpace(scanner.Text())
+			if key == "" || strings.HasPrefix(key, "#") {
+				continue
+			}
+			if !fn(key) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	})
+}
+
+// Warmup 描述一次缓存预热
+type Warmup struct {
+	// FromPeers 从其他节点拉取它们已缓存的、按一致性哈希属于本节点的值，需要先 RegisterPeers
+	FromPeers bool
+	// Source 经 Getter 加载的 key，nil 表示不加载；属于其他节点或已在缓存中的 key 被跳过
+	Source KeySource
+	// Concurrency 经 Getter 并发加载的数量上限，0 表示 DefaultWarmConcurrency
+	Concurrency int
+	// OnProgress 在预热过程中与结束时（Done 为 true）报告进度，在执行预热的协程中调用
+	OnProgress func(WarmProgress)
+}
+
+// WarmProgress 表示预热的进度
+type WarmProgress struct {
+	// Loaded 写入缓存的 key 数量
+	Loaded int
+	// Skipped 已在缓存中、属于其他节点或无法在本节点缓存的 key 数量
+	Skipped int
+	// Failed Getter 返回错误的 key 数量
+	Failed int
+	// Done 预热是否已结束
+	Done bool
+}
+
+// warmCounter 是预热协程共享的进度计数
+type warmCounter struct {
+	loaded, skipped, failed int64
+}
+
+func (c *warmCounter) progress(done bool) WarmProgress {
+	return WarmProgress{
+		Loaded:  int(atomic.LoadInt64(&c.loaded)),
+		Skipped: int(atomic.LoadInt64(&c.skipped)),
+		Failed:  int(atomic.LoadInt64(&c.failed)),
+		Done:    done,
+	}
+}
+
+// DumpEntry 是 Dump 返回的一个缓存条目
+type DumpEntry struct {
+	Key   string
+	Value []byte
+	// TTL 剩余生存时间（秒），0 表示永不过期
+	TTL int64
+}
+
+// Dump 与 Scan 一样增量遍历本节点缓存中的键，返回其原值与剩余 TTL，供其他节点预热。
+// owner 非空且注册的 PeerPicker 实现了 PeerLocator 时，只返回按一致性哈希属于 owner 的 key。
+// 哈希值、负缓存、分块存储的大值与无法还原的值不返回，因此一页的条目可能少于 count；返回的游标为空时遍历结束
+func (g *Group) Dump(cursor string, count int, owner string) ([]DumpEntry, string, error) {
+	keys, next, err := g.Scan(cursor, "", count)
+	if err != nil {
+		return nil, "", err
+	}
+	locator, _ := g.peers.(PeerLocator)
+	now := time.Now().Unix()
+	entries := make([]DumpEntry, 0, len(keys))
+	for _, key := range keys {
+		if owner != "" && locator != nil && locator.Owner(key) != owner {
+			continue
+		}
+		e, ok := g.mainCache.peek(g.cacheKey(key))
+		if !ok || e.fields != nil || e.value.Len() == 0 || e.value.enc&encManifest != 0 {
+			continue
+		}
+		var ttl int64
+		if e.expiresAt > 0 {
+			if ttl = e.expiresAt - now; ttl <= 0 {
+				continue
+			}
+		}
+		view, err := g.decodeValue(key, e.value)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, DumpEntry{Key: key, Value: view.ByteSlice(), TTL: ttl})
+	}
+	return entries, next, nil
+}
+
+// Warm 预热缓存：先从其他节点拉取属于本节点的值，再经 Getter 加载 Source 中尚未缓存的 key。
+// 预热期间 Ready 返回的 channel 不会关闭；写入的值跳过 LRU-K 的 K 次访问门槛，从其他节点拉取的值不携带标签。
+// 预热进行中 key 被 Set/Delete 时以新的写入为准。ctx 被取消时停止并返回 ctx.Err()；
+// 远程节点出错时跳过该节点继续预热，最后返回遇到的第一个错误
+func (g *Group) Warm(ctx context.Context, w Warmup) (WarmProgress, error) {
+	g.beginWarm()
+	defer g.endWarm()
+	return g.warm(ctx, w)
+}
+
+// StartWarm 与 Warm 相同，但在后台协程中预热并立即返回。返回前 Group 已标记为预热中，
+// 因此调用之后 IsReady 一直返回 false 直到预热结束，不会在后台协程开始运行前短暂报告就绪。
+// wait 阻塞到预热结束并返回与 Warm 相同的结果，可以多次调用
+func (g *Group) StartWarm(ctx context.Context, w Warmup) (wait func() (WarmProgress, error)) {
+	g.beginWarm()
+	done := make(chan struct{})
+	var (
+		progress WarmProgress
+		err      error
+	)
+	go func() {
+		defer close(done)
+		defer g.endWarm()
+		progress, err = g.warm(ctx, w)
+	}()
+	return func() (WarmProgress, error) {
+		<-done
+		return progress, err
+	}
+}
+
+// warm 执行预热，调用方负责 beginWarm 与 endWarm
+func (g *Group) warm(ctx context.Context, w Warmup) (WarmProgress, error) {
+	start := time.Now()
+	var c warmCounter
+	report := func() {
+		if w.OnProgress != nil {
+			w.OnProgress(c.progress(false))
+		}
+	}
+	var firstErr error
+	if w.FromPeers {
+		firstErr = g.warmFromPeers(ctx, &c, report)
+	}
+	if w.Source != nil && ctx.Err() == nil {
+		if err := g.warmFromSource(ctx, w.Source, w.Concurrency, &c, report); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		firstErr = err
+	}
+
+	progress := c.progress(true)
+	if w.OnProgress != nil {
+		w.OnProgress(progress)
+	}
+	asynclog.Printf("[GeeCache] group %s warmed in %v: loaded=%d skipped=%d failed=%d",
+		g.name, time.Since(start), progress.Loaded, progress.Skipped, progress.Failed)
+	return progress, firstErr
+}
+
+// warmFromPeers 依次从每个远程节点分页拉取属于本节点的值
+func (g *Group) warmFromPeers(ctx context.Context, c *warmCounter, report func()) error {
+	lister, ok := g.peers.(PeerLister)
+	if !ok {
+		return nil
+	}
+	var owner string
+	if locator, ok := g.peers.(PeerLocator); ok {
+		owner = locator.Self()
+	}
+
+	var firstErr error
+	for _, peer := range lister.ListPeers() {
+		dumper, ok := peer.(PeerDumper)
+		if !ok {
+			continue
+		}
+		for cursor := ""; ; {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			entries, next, err := dumper.Dump(g.name, cursor, warmDumpCount, owner)
+			if err != nil {
+				asynclog.Printf("[GeeCache] dump from peer failed, skipping it: %v", err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				break
+			}
+			for _, e := range entries {
+				g.warmEntry(e, c)
+			}
+			report()
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+	}
+	return firstErr
+}
+
+// warmEntry 写入从远程节点拉取的条目，在填充租约内完成，避免覆盖预热期间的写入
+func (g *Group) warmEntry(e DumpEntry, c *warmCounter) {
+	// 远程节点不知道本节点的一致性哈希时会返回所有条目，只保留属于本节点的
+	if g.ownedByPeer(e.Key) || g.shouldChunk(e.Value) {
+		atomic.AddInt64(&c.skipped, 1)
+		return
+	}
+	ck := g.cacheKey(e.Key)
+	token, leased := g.leases.grant(ck, false)
+	if !leased {
+		atomic.AddInt64(&c.skipped, 1)
+		return
+	}
+	stored := g.encodeValue(e.Key, ByteView{b: e.Value})
+	filled := g.leases.redeem(ck, token, func(held func() bool) bool {
+		return g.mainCache.addIf(ck, stored, e.TTL, true, func(exists bool) bool { return !exists && held() })
+	})
+	if filled {
+		atomic.AddInt64(&c.loaded, 1)
+	} else {
+		atomic.AddInt64(&c.skipped, 1)
+	}
+}
+
+// warmFromSource 通过协程池经 Getter 并发加载 src 中的 key，同时进行的加载不超过 concurrency 个
+func (g *Group) warmFromSource(ctx context.Context, src KeySource, concurrency int, c *warmCounter, report func()) error {
+	if concurrency <= 0 {
+		concurrency = DefaultWarmConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	submitted := 0
+	err := src.Keys(func(key string) bool {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		wg.Add(1)
+		task := func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			g.warmKey(key, c)
+		}
+		// 提交失败时同步执行
+		if err := g.goroutinePool.Submit(task); err != nil {
+			task()
+		}
+		if submitted++; submitted%warmProgressEvery == 0 {
+			report()
+		}
+		return true
+	})
+	wg.Wait()
+	return err
+}
+
+// warmKey 经 Getter 加载属于本节点且尚未缓存的 key
+func (g *Group) warmKey(key string, c *warmCounter) {
+	ck := g.cacheKey(key)
+	if key == "" || g.ownedByPeer(key) || g.mainCache.contains(ck) {
+		atomic.AddInt64(&c.skipped, 1)
+		return
+	}
+	_, err, shared := g.loader.Do(ck, func() (interface{}, error) {
+		return g.getLocallyWithTTL(key, ck, g.defaultTTL, true)
+	})
+	switch {
+	case shared:
+		// 与同一 key 的其他加载合并，由发起加载的一方计数
+		atomic.AddInt64(&c.skipped, 1)
+		return
+	case err != nil:
+		asynclog.Printf("[GeeCache] warm key=%s failed: %v", key, err)
+		atomic.AddInt64(&c.failed, 1)
+		return
+	}
+	atomic.AddInt64(&c.loaded, 1)
+}
+
+// ownedByPeer 判断 key 是否按一致性哈希属于其他节点
+func (g *Group) ownedByPeer(key string) bool {
+	if g.peers == nil {
+		return false
+	}
+	_, ok := g.peers.PickPeer(key)
+	return ok
+}
+
+// Ready 返回一个在所有进行中的预热结束后关闭的 channel，没有进行中的预热时已关闭。
+// 可用于在预热完成前推迟对外提供服务，或作为就绪探针
+func (g *Group) Ready() <-chan struct{} {
+	g.warmMu.Lock()
+	defer g.warmMu.Unlock()
+	return g.ready
+}
+
+// IsReady 返回是否没有进行中的预热
+func (g *Group) IsReady() bool {
+	select {
+	case <-g.Ready():
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *Group) beginWarm() {
+	g.warmMu.Lock()
+	defer g.warmMu.Unlock()
+	if g.warming == 0 {
+		g.ready = make(chan struct{})
+	}
+	g.warming++
+}
+
+func (g *Group) endWarm() {
+	g.warmMu.Lock()
+	defer g.warmMu.Unlock()
+	if g.warming--; g.warming == 0 {
+		close(g.ready)
+	}
+}
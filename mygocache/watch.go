@@ -0,0 +1,318 @@
+package mygocache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"mygocache/asynclog"
+	"mygocache/lru"
+)
+
+// ChangeType 表示 key 的变更类型
+type ChangeType int
+
+const (
+	// ChangeSet key 被显式写入（Set、SetMulti、Add、Replace、CompareAndSet、Incr、LeaseSet）
+	ChangeSet ChangeType = iota
+	// ChangeDelete key 被显式删除
+	ChangeDelete
+	// ChangeExpire key 的 TTL 到期
+	ChangeExpire
+	// ChangeEvict key 因容量不足被淘汰
+	ChangeEvict
+)
+
+// String 返回变更类型的可读名称
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeSet:
+		return "set"
+	case ChangeDelete:
+		return "delete"
+	case ChangeExpire:
+		return "expire"
+	case ChangeEvict:
+		return "evict"
+	}
+	return "unknown"
+}
+
+// ChangeEvent 表示一次 key 变更
+type ChangeEvent struct {
+	// Seq 事件序号，在本节点的 Group 内单调递增
+	Seq uint64
+	Key string
+	// Type 变更类型
+	Type ChangeType
+	// Value ChangeSet 时为写入的新值，其余类型为被移除的旧值
+	Value ByteView
+	// Dropped 该订阅在本事件之前因缓冲区已满而丢弃的事件数
+	Dropped uint64
+}
+
+// DefaultWatchBuffer Watch 返回的 channel 的缓冲区大小，消费不及时时新事件会被丢弃
+const DefaultWatchBuffer = 64
+
+// changeLogSize 为长轮询保留的最近事件数量
+const changeLogSize = 1024
+
+// watcher 表示一个 Watch 订阅
+type watcher struct {
+	pattern string
+	ch      chan ChangeEvent
+	dropped uint64 // 受 watchHub.mu 保护
+}
+
+// watchHub 向订阅者分发变更事件，并保留最近的事件供长轮询使用
+type watchHub struct {
+	// 有订阅或长轮询后置为 1，此前 publish 直接返回，避免未使用 Watch 时争用 mu
+	active   int32
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	seq      uint64
+	log      []ChangeEvent // 环形缓冲区，按 Seq 取模存放
+	notify   chan struct{} // 有新事件时关闭并替换，唤醒长轮询
+	done     chan struct{} // Group 关闭时关闭
+	closed   bool
+	// 启用加密时，保留在 log 中的值以 envelope 加密，读取时再解密，避免明文长期留在内存中
+	envelope *envelope
+	aad      func(key string) []byte
+}
+
+func newWatchHub() *watchHub {
+	return &watchHub{
+		// 第一个事件的序号为 2，返回的最新序号从不为 0，调用方以 0 表示"从当前位置开始"
+		seq:      1,
+		watchers: make(map[*watcher]struct{}),
+		log:      make([]ChangeEvent, changeLogSize),
+		notify:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// matchWatch 判断 key 是否匹配订阅：以 * 结尾时按前缀匹配，否则精确匹配
+func matchWatch(pattern, key string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(key, pattern[:len(pattern)-1])
+	}
+	return key == pattern
+}
+
+// publish 记录事件并以非阻塞方式发送给匹配的订阅者
+func (h *watchHub) publish(key string, typ ChangeType, value ByteView) {
+	if atomic.LoadInt32(&h.active) == 0 {
+		return
+	}
+	logged := h.seal(key, value)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.seq++
+	event := ChangeEvent{Seq: h.seq, Key: key, Type: typ, Value: value}
+	h.log[h.seq%changeLogSize] = event
+	h.log[h.seq%changeLogSize].Value = logged
+
+	for w := range h.watchers {
+		if !matchWatch(w.pattern, key) {
+			continue
+		}
+		e := event
+		e.Dropped = w.dropped
+		select {
+		case w.ch <- e:
+			w.dropped = 0
+		default:
+			w.dropped++
+		}
+	}
+
+	close(h.notify)
+	h.notify = make(chan struct{})
+}
+
+// seal 返回保留在 log 中的值：启用加密时为密文，加密失败时不保留值
+func (h *watchHub) seal(key string, value ByteView) ByteView {
+	if h.envelope == nil || value.Len() == 0 {
+		return value
+	}
+	data, err := h.envelope.seal(value.b, h.aad(key))
+	if err != nil {
+		asynclog.Printf("[GeeCache] encrypt change of key=%s failed, dropping its value: %v", key, err)
+		return ByteView{}
+	}
+	return ByteView{b: data, enc: encEncrypted}
+}
+
+// open 还原 log 中保留的值，密钥已移除等原因无法解密时返回空值
+func (h *watchHub) open(key string, value ByteView) ByteView {
+	if value.enc&encEncrypted == 0 {
+		return value
+	}
+	data, err := h.envelope.open(value.b, h.aad(key))
+	if err != nil {
+		asynclog.Printf("[GeeCache] decrypt change of key=%s failed, dropping its value: %v", key, err)
+		return ByteView{}
+	}
+	return ByteView{b: data}
+}
+
+// subscribe 注册订阅，ctx 结束或 hub 关闭时注销并关闭 channel
+func (h *watchHub) subscribe(ctx context.Context, pattern string, buffer int) <-chan ChangeEvent {
+	w := &watcher{pattern: pattern, ch: make(chan ChangeEvent, buffer)}
+	atomic.StoreInt32(&h.active, 1)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(w.ch)
+		return w.ch
+	}
+	h.watchers[w] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-h.done:
+		}
+		h.mu.Lock()
+		delete(h.watchers, w)
+		h.mu.Unlock()
+		// 已从订阅表移除，publish 不会再向 w.ch 发送
+		close(w.ch)
+	}()
+	return w.ch
+}
+
+// since 返回序号大于 seq 且匹配 pattern 的事件、当前最新序号，
+// 以及 seq 之后的事件是否已有部分被环形缓冲区覆盖
+func (h *watchHub) since(pattern string, seq uint64) (events []ChangeEvent, latest uint64, truncated bool) {
+	events, latest, truncated = h.logSince(pattern, seq)
+	for i := range events {
+		events[i].Value = h.open(events[i].Key, events[i].Value)
+	}
+	return events, latest, truncated
+}
+
+// logSince 返回 log 中序号大于 seq 且匹配 pattern 的事件（值为保留的形式）
+func (h *watchHub) logSince(pattern string, seq uint64) (events []ChangeEvent, latest uint64, truncated bool) {
+	atomic.StoreInt32(&h.active, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if seq > h.seq {
+		// 序号来自重启前的节点，无法判断丢失了哪些事件
+		return nil, h.seq, true
+	}
+	oldest := uint64(2)
+	if h.seq > changeLogSize+1 {
+		oldest = h.seq - changeLogSize + 1
+	}
+	if seq+1 < oldest {
+		truncated = true
+		seq = oldest - 1
+	}
+	for s := seq + 1; s <= h.seq; s++ {
+		if e := h.log[s%changeLogSize]; matchWatch(pattern, e.Key) {
+			events = append(events, e)
+		}
+	}
+	return events, h.seq, truncated
+}
+
+// wait 返回在下一个事件发布时关闭的 channel
+func (h *watchHub) wait() <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.notify
+}
+
+// close 关闭所有订阅，并唤醒正在等待的长轮询
+func (h *watchHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	close(h.done)
+	close(h.notify)
+}
+
+// isClosed 判断 hub 是否已关闭
+func (h *watchHub) isClosed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closed
+}
+
+// Watch 订阅本节点上 keyOrPrefix 的变更：以 * 结尾时按前缀匹配（如 "user:*"），否则精确匹配。
+// 返回的 channel 缓冲 DefaultWatchBuffer 个事件，消费不及时时新事件被丢弃，
+// 丢弃数量记录在下一个送达事件的 Dropped 字段中。ctx 结束或 Group 关闭时 channel 被关闭。
+// 从 Getter 或远程节点加载填充缓存不产生事件；被 BumpGeneration 作废的旧代数条目也不产生事件。
+// 写入事件在写入完成后发布，删除、过期与淘汰事件经协程池异步发布，
+// 同一 key 上的写入与删除的事件顺序可能与生效顺序不一致，需要精确状态时应在收到事件后重新读取
+func (g *Group) Watch(ctx context.Context, keyOrPrefix string) <-chan ChangeEvent {
+	return g.watches.subscribe(ctx, keyOrPrefix, DefaultWatchBuffer)
+}
+
+// WaitChanges 以长轮询方式获取本节点上 keyOrPrefix 的变更（匹配规则同 Watch），供远程客户端使用。
+// 返回序号大于 since 的事件；暂无事件时等待新事件、ctx 结束或 Group 关闭。
+// since 为 0 时从当前位置开始等待。返回的 next 作为下一次调用的 since；
+// truncated 为 true 表示 since 之后的部分事件已被覆盖而丢失
+func (g *Group) WaitChanges(ctx context.Context, keyOrPrefix string, since uint64) (events []ChangeEvent, next uint64, truncated bool) {
+	if since == 0 {
+		_, since, _ = g.watches.since(keyOrPrefix, 0)
+	}
+	for {
+		// 先取得唤醒 channel 再读取事件，避免错过两者之间发布的事件
+		wake := g.watches.wait()
+		events, next, truncated = g.watches.since(keyOrPrefix, since)
+		if len(events) > 0 || truncated {
+			return events, next, truncated
+		}
+		since = next
+		select {
+		case <-wake:
+			if g.watches.isClosed() {
+				return nil, next, false
+			}
+		case <-ctx.Done():
+			return nil, next, false
+		}
+	}
+}
+
+// notifySet 为显式写入发布 ChangeSet 事件
+func (g *Group) notifySet(key string, value ByteView) {
+	g.watches.publish(key, ChangeSet, value)
+}
+
+// notifyRemoved 将分片上报的移除转换为变更事件，覆盖写入由写入方发布 ChangeSet。
+// 在分片锁内调用，与 dispatchEviction 一样经协程池在锁外还原、加密并发布；value 为存储形式，只在有订阅者时还原
+func (g *Group) notifyRemoved(userKey string, value ByteView, reason lru.RemoveReason) {
+	var typ ChangeType
+	switch reason {
+	case lru.RemoveDeleted:
+		typ = ChangeDelete
+	case lru.RemoveExpired:
+		typ = ChangeExpire
+	case lru.RemoveEvicted:
+		typ = ChangeEvict
+	default:
+		return
+	}
+	if atomic.LoadInt32(&g.watches.active) == 0 {
+		return
+	}
+	task := func() { g.watches.publish(userKey, typ, g.decodeRemoved(userKey, value)) }
+	if err := g.goroutinePool.Submit(task); err != nil {
+		// 协程池已关闭，仍在锁外执行
+		go task()
+	}
+}